@@ -12,73 +12,112 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// @title Bike Stores API
+// @version 1.0
+// @BasePath /v1
 func NewApi(r *gin.Engine, cfg *config.Config, store storage.StorageI, cache storage.StorageCacheI, logger logger.LoggerI) {
 
-	handler := handler.NewHandler(cfg, store, cache, logger)
+	h := handler.NewHandler(cfg, store, cache, logger)
 
 	v1 := r.Group("/v1")
-	v1.Use(handler.AuthMiddleware())
-
-	r.POST("/register", handler.RegisterUser)
-	r.POST("/login", handler.LoginUser)
-
-	r.POST("/category", handler.CreateCategory)
-	r.GET("/category/:id", handler.GetByIdCategory)
-	r.GET("/category", handler.GetListCategory)
-	r.PUT("/category/:id", handler.UpdateCategory)
-	r.DELETE("/category/:id", handler.DeleteCategory)
-
-	r.POST("/brand", handler.CreateBrand)
-	r.GET("/brand/:id", handler.GetByIdBrand)
-	r.GET("/brand", handler.GetListBrand)
-	r.PUT("/brand/:id", handler.UpdateBrand)
-	r.DELETE("/brand/:id", handler.DeleteBrand)
-
-	r.POST("/product", handler.CreateProduct)
-	r.GET("/product/:id", handler.GetByIdProduct)
-	r.GET("/product", handler.GetListProduct)
-	r.PUT("/product/:id", handler.UpdateProduct)
-	r.DELETE("/product/:id", handler.DeleteProduct)
-
-	r.POST("/stock", handler.CreateStock)
-	r.GET("/stock/:id", handler.GetByIdStock)
-	r.GET("/stock", handler.GetListStock)
-	r.PUT("/stock", handler.UpdateStock)
-
-	r.POST("/store", handler.CreateStore)
-	r.GET("/store/:id", handler.GetByIdStore)
-	r.GET("/store", handler.GetListStore)
-	r.PUT("/store/:id", handler.UpdateStore)
-	r.DELETE("/store/:id", handler.DeleteStore)
-
-	r.POST("/customer", handler.CreateCustomer)
-	r.GET("/customer/:id", handler.GetByIdCustomer)
-	r.GET("/customer", handler.GetListCustomer)
-	r.PUT("/customer/:id", handler.UpdateCustomer)
-	r.DELETE("/customer/:id", handler.DeleteCustomer)
-
-	r.POST("/staff", handler.CreateStaff)
-	r.GET("/staff/:id", handler.GetByIdStaff)
-	r.GET("/staff", handler.GetListStaff)
-	r.PUT("/staff/:id", handler.UpdateStaff)
-	r.DELETE("/staff/:id", handler.DeleteStaff)
-
-	r.POST("/order", handler.AuthMiddleware(), handler.CreateOrder)
-	r.GET("/order/:id", handler.AuthMiddleware(), handler.GetByIdOrder)
-	r.GET("/order", handler.AuthMiddleware(), handler.GetListOrder)
-	r.PUT("/order/:id", handler.UpdateOrder)
-	r.DELETE("/order/:id", handler.DeleteOrder)
-	r.POST("/order_item", handler.CreateOrderItem)
-	r.DELETE("/order_item/:id", handler.DeleteOrderItem)
-
-	r.POST("/promocode", handler.CreatePromocode)
-	r.GET("/promocode/:id", handler.GetByIdPromocode)
-	r.GET("/promocode", handler.GetListPromocode)
-	r.DELETE("/promocode/:id", handler.DeletePromocode)
-
-	r.PUT("/report/send_product", handler.SendProductToStore)
-	r.GET("/report/staff_report", handler.GetListStaffReport)
-	r.GET("/report/total_sum", handler.OrderTotalSum)
+
+	v1.POST("/register", h.RegisterUser)
+	v1.POST("/login", h.LoginUser)
+	v1.POST("/refresh", h.RefreshToken)
+
+	secured := v1.Group("")
+	secured.Use(h.AuthMiddleware())
+
+	secured.POST("/logout", h.LogoutUser)
+	secured.PUT("/user/:id/role", h.RequirePermission(handler.PermUserManage), h.UpdateUserRole)
+	secured.PUT("/user/:id/staff", h.RequirePermission(handler.PermUserManage), h.UpdateUserStaff)
+
+	secured.POST("/category", h.RequirePermission(handler.PermCategoryCreate), h.CreateCategory)
+	secured.GET("/category/:id", h.RequirePermission(handler.PermCategoryRead), h.GetByIdCategory)
+	secured.GET("/category", h.RequirePermission(handler.PermCategoryRead), h.GetListCategory)
+	secured.PUT("/category/:id", h.RequirePermission(handler.PermCategoryUpdate), h.UpdateCategory)
+	secured.DELETE("/category/:id", h.RequirePermission(handler.PermCategoryDelete), h.DeleteCategory)
+
+	secured.POST("/brand", h.RequirePermission(handler.PermBrandCreate), h.CreateBrand)
+	secured.GET("/brand/:id", h.RequirePermission(handler.PermBrandRead), h.GetByIdBrand)
+	secured.GET("/brand", h.RequirePermission(handler.PermBrandRead), h.GetListBrand)
+	secured.PUT("/brand/:id", h.RequirePermission(handler.PermBrandUpdate), h.UpdateBrand)
+	secured.DELETE("/brand/:id", h.RequirePermission(handler.PermBrandDelete), h.DeleteBrand)
+
+	secured.POST("/product", h.RequirePermission(handler.PermProductCreate), h.CreateProduct)
+	secured.POST("/product/import", h.RequirePermission(handler.PermProductCreate), h.ImportProducts)
+	secured.GET("/product/:id", h.RequirePermission(handler.PermProductRead), h.GetByIdProduct)
+	secured.GET("/product", h.RequirePermission(handler.PermProductRead), h.GetListProduct)
+	secured.PUT("/product/:id", h.RequirePermission(handler.PermProductUpdate), h.UpdateProduct)
+	secured.DELETE("/product/:id", h.RequirePermission(handler.PermProductDelete), h.DeleteProduct)
+
+	secured.POST("/stock", h.RequirePermission(handler.PermStockCreate), h.CreateStock)
+	secured.POST("/stock/import", h.RequirePermission(handler.PermStockCreate), h.RequirePermission(handler.PermStockUpdate), h.ImportStocks)
+	secured.GET("/stock/:id", h.RequirePermission(handler.PermStockRead), h.GetByIdStock)
+	secured.GET("/stock", h.RequirePermission(handler.PermStockRead), h.GetListStock)
+	secured.PUT("/stock", h.RequirePermission(handler.PermStockUpdate), h.UpdateStock)
+
+	secured.POST("/store", h.RequirePermission(handler.PermStoreCreate), h.CreateStore)
+	secured.GET("/store/:id", h.RequirePermission(handler.PermStoreRead), h.GetByIdStore)
+	secured.GET("/store", h.RequirePermission(handler.PermStoreRead), h.GetListStore)
+	secured.PUT("/store/:id", h.RequirePermission(handler.PermStoreUpdate), h.UpdateStore)
+	secured.DELETE("/store/:id", h.RequirePermission(handler.PermStoreDelete), h.DeleteStore)
+
+	secured.POST("/customer", h.RequirePermission(handler.PermCustomerCreate), h.CreateCustomer)
+	secured.POST("/customer/import", h.RequirePermission(handler.PermCustomerCreate), h.ImportCustomers)
+	secured.GET("/customer/:id", h.RequirePermission(handler.PermCustomerRead), h.GetByIdCustomer)
+	secured.GET("/customer", h.RequirePermission(handler.PermCustomerRead), h.GetListCustomer)
+	secured.PUT("/customer/:id", h.RequirePermission(handler.PermCustomerUpdate), h.UpdateCustomer)
+	secured.DELETE("/customer/:id", h.RequirePermission(handler.PermCustomerDelete), h.DeleteCustomer)
+
+	secured.POST("/staff", h.RequirePermission(handler.PermStaffCreate), h.CreateStaff)
+	secured.GET("/staff/:id", h.RequirePermission(handler.PermStaffRead), h.GetByIdStaff)
+	secured.GET("/staff", h.RequirePermission(handler.PermStaffRead), h.GetListStaff)
+	secured.PUT("/staff/:id", h.RequirePermission(handler.PermStaffUpdate), h.UpdateStaff)
+	secured.DELETE("/staff/:id", h.RequirePermission(handler.PermStaffDelete), h.DeleteStaff)
+
+	secured.POST("/order", h.RequirePermission(handler.PermOrderCreate), h.CreateOrder)
+	secured.POST("/order/place", h.RequirePermission(handler.PermOrderCreate), h.PlaceOrder)
+	secured.GET("/order/:id", h.RequirePermission(handler.PermOrderRead), h.GetByIdOrder)
+	secured.GET("/order", h.RequirePermission(handler.PermOrderRead), h.GetListOrder)
+	secured.PUT("/order/:id", h.RequirePermission(handler.PermOrderUpdate), h.UpdateOrder)
+	secured.DELETE("/order/:id", h.RequirePermission(handler.PermOrderDelete), h.DeleteOrder)
+	secured.PUT("/order/:id/process", h.RequirePermission(handler.PermOrderUpdate), h.ProcessOrder)
+	secured.PUT("/order/:id/ship", h.RequirePermission(handler.PermOrderUpdate), h.ShipOrder)
+	secured.PUT("/order/:id/complete", h.RequirePermission(handler.PermOrderUpdate), h.CompleteOrder)
+	secured.PUT("/order/:id/reject", h.RequirePermission(handler.PermOrderReject), h.RejectOrder)
+	secured.PUT("/order/:id/cancel", h.RequirePermission(handler.PermOrderUpdate), h.CancelOrder)
+	secured.GET("/order/:id/history", h.RequirePermission(handler.PermOrderRead), h.GetOrderStatusHistory)
+	secured.POST("/order_item", h.RequirePermission(handler.PermOrderUpdate), h.CreateOrderItem)
+	secured.DELETE("/order_item/:id", h.RequirePermission(handler.PermOrderUpdate), h.DeleteOrderItem)
+
+	secured.POST("/promocode", h.RequirePermission(handler.PermPromocodeCreate), h.CreatePromocode)
+	secured.GET("/promocode/:id", h.RequirePermission(handler.PermPromocodeRead), h.GetByIdPromocode)
+	secured.GET("/promocode", h.RequirePermission(handler.PermPromocodeRead), h.GetListPromocode)
+	secured.DELETE("/promocode/:id", h.RequirePermission(handler.PermPromocodeDelete), h.DeletePromocode)
+
+	secured.POST("/supplier", h.RequirePermission(handler.PermSupplierCreate), h.CreateSupplier)
+	secured.GET("/supplier/:id", h.RequirePermission(handler.PermSupplierRead), h.GetByIdSupplier)
+	secured.GET("/supplier", h.RequirePermission(handler.PermSupplierRead), h.GetListSupplier)
+	secured.PUT("/supplier/:id", h.RequirePermission(handler.PermSupplierUpdate), h.UpdateSupplier)
+	secured.DELETE("/supplier/:id", h.RequirePermission(handler.PermSupplierDelete), h.DeleteSupplier)
+
+	secured.POST("/purchase_order", h.RequirePermission(handler.PermPurchaseOrderCreate), h.CreatePurchaseOrder)
+	secured.GET("/purchase_order/:id", h.RequirePermission(handler.PermPurchaseOrderRead), h.GetByIdPurchaseOrder)
+	secured.GET("/purchase_order", h.RequirePermission(handler.PermPurchaseOrderRead), h.GetListPurchaseOrder)
+	secured.DELETE("/purchase_order/:id", h.RequirePermission(handler.PermPurchaseOrderDelete), h.DeletePurchaseOrder)
+	secured.PUT("/purchase_order/:id/submit", h.RequirePermission(handler.PermPurchaseOrderUpdate), h.SubmitPurchaseOrder)
+	secured.PUT("/purchase_order/:id/cancel", h.RequirePermission(handler.PermPurchaseOrderUpdate), h.CancelPurchaseOrder)
+	secured.PUT("/purchase_order/:id/close", h.RequirePermission(handler.PermPurchaseOrderUpdate), h.ClosePurchaseOrder)
+	secured.POST("/purchase_order/:id/receive", h.RequirePermission(handler.PermPurchaseOrderReceive), h.ReceivePurchaseOrder)
+	secured.GET("/purchase_order/:id/history", h.RequirePermission(handler.PermPurchaseOrderRead), h.GetPurchaseOrderStatusHistory)
+
+	secured.PUT("/report/send_product", h.RequirePermission(handler.PermStockTransfer), h.SendProductToStore)
+	secured.GET("/report/staff_report", h.RequirePermission(handler.PermReportRead), h.GetListStaffReport)
+	secured.GET("/report/stock_reconciliation", h.RequirePermission(handler.PermReportRead), h.StockReconciliation)
+	secured.GET("/report/total_sum", h.RequirePermission(handler.PermOrderRead), h.OrderTotalSum)
+
+	secured.GET("/cache/stats", h.RequirePermission(handler.PermReportRead), h.GetCacheStats)
 
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
@@ -3,10 +3,15 @@ package handler
 import (
 	"app/api/models"
 	"app/pkg/helper"
+	"app/pkg/logger"
 	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
 // Create User godoc
@@ -18,8 +23,10 @@ import (
 // @Accept json
 // @Produce json
 // @Param User body models.CreateUser true "CreateUserRequest"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Success 201 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 409 {object} Response{data=string} "Conflict"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) RegisterUser(c *gin.Context) {
 	var createUser models.CreateUser
@@ -30,26 +37,49 @@ func (h *Handler) RegisterUser(c *gin.Context) {
 		return
 	}
 
+	if !helper.IsValidLogin(createUser.Username) {
+		h.handlerResponse(c, "create User", http.StatusBadRequest, "username must start with a letter and be 6-30 letters, digits or underscores")
+		return
+	}
+
+	err = helper.ValidPassword(createUser.Password, h.passwordPolicy())
+	if err != nil {
+		h.handlerResponse(c, "create User", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createUser.Password, err = helper.HashPassword(createUser.Password, h.cfg.BcryptCost)
+	if err != nil {
+		h.handleError(c, "hash password", err)
+		return
+	}
+
 	_, err = h.storages.User().Create(context.Background(), &createUser)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		h.handlerResponse(c, "create User", http.StatusConflict, "username is already taken")
+		return
+	}
 	if err != nil {
-		h.handlerResponse(c, "storage create User", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create User", err)
 		return
 	}
 
 	h.handlerResponse(c, "create User", http.StatusCreated, nil)
 }
 
-// Create User godoc
-// @ID create_User
+// Login User godoc
+// @ID login_User
 // @Router /login [POST]
-// @Summary Create User
-// @Description Create User
+// @Summary Login User
+// @Description Login User and get access token
 // @Tags User
 // @Accept json
 // @Produce json
-// @Param User body models.LoginUser true "CreateUserRequest"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Param User body models.LoginUser true "LoginUserRequest"
+// @Success 200 {object} Response{data=models.TokenResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 401 {object} Response{data=string} "Unauthorized"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) LoginUser(c *gin.Context) {
 	var loginUser models.LoginUser
@@ -60,23 +90,294 @@ func (h *Handler) LoginUser(c *gin.Context) {
 		return
 	}
 
-	exists, err := h.storages.User().GetById(context.Background(), &loginUser)
+	user, err := h.storages.User().GetById(context.Background(), &models.UserPrimaryKey{Username: loginUser.Username})
+	if errors.Is(err, pgx.ErrNoRows) {
+		helper.WastePasswordCheck(loginUser.Password, h.cfg.BcryptCost)
+		h.handlerResponse(c, "login User", http.StatusUnauthorized, "username or password invalid")
+		return
+	}
 	if err != nil {
-		h.handlerResponse(c, "storage login User", http.StatusBadRequest, err.Error())
+		h.handleError(c, "storage login User", err)
+		return
+	}
+
+	match, needsRehash := helper.CheckPassword(user.Password, loginUser.Password)
+	if !match {
+		h.handlerResponse(c, "login User", http.StatusUnauthorized, "username or password invalid")
+		return
+	}
+
+	// upgrade rows that still hold a plain text password
+	if needsRehash {
+		h.rehashPassword(user.UserId, loginUser.Password)
+	}
+
+	tokens, err := h.issueTokens(user)
+	if err != nil {
+		h.handleError(c, "generate token", err)
+		return
+	}
+
+	h.handlerResponse(c, "generated token", http.StatusOK, tokens)
+}
+
+// Refresh Token godoc
+// @ID refresh_token
+// @Router /refresh [POST]
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new access and refresh token pair
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param Token body models.RefreshTokenRequest true "RefreshTokenRequest"
+// @Success 200 {object} Response{data=models.TokenResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 401 {object} Response{data=string} "Unauthorized"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var refreshToken models.RefreshTokenRequest
+
+	err := c.ShouldBindJSON(&refreshToken)
+	if err != nil || refreshToken.RefreshToken == "" {
+		h.handlerResponse(c, "refresh token", http.StatusBadRequest, "refresh_token is required")
 		return
 	}
 
-	if !exists {
-		h.handlerResponse(c, "username or password invalid", http.StatusBadRequest, nil)
+	key := &models.RefreshTokenPrimaryKey{TokenHash: helper.HashToken(refreshToken.RefreshToken)}
+
+	token, err := h.storages.RefreshToken().GetById(context.Background(), key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		h.handlerResponse(c, "refresh token", http.StatusUnauthorized, "invalid refresh token")
+		return
+	}
+	if err != nil {
+		h.handleError(c, "storage.refreshToken.getById", err)
 		return
 	}
 
-	if exists {
-		tokenString, err := helper.MakeJWT(&loginUser)
+	// A revoked token being presented again means it has leaked: revoke the whole family.
+	if token.RevokedAt != "" {
+		_, err = h.storages.RefreshToken().RevokeByUser(context.Background(), &models.UserPrimaryKey{UserId: token.UserId})
 		if err != nil {
-			h.handlerResponse(c, "generate token", http.StatusBadRequest, err.Error())
+			h.handleError(c, "storage.refreshToken.revokeByUser", err)
+			return
+		}
+
+		h.handlerResponse(c, "refresh token", http.StatusUnauthorized, "refresh token has been revoked")
+		return
+	}
+
+	rowsAffected, err := h.storages.RefreshToken().Revoke(context.Background(), key)
+	if err != nil {
+		h.handleError(c, "storage.refreshToken.revoke", err)
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "refresh token", http.StatusUnauthorized, "refresh token is expired")
+		return
+	}
+
+	// load the user again so that role changes apply from the next access token
+	user, err := h.storages.User().GetById(context.Background(), &models.UserPrimaryKey{UserId: token.UserId})
+	if err != nil {
+		h.handleError(c, "storage.user.getById", err)
+		return
+	}
+
+	tokens, err := h.issueTokens(user)
+	if err != nil {
+		h.handleError(c, "generate token", err)
+		return
+	}
+
+	h.handlerResponse(c, "refresh token", http.StatusOK, tokens)
+}
+
+// Logout User godoc
+// @ID logout_User
+// @Router /logout [POST]
+// @Summary Logout User
+// @Description Revoke the current access token and the given refresh token
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param Token body models.LogoutUser false "LogoutUserRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 401 {object} Response{data=string} "Unauthorized"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) LogoutUser(c *gin.Context) {
+	var logoutUser models.LogoutUser
+
+	// the body is optional, the access token alone is enough to log out
+	_ = c.ShouldBindJSON(&logoutUser)
+
+	if logoutUser.RefreshToken != "" {
+		key := &models.RefreshTokenPrimaryKey{TokenHash: helper.HashToken(logoutUser.RefreshToken)}
+
+		token, err := h.storages.RefreshToken().GetById(context.Background(), key)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			h.handleError(c, "storage.refreshToken.getById", err)
 			return
 		}
-		h.handlerResponse(c, "generated token", http.StatusOK, tokenString)
+
+		if err == nil && token.UserId == c.GetString(ctxUserId) {
+			_, err = h.storages.RefreshToken().Revoke(context.Background(), key)
+			if err != nil {
+				h.handleError(c, "storage.refreshToken.revoke", err)
+				return
+			}
+		}
+	}
+
+	expiresAt := c.MustGet(ctxTokenExpiresAt).(time.Time)
+
+	err := h.cache.Token().Revoke(c.GetString(ctxTokenId), time.Until(expiresAt))
+	if err != nil {
+		h.handleError(c, "cache.token.revoke", err)
+		return
+	}
+
+	h.handlerResponse(c, "logout User", http.StatusOK, "Logged out")
+}
+
+func (h *Handler) rehashPassword(userId, password string) {
+
+	hash, err := helper.HashPassword(password, h.cfg.BcryptCost)
+	if err != nil {
+		h.logger.Error("rehash password", logger.Error(err))
+		return
+	}
+
+	_, err = h.storages.User().UpdatePassword(context.Background(), &models.UpdateUserPassword{
+		UserId:   userId,
+		Password: hash,
+	})
+	if err != nil {
+		h.logger.Error("storage.user.updatePassword", logger.Error(err))
+	}
+}
+
+func (h *Handler) passwordPolicy() helper.PasswordPolicy {
+	return helper.PasswordPolicy{
+		MinLength:      h.cfg.PasswordMinLength,
+		RequireDigit:   h.cfg.PasswordRequireDigit,
+		RequireUpper:   h.cfg.PasswordRequireUpper,
+		RequireSpecial: h.cfg.PasswordRequireSpecial,
+	}
+}
+
+func (h *Handler) issueTokens(user *models.User) (*models.TokenResponse, error) {
+
+	accessToken, err := helper.MakeJWT(user, h.cfg.SecretKey, h.cfg.TokenIssuer, h.cfg.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, tokenHash, err := helper.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.storages.RefreshToken().Create(context.Background(), &models.CreateRefreshToken{
+		TokenHash: tokenHash,
+		UserId:    user.UserId,
+		ExpiresIn: int64(h.cfg.RefreshTokenTTL.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(h.cfg.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// Update User Role godoc
+// @ID update_user_role
+// @Router /user/{id}/role [PUT]
+// @Summary Update User Role
+// @Description Update User Role
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param User body models.UpdateUserRole true "UpdateUserRoleRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	var updateUserRole models.UpdateUserRole
+
+	err := c.ShouldBindJSON(&updateUserRole)
+	if err != nil {
+		h.handlerResponse(c, "update user role", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !IsValidRole(updateUserRole.Role) {
+		h.handlerResponse(c, "update user role", http.StatusBadRequest, "invalid role")
+		return
+	}
+	updateUserRole.UserId = c.Param("id")
+
+	rowsAffected, err := h.storages.User().UpdateRole(context.Background(), &updateUserRole)
+	if err != nil {
+		h.handleError(c, "storage.user.updateRole", err)
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "storage.user.updateRole", http.StatusNotFound, "not found")
+		return
+	}
+
+	h.handlerResponse(c, "update user role", http.StatusOK, "Updated Successfully")
+}
+
+// Update User Staff godoc
+// @ID update_user_staff
+// @Router /user/{id}/staff [PUT]
+// @Summary Update User Staff
+// @Description Bind User to a staff member, staff_id 0 removes the binding
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param User body models.UpdateUserStaff true "UpdateUserStaffRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) UpdateUserStaff(c *gin.Context) {
+	var updateUserStaff models.UpdateUserStaff
+
+	err := c.ShouldBindJSON(&updateUserStaff)
+	if err != nil {
+		h.handlerResponse(c, "update user staff", http.StatusBadRequest, err.Error())
+		return
+	}
+	updateUserStaff.UserId = c.Param("id")
+
+	rowsAffected, err := h.storages.User().UpdateStaff(context.Background(), &updateUserStaff)
+	if err != nil {
+		h.handleError(c, "storage.user.updateStaff", err)
+		return
 	}
+
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "storage.user.updateStaff", http.StatusNotFound, "not found")
+		return
+	}
+
+	h.handlerResponse(c, "update user staff", http.StatusOK, "Updated Successfully")
 }
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"app/api/models"
+	"app/storage"
 	"context"
 	"net/http"
 	"strconv"
@@ -17,9 +18,11 @@ import (
 // @Tags Brand
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param Brand body models.CreateBrand true "CreateBrandRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateBrand(c *gin.Context) {
 	var createBrand models.CreateBrand
@@ -32,13 +35,13 @@ func (h *Handler) CreateBrand(c *gin.Context) {
 
 	id, err := h.storages.Brand().Create(context.Background(), &createBrand)
 	if err != nil {
-		h.handlerResponse(c, "storage create brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create brand", err)
 		return
 	}
 
 	brand, err := h.storages.Brand().GetById(context.Background(), &models.BrandPrimaryKey{BrandId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id brand", err)
 		return
 	}
 
@@ -53,9 +56,11 @@ func (h *Handler) CreateBrand(c *gin.Context) {
 // @Tags Brand
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"Order
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdBrand(c *gin.Context) {
 	id := c.Param("id")
@@ -67,7 +72,7 @@ func (h *Handler) GetByIdBrand(c *gin.Context) {
 
 	brand, err := h.storages.Brand().GetById(context.Background(), &models.BrandPrimaryKey{BrandId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id brand", err)
 		return
 	}
 
@@ -82,6 +87,7 @@ func (h *Handler) GetByIdBrand(c *gin.Context) {
 // @Tags Brand
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -107,7 +113,7 @@ func (h *Handler) GetListBrand(c *gin.Context) {
 		Search: c.Query("search"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage get list brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list brand", err)
 		return
 	}
 
@@ -122,10 +128,13 @@ func (h *Handler) GetListBrand(c *gin.Context) {
 // @Tags Brand
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Brand body models.UpdateBrand true "UpdateBrandRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateBrand(c *gin.Context) {
 	var updateBrand models.UpdateBrand
@@ -146,18 +155,19 @@ func (h *Handler) UpdateBrand(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Brand().Update(context.Background(), &updateBrand)
 	if err != nil {
-		h.handlerResponse(c, "Storage update brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update brand", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update brand", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update brand", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheBrandTag(idInt))
 
 	resp, err := h.storages.Brand().GetById(context.Background(), &models.BrandPrimaryKey{BrandId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id brand", err)
 		return
 	}
 
@@ -172,10 +182,12 @@ func (h *Handler) UpdateBrand(c *gin.Context) {
 // @Tags Brand
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Brand body models.BrandPrimaryKey true "DeleteBrandRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteBrand(c *gin.Context) {
 	id := c.Param("id")
@@ -187,14 +199,16 @@ func (h *Handler) DeleteBrand(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Brand().Delete(context.Background(), &models.BrandPrimaryKey{BrandId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete brand", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete brand", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete brand", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete brand", http.StatusNotFound, "not found")
 		return
 	}
+	// products of a deleted brand are deleted with it
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
 
 	h.handlerResponse(c, "Delete brand", http.StatusNoContent, "Deleted Successfully")
 }
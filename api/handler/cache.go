@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"app/api/models"
+	"app/pkg/logger"
+	"app/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// productPageTags lists what a cached product page depends on: any product change
+// can move it, a brand or category change only matters when one of its rows uses it
+// and a stock change only when the page is filtered on stock at that store.
+func productPageTags(req *models.GetListProductRequest, resp *models.GetListProductResponse) []string {
+	tags := []string{storage.ProductCacheTagAll}
+	if req.InStockStoreId > 0 {
+		tags = append(tags, storage.ProductCacheStoreTag(req.InStockStoreId))
+	}
+
+	seen := map[string]bool{}
+	for _, product := range resp.Products {
+		for _, tag := range []string{storage.ProductCacheBrandTag(product.BrandId), storage.ProductCacheCategoryTag(product.CategoryId)} {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// invalidateProductCache runs after a successful write, so a cache failure is logged
+// and left to the TTL instead of failing a request whose change is already committed.
+func (h *Handler) invalidateProductCache(path string, tags ...string) {
+	err := h.cache.Product().Invalidate(tags...)
+	if err != nil {
+		h.logger.Error(path, logger.Error(err))
+	}
+}
+
+// invalidateStockPages drops the product pages filtered on stock at the stores, it runs
+// after every write that changes their stock.
+func (h *Handler) invalidateStockPages(storeIds ...int) {
+	tags := make([]string, 0, len(storeIds))
+	for _, storeId := range storeIds {
+		tags = append(tags, storage.ProductCacheStoreTag(storeId))
+	}
+
+	h.invalidateProductCache("cache.product.invalidate", tags...)
+}
+
+// Get Cache Stats godoc
+// @ID get_cache_stats
+// @Router /cache/stats [GET]
+// @Summary Get Cache Stats
+// @Description Hit and miss counts of this instance since it started
+// @Tags Cache
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Success 200 {object} Response{data=models.GetCacheStatsResponse} "Success Request"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetCacheStats(c *gin.Context) {
+	h.handlerResponse(c, "Get cache stats", http.StatusOK, &models.GetCacheStatsResponse{
+		Product: h.cache.Product().Stats(),
+	})
+}
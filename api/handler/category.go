@@ -2,6 +2,7 @@ package handler
 
 import (
 	"app/api/models"
+	"app/storage"
 	"context"
 	"net/http"
 	"strconv"
@@ -17,9 +18,11 @@ import (
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param Category body models.CreateCategory true "CreateCategoryRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateCategory(c *gin.Context) {
 	var createCategory models.CreateCategory
@@ -32,13 +35,13 @@ func (h *Handler) CreateCategory(c *gin.Context) {
 
 	id, err := h.storages.Category().Create(context.Background(), &createCategory)
 	if err != nil {
-		h.handlerResponse(c, "storage create category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create category", err)
 		return
 	}
 
 	category, err := h.storages.Category().GetById(context.Background(), &models.CategoryPrimaryKey{CategoryId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id category", err)
 		return
 	}
 
@@ -53,9 +56,11 @@ func (h *Handler) CreateCategory(c *gin.Context) {
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdCategory(c *gin.Context) {
 	id := c.Param("id")
@@ -67,7 +72,7 @@ func (h *Handler) GetByIdCategory(c *gin.Context) {
 
 	category, err := h.storages.Category().GetById(context.Background(), &models.CategoryPrimaryKey{CategoryId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id category", err)
 		return
 	}
 
@@ -82,6 +87,7 @@ func (h *Handler) GetByIdCategory(c *gin.Context) {
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -107,7 +113,7 @@ func (h *Handler) GetListCategory(c *gin.Context) {
 		Search: c.Query("search"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage get list category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list category", err)
 		return
 	}
 
@@ -122,10 +128,13 @@ func (h *Handler) GetListCategory(c *gin.Context) {
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Category body models.UpdateCategory true "UpdateCategoryRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateCategory(c *gin.Context) {
 	var updateCategory models.UpdateCategory
@@ -146,18 +155,19 @@ func (h *Handler) UpdateCategory(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Category().Update(context.Background(), &updateCategory)
 	if err != nil {
-		h.handlerResponse(c, "Storage update category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update category", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update category", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update category", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheCategoryTag(idInt))
 
 	resp, err := h.storages.Category().GetById(context.Background(), &models.CategoryPrimaryKey{CategoryId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id category", err)
 		return
 	}
 
@@ -174,10 +184,12 @@ func (h *Handler) UpdateCategory(c *gin.Context) {
 // @Tags Category
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Category body models.CategoryPrimaryKey true "DeleteCategoryRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteCategory(c *gin.Context) {
 	id := c.Param("id")
@@ -189,14 +201,16 @@ func (h *Handler) DeleteCategory(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Category().Delete(context.Background(), &models.CategoryPrimaryKey{CategoryId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete category", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete category", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete category", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete category", http.StatusNotFound, "not found")
 		return
 	}
+	// products of a deleted category are deleted with it
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
 
 	h.handlerResponse(c, "Delete category", http.StatusNoContent, "Deleted Successfully")
 }
\ No newline at end of file
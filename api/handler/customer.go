@@ -17,9 +17,11 @@ import (
 // @Tags Customer
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param Customer body models.CreateCustomer true "CreateCustomerRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateCustomer(c *gin.Context) {
 	var createCustomer models.CreateCustomer
@@ -32,18 +34,18 @@ func (h *Handler) CreateCustomer(c *gin.Context) {
 
 	id, err := h.storages.Customer().Create(context.Background(), &createCustomer)
 	if err != nil {
-		h.handlerResponse(c, "storage create customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create customer", err)
 		return
 	}
 
 	customer, err := h.storages.Customer().GetById(context.Background(), &models.CustomerPrimaryKey{CustomerId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id customer", err)
 		return
 	}
 
 	h.handlerResponse(c, "create customer", http.StatusCreated, customer)
-} 
+}
 
 // Get By ID Customer godoc
 // @ID get_by_id_customer
@@ -53,9 +55,11 @@ func (h *Handler) CreateCustomer(c *gin.Context) {
 // @Tags Customer
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdCustomer(c *gin.Context) {
 	id := c.Param("id")
@@ -67,7 +71,7 @@ func (h *Handler) GetByIdCustomer(c *gin.Context) {
 
 	customer, err := h.storages.Customer().GetById(context.Background(), &models.CustomerPrimaryKey{CustomerId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id customer", err)
 		return
 	}
 
@@ -82,10 +86,12 @@ func (h *Handler) GetByIdCustomer(c *gin.Context) {
 // @Tags Customer
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Param after query string false "next_cursor of the previous page, offset is ignored"
+// @Success 200 {object} Response{data=models.GetListCustomerResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetListCustomer(c *gin.Context) {
@@ -103,11 +109,12 @@ func (h *Handler) GetListCustomer(c *gin.Context) {
 
 	resp, err := h.storages.Customer().GetList(context.Background(), &models.GetListCustomerRequest{
 		Offset: offset,
-		Limit: limit,
+		Limit:  limit,
 		Search: c.Query("search"),
+		After:  c.Query("after"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage get list customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list customer", err)
 		return
 	}
 
@@ -122,10 +129,13 @@ func (h *Handler) GetListCustomer(c *gin.Context) {
 // @Tags Customer
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Customer body models.UpdateCustomer true "UpdateCustomerRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateCustomer(c *gin.Context) {
 	var updateCustomer models.UpdateCustomer
@@ -140,24 +150,24 @@ func (h *Handler) UpdateCustomer(c *gin.Context) {
 	err = c.ShouldBindJSON(&updateCustomer)
 	if err != nil {
 		h.handlerResponse(c, "Update custoemr", http.StatusBadRequest, err.Error())
-		return 
+		return
 	}
 	updateCustomer.CustomerId = idInt
 
 	rowsAffected, err := h.storages.Customer().Update(context.Background(), &updateCustomer)
 	if err != nil {
-		h.handlerResponse(c, "Storage update customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update customer", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update customer", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update customer", http.StatusNotFound, "not found")
 		return
 	}
 
 	resp, err := h.storages.Customer().GetById(context.Background(), &models.CustomerPrimaryKey{CustomerId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id customer", err)
 		return
 	}
 
@@ -172,10 +182,12 @@ func (h *Handler) UpdateCustomer(c *gin.Context) {
 // @Tags Customer
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Customer body models.CustomerPrimaryKey true "DeleteCustomerRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteCustomer(c *gin.Context) {
 	id := c.Param("id")
@@ -187,14 +199,14 @@ func (h *Handler) DeleteCustomer(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Customer().Delete(context.Background(), &models.CustomerPrimaryKey{CustomerId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete customer", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete customer", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete customer", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete customer", http.StatusNotFound, "not found")
 		return
 	}
 
 	h.handlerResponse(c, "Delete customer", http.StatusNoContent, "Deleted Successfully")
-}
\ No newline at end of file
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"app/pkg/logger"
+	"app/storage"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ErrCodeBadRequest    = "bad_request"
+	ErrCodeUnauthorized  = "unauthorized"
+	ErrCodeForbidden     = "forbidden"
+	ErrCodeNotFound      = "not_found"
+	ErrCodeConflict      = "conflict"
+	ErrCodeUnprocessable = "unprocessable_entity"
+	ErrCodeInternal      = "internal_error"
+	ErrCodeUnavailable   = "service_unavailable"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgNotNullViolation    = "23502"
+)
+
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusUnprocessableEntity:
+		return ErrCodeUnprocessable
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	}
+
+	if status < 500 {
+		return ErrCodeBadRequest
+	}
+	return ErrCodeInternal
+}
+
+// handleError maps a storage error to a response. Unknown errors are logged with their
+// details but the client only gets a generic message.
+func (h *Handler) handleError(c *gin.Context, path string, err error) {
+
+	var pgErr *pgconn.PgError
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		h.handlerResponse(c, path, http.StatusNotFound, "not found")
+	case errors.Is(err, storage.ErrInvalidSort), errors.Is(err, storage.ErrInvalidCursor):
+		h.handlerResponse(c, path, http.StatusBadRequest, err.Error())
+	case errors.Is(err, storage.ErrInvalidStatusTransition), errors.Is(err, storage.ErrOrderClosed):
+		h.handlerResponse(c, path, http.StatusConflict, err.Error())
+	case errors.Is(err, storage.ErrInvalidQuantity), errors.Is(err, storage.ErrNotEnoughStock), errors.Is(err, storage.ErrOverReceived):
+		h.handlerResponse(c, path, http.StatusUnprocessableEntity, err.Error())
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		h.handlerResponse(c, path, http.StatusConflict, "already exists")
+	case errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation:
+		h.handlerResponse(c, path, http.StatusUnprocessableEntity, "referenced entity does not exist or is still in use")
+	case errors.As(err, &pgErr) && (pgErr.Code == pgCheckViolation || pgErr.Code == pgNotNullViolation):
+		h.handlerResponse(c, path, http.StatusUnprocessableEntity, "invalid value for "+pgErr.ColumnName)
+	case errors.Is(err, storage.ErrCacheUnavailable):
+		h.handlerResponse(c, path, http.StatusServiceUnavailable, err.Error())
+	default:
+		h.logger.Error(path, logger.Error(err))
+		h.handlerResponse(c, path, http.StatusInternalServerError, "internal server error")
+	}
+}
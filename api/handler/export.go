@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"app/api/models"
+	"app/pkg/logger"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const mimeCSV = "text/csv"
+
+// wantsCSV reports whether a list is asked for as CSV, with ?format=csv or an Accept header
+// preferring text/csv over JSON. It answers 400 and returns ok false for an unknown ?format=.
+func (h *Handler) wantsCSV(c *gin.Context, path string) (asCSV bool, ok bool) {
+	switch strings.ToLower(c.Query("format")) {
+	case "csv":
+		return true, true
+	case "json":
+		return false, true
+	case "":
+		return c.NegotiateFormat(gin.MIMEJSON, mimeCSV) == mimeCSV, true
+	}
+
+	h.handlerResponse(c, path, http.StatusBadRequest, "format must be json or csv")
+	return false, false
+}
+
+// csvFormulaPrefixes start a cell a spreadsheet would run as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCell prefixes a cell that would run as a formula with a quote, so a name such as
+// =HYPERLINK(...) opens as text. Numbers are left alone, a negative total stays a number.
+func escapeCSVCell(cell string) string {
+	if cell == "" || !strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// exportCSV streams the records export writes as a CSV attachment, every row goes out as it is
+// read from the storage, with cells that would run as a formula escaped. The status and header line are only sent with the first row, so an
+// export failing before it still gets a JSON error. A failure after it can only cut the file
+// short and is logged.
+func (h *Handler) exportCSV(c *gin.Context, path, name string, header []string, export func(write func(record []string) error) error) {
+	w := csv.NewWriter(c.Writer)
+
+	started := false
+	start := func() error {
+		started = true
+
+		c.Header("Content-Type", mimeCSV+"; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.csv"`, name, time.Now().Format("20060102")))
+		c.Status(http.StatusOK)
+
+		return w.Write(header)
+	}
+
+	err := export(func(record []string) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for i, cell := range record {
+			record[i] = escapeCSVCell(cell)
+		}
+		return w.Write(record)
+	})
+	if err != nil && !started {
+		h.handleError(c, path, err)
+		return
+	}
+
+	// an empty export is still a file with its header line
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	if err != nil {
+		h.logger.Error(path+": export cut short", logger.Error(err))
+	}
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+// formatListPrice formats a list price as the NUMERIC(10, 2) it is stored as, not as the
+// nearest float32.
+func formatListPrice(price float32) string {
+	return strconv.FormatFloat(float64(price), 'f', 2, 32)
+}
+
+var productCSVHeader = []string{"product_id", "product_name", "brand_id", "brand_name", "category_id", "category_name", "model_year", "list_price"}
+
+func productCSVRecord(product *models.Product) []string {
+	return []string{
+		strconv.Itoa(product.ProductId),
+		product.ProductName,
+		strconv.Itoa(product.BrandId),
+		product.BrandData.BrandName,
+		strconv.Itoa(product.CategoryId),
+		product.CategoryData.CategoryName,
+		strconv.Itoa(product.ModelYear),
+		formatListPrice(product.ListPrice),
+	}
+}
+
+var stockCSVHeader = []string{"store_id", "product_id", "product_name", "brand_name", "category_name", "model_year", "list_price", "quantity"}
+
+func stockCSVRecord(stock *models.Stock) []string {
+	return []string{
+		strconv.Itoa(stock.StoreId),
+		strconv.Itoa(stock.ProductId),
+		stock.ProductData.ProductName,
+		stock.ProductData.BrandData.BrandName,
+		stock.ProductData.CategoryData.CategoryName,
+		strconv.Itoa(stock.ProductData.ModelYear),
+		formatListPrice(stock.ProductData.ListPrice),
+		strconv.Itoa(stock.Quantity),
+	}
+}
+
+var orderCSVHeader = []string{
+	"order_id", "order_status", "order_date", "required_date", "shipped_date",
+	"customer_id", "customer_name", "customer_email", "store_id", "store_name", "staff_id", "staff_name",
+	"items", "quantity", "total",
+}
+
+// orderCSVRecord is one line per order, total is what the items sell for after their discount.
+func orderCSVRecord(order *models.Order) []string {
+	var (
+		quantity int
+		total    float64
+	)
+	for _, item := range order.OrderItems {
+		quantity += item.Quantity
+		total += float64(item.Quantity) * item.ListPrice * (1 - item.Discount)
+	}
+
+	return []string{
+		strconv.Itoa(order.OrderId),
+		models.OrderStatusName(order.OrderStatus),
+		order.OrderDate,
+		order.RequiredDate,
+		order.ShippedDate,
+		strconv.Itoa(order.CustomerId),
+		order.CustomerData.FirstName + " " + order.CustomerData.LastName,
+		order.CustomerData.Email,
+		strconv.Itoa(order.StoreId),
+		order.StoreData.StoreName,
+		strconv.Itoa(order.StaffId),
+		order.StaffData.FirstName + " " + order.StaffData.LastName,
+		strconv.Itoa(len(order.OrderItems)),
+		strconv.Itoa(quantity),
+		formatPrice(total),
+	}
+}
+
+var staffReportCSVHeader = []string{"staff_name", "store_name", "order_date", "category_name", "product_name", "quantity", "total_sum"}
+
+func staffReportCSVRecord(report *models.StaffReport) []string {
+	return []string{
+		report.StaffName,
+		report.StoreName,
+		report.OrderDate,
+		report.CategoryName,
+		report.ProductName,
+		strconv.Itoa(report.Quantity),
+		formatPrice(report.TotalSum),
+	}
+}
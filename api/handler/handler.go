@@ -4,6 +4,7 @@ import (
 	"app/config"
 	"app/pkg/logger"
 	"app/storage"
+	"fmt"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -13,13 +14,21 @@ type Handler struct {
 	cfg      *config.Config
 	logger   logger.LoggerI
 	storages storage.StorageI
-	cache storage.StorageCacheI
+	cache    storage.StorageCacheI
 }
 
 type Response struct {
-	Status      int
-	Description string
-	Data        interface{}
+	Status      int            `json:"status"`
+	Description string         `json:"description"`
+	Data        interface{}    `json:"data"`
+	Error       *ErrorResponse `json:"error,omitempty"`
+}
+
+// ErrorResponse is set on every response with a 4xx or 5xx status. Code is stable and
+// meant for clients to switch on, Message is for humans and may change.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 func NewHandler(cfg *config.Config, store storage.StorageI, cache storage.StorageCacheI, logger logger.LoggerI) *Handler {
@@ -27,18 +36,26 @@ func NewHandler(cfg *config.Config, store storage.StorageI, cache storage.Storag
 		cfg:      cfg,
 		logger:   logger,
 		storages: store,
-		cache: cache,
+		cache:    cache,
 	}
 }
 
 func (h *Handler) handlerResponse(c *gin.Context, path string, code int, message interface{}) {
 
 	response := Response{
-		Status: code,
-		Data:   message,
+		Status:      code,
+		Data:        message,
 		Description: path,
 	}
 
+	if code >= 400 {
+		response.Data = nil
+		response.Error = &ErrorResponse{
+			Code:    errorCode(code),
+			Message: fmt.Sprint(message),
+		}
+	}
+
 	switch {
 	case code < 300:
 		// h.logger.Info(path, logger.Any("info", response))
@@ -66,3 +83,23 @@ func (h *Handler) getLimitQuery(limit string) (int, error) {
 
 	return strconv.Atoi(limit)
 }
+
+// getIntQuery parses an optional integer query parameter, 0 when it is missing.
+func (h *Handler) getIntQuery(value string) (int, error) {
+
+	if len(value) <= 0 {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// getFloatQuery parses an optional decimal query parameter, 0 when it is missing.
+func (h *Handler) getFloatQuery(value string) (float64, error) {
+
+	if len(value) <= 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
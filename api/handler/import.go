@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"app/api/models"
+	"app/pkg/importer"
+	"app/storage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type importFunc func(ctx context.Context, store storage.StorageI, r io.Reader, opts importer.Options) (*models.ImportReport, error)
+
+// Import Products godoc
+// @ID import_products
+// @Router /product/import [POST]
+// @Summary Import Products
+// @Description Bulk create products from CSV with a header line (product_name, brand_id, category_id, model_year, list_price) or from NDJSON, one CreateProduct object per line. Nothing is imported when a row fails validation.
+// @Tags Product
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "csv or ndjson, defaults to the Content-Type"
+// @Param dry_run query bool false "only validate and report"
+// @Param file body string true "CSV or NDJSON rows"
+// @Success 200 {object} Response{data=models.ImportReport} "Dry run"
+// @Success 201 {object} Response{data=models.ImportReport} "Imported"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=models.ImportReport} "Rows with errors"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ImportProducts(c *gin.Context) {
+	report, ok := h.importRows(c, "import products", 0, importer.Products)
+	if ok && report.Imported > 0 {
+		h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
+	}
+}
+
+// Import Customers godoc
+// @ID import_customers
+// @Router /customer/import [POST]
+// @Summary Import Customers
+// @Description Bulk create customers from CSV with a header line (first_name, last_name, phone, email, street, city, state, zip_code) or from NDJSON, one CreateCustomer object per line. Nothing is imported when a row fails validation.
+// @Tags Customer
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "csv or ndjson, defaults to the Content-Type"
+// @Param dry_run query bool false "only validate and report"
+// @Param file body string true "CSV or NDJSON rows"
+// @Success 200 {object} Response{data=models.ImportReport} "Dry run"
+// @Success 201 {object} Response{data=models.ImportReport} "Imported"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=models.ImportReport} "Rows with errors"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ImportCustomers(c *gin.Context) {
+	h.importRows(c, "import customers", 0, importer.Customers)
+}
+
+// Import Stocks godoc
+// @ID import_stocks
+// @Router /stock/import [POST]
+// @Summary Import Stocks
+// @Description Set the quantity of store and product pairs from CSV with a header line (store_id, product_id, quantity) or from NDJSON, one CreateStock object per line. Pairs the store does not stock yet are added. Nothing is imported when a row fails validation.
+// @Tags Stock
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "csv or ndjson, defaults to the Content-Type"
+// @Param dry_run query bool false "only validate and report"
+// @Param file body string true "CSV or NDJSON rows"
+// @Success 200 {object} Response{data=models.ImportReport} "Dry run"
+// @Success 201 {object} Response{data=models.ImportReport} "Imported"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 422 {object} Response{data=models.ImportReport} "Rows with errors"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ImportStocks(c *gin.Context) {
+	storeId, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+
+	report, ok := h.importRows(c, "import stocks", storeId, importer.Stocks)
+	if !ok || report.Imported == 0 {
+		return
+	}
+
+	// unscoped imports can touch any store
+	if storeId > 0 {
+		h.invalidateStockPages(storeId)
+		return
+	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
+}
+
+// importRows runs an import on the request body and writes the report. It returns the report
+// and true when every row was valid.
+func (h *Handler) importRows(c *gin.Context, path string, storeId int, run importFunc) (*models.ImportReport, bool) {
+	opts := importer.Options{
+		Format:  importer.FormatOf(c.ContentType()),
+		MaxRows: h.cfg.ImportMaxRows,
+		StoreId: storeId,
+	}
+
+	if format := c.Query("format"); format != "" {
+		opts.Format = importer.FormatOf(format)
+	}
+	if opts.Format == "" {
+		h.handlerResponse(c, path, http.StatusBadRequest, "format must be csv or ndjson, set it with ?format= or the Content-Type")
+		return nil, false
+	}
+
+	if dryRun := c.Query("dry_run"); dryRun != "" {
+		var err error
+		opts.DryRun, err = strconv.ParseBool(dryRun)
+		if err != nil {
+			h.handlerResponse(c, path, http.StatusBadRequest, "dry_run must be true or false")
+			return nil, false
+		}
+	}
+
+	report, err := run(context.Background(), h.storages, c.Request.Body, opts)
+	if errors.Is(err, importer.ErrInvalidFile) {
+		h.handlerResponse(c, path, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	if err != nil {
+		h.handleError(c, "storage."+path, err)
+		return nil, false
+	}
+
+	// the report is the point of a failed import, so it goes out with the error
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, Response{
+			Status:      http.StatusUnprocessableEntity,
+			Description: path,
+			Data:        report,
+			Error: &ErrorResponse{
+				Code:    ErrCodeUnprocessable,
+				Message: fmt.Sprintf("%d errors, nothing was imported", len(report.Errors)),
+			},
+		})
+		return report, false
+	}
+
+	if report.DryRun {
+		h.handlerResponse(c, path, http.StatusOK, report)
+		return report, true
+	}
+
+	h.handlerResponse(c, path, http.StatusCreated, report)
+	return report, true
+}
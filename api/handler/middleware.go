@@ -1,24 +1,60 @@
 package handler
 
 import (
-	"errors"
-	"fmt"
+	"app/pkg/helper"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	ctxUserId         = "user_id"
+	ctxUsername       = "username"
+	ctxRole           = "role"
+	ctxStoreId        = "store_id"
+	ctxTokenId        = "token_id"
+	ctxTokenExpiresAt = "token_expires_at"
+)
+
 func (h *Handler) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		value := c.GetHeader("Password")
-		fmt.Println(value)
-		if value != h.cfg.SecretKey {
-			c.AbortWithError(http.StatusForbidden, errors.New("password invalid"))
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || len(parts[1]) == 0 {
+			h.handlerResponse(c, "auth middleware", http.StatusUnauthorized, "bearer token required")
+			c.Abort()
+			return
+		}
+
+		claims, err := helper.ParseJWT(parts[1], h.cfg.SecretKey, h.cfg.TokenIssuer)
+		if err != nil {
+			h.handlerResponse(c, "auth middleware", http.StatusUnauthorized, "invalid or expired token")
+			c.Abort()
+			return
+		}
+
+		revoked, err := h.cache.Token().IsRevoked(claims.Id)
+		if err != nil {
+			h.handleError(c, "cache.token.isRevoked", err)
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			h.handlerResponse(c, "auth middleware", http.StatusUnauthorized, "token has been revoked")
+			c.Abort()
 			return
 		}
 
+		c.Set(ctxUserId, claims.UserId)
+		c.Set(ctxUsername, claims.Username)
+		c.Set(ctxRole, claims.Role)
+		c.Set(ctxStoreId, claims.StoreId)
+		c.Set(ctxTokenId, claims.Id)
+		c.Set(ctxTokenExpiresAt, time.Unix(claims.ExpiresAt, 0))
+
 		c.Next()
 	}
 }
-	
\ No newline at end of file
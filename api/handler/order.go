@@ -2,6 +2,7 @@ package handler
 
 import (
 	"app/api/models"
+	"app/pkg/helper"
 	"context"
 	"net/http"
 	"strconv"
@@ -17,10 +18,11 @@ import (
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param Order body models.CreateOrder true "CreateOrderRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateOrder(c *gin.Context) {
 	var createOrder models.CreateOrder
@@ -31,21 +33,90 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+	if createOrder.StoreId == 0 {
+		createOrder.StoreId = scope
+	}
+	if !h.allowStore(c, createOrder.StoreId) {
+		return
+	}
+	createOrder.CreatedBy = c.GetString(ctxUserId)
+
 	id, err := h.storages.Order().Create(context.Background(), &createOrder)
 	if err != nil {
-		h.handlerResponse(c, "storage create order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create order", err)
 		return
 	}
 
 	staff, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id order", err)
 		return
 	}
 
 	h.handlerResponse(c, "create order", http.StatusCreated, staff)
 }
 
+// Place Order godoc
+// @ID place_order
+// @Router /order/place [POST]
+// @Summary Place Order
+// @Description Create an Order with all of its items and take them out of stock, all or nothing
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param Order body models.PlaceOrder true "PlaceOrderRequest"
+// @Success 201 {object} Response{data=models.Order} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) PlaceOrder(c *gin.Context) {
+	var placeOrder models.PlaceOrder
+
+	err := c.ShouldBindJSON(&placeOrder)
+	if err != nil {
+		h.handlerResponse(c, "place order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(placeOrder.Items) == 0 {
+		h.handlerResponse(c, "place order", http.StatusBadRequest, "order must have at least one item")
+		return
+	}
+
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+	if placeOrder.StoreId == 0 {
+		placeOrder.StoreId = scope
+	}
+	if !h.allowStore(c, placeOrder.StoreId) {
+		return
+	}
+	placeOrder.CreatedBy = c.GetString(ctxUserId)
+
+	id, err := h.storages.Order().Place(context.Background(), &placeOrder)
+	if err != nil {
+		h.handleError(c, "storage place order", err)
+		return
+	}
+	h.invalidateStockPages(placeOrder.StoreId)
+
+	resp, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: id})
+	if err != nil {
+		h.handleError(c, "storage get by id order", err)
+		return
+	}
+
+	h.handlerResponse(c, "place order", http.StatusCreated, resp)
+}
+
 // Get By ID Order godoc
 // @ID get_by_id_order
 // @Router /order/{id} [GET]
@@ -54,10 +125,11 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdOrder(c *gin.Context) {
 	id := c.Param("id")
@@ -69,7 +141,11 @@ func (h *Handler) GetByIdOrder(c *gin.Context) {
 
 	category, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id order", err)
+		return
+	}
+
+	if !h.allowStore(c, category.StoreId) {
 		return
 	}
 
@@ -84,13 +160,32 @@ func (h *Handler) GetByIdOrder(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Produce text/csv
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "json or csv, Accept: text/csv works too; csv has every matching row, offset, limit and after are ignored"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Param search query string false "customer name or email"
+// @Param customer_id query int false "customer_id"
+// @Param store_id query int false "store_id"
+// @Param staff_id query int false "staff_id"
+// @Param order_status query int false "1 pending, 2 processing, 3 rejected, 4 completed, 5 shipped, 6 cancelled"
+// @Param order_date_from query string false "YYYY-MM-DD"
+// @Param order_date_to query string false "YYYY-MM-DD"
+// @Param required_date_from query string false "YYYY-MM-DD"
+// @Param required_date_to query string false "YYYY-MM-DD"
+// @Param late query bool false "only orders shipped after their required date"
+// @Param sort query string false "comma separated order_id, order_date, required_date, shipped_date, order_status, customer_name; prefix - for descending"
+// @Param after query string false "next_cursor of the previous page, offset is ignored and sort is not allowed"
+// @Success 200 {object} Response{data=models.GetListOrderResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetListOrder(c *gin.Context) {
+	asCSV, ok := h.wantsCSV(c, "Get list order")
+	if !ok {
+		return
+	}
+
 	offset, err := h.getOffsetQuery(c.Query("offset"))
 	if err != nil {
 		h.handlerResponse(c, "Get list order", http.StatusBadRequest, "invalid offset")
@@ -103,12 +198,85 @@ func (h *Handler) GetListOrder(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.storages.Order().GetList(context.Background(), &models.GetListOrderRequest{
-		Offset: offset,
-		Limit:  limit,
-	})
+	req := &models.GetListOrderRequest{
+		Offset:           offset,
+		Limit:            limit,
+		Search:           c.Query("search"),
+		OrderDateFrom:    c.Query("order_date_from"),
+		OrderDateTo:      c.Query("order_date_to"),
+		RequiredDateFrom: c.Query("required_date_from"),
+		RequiredDateTo:   c.Query("required_date_to"),
+		Sort:             c.Query("sort"),
+		After:            c.Query("after"),
+	}
+
+	intParams := []struct {
+		name string
+		dst  *int
+	}{
+		{"customer_id", &req.CustomerId},
+		{"store_id", &req.StoreId},
+		{"staff_id", &req.StaffId},
+	}
+	for _, param := range intParams {
+		*param.dst, err = h.getIntQuery(c.Query(param.name))
+		if err != nil {
+			h.handlerResponse(c, "Get list order", http.StatusBadRequest, "invalid "+param.name)
+			return
+		}
+	}
+
+	status, err := h.getIntQuery(c.Query("order_status"))
+	if err != nil || status < 0 || status > int(models.OrderStatusCancelled) {
+		h.handlerResponse(c, "Get list order", http.StatusBadRequest, "invalid order_status")
+		return
+	}
+	req.OrderStatus = int16(status)
+
+	if len(c.Query("late")) > 0 {
+		req.Late, err = strconv.ParseBool(c.Query("late"))
+		if err != nil {
+			h.handlerResponse(c, "Get list order", http.StatusBadRequest, "invalid late")
+			return
+		}
+	}
+
+	for _, date := range []string{req.OrderDateFrom, req.OrderDateTo, req.RequiredDateFrom, req.RequiredDateTo} {
+		if len(date) > 0 && !helper.IsValidDate(date) {
+			h.handlerResponse(c, "Get list order", http.StatusBadRequest, "dates must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	if len(req.Sort) > 0 && len(req.After) > 0 {
+		h.handlerResponse(c, "Get list order", http.StatusBadRequest, "after cannot be combined with sort")
+		return
+	}
+
+	// store scoped users may only narrow the list to their own store
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+	if scope > 0 {
+		if req.StoreId > 0 && !h.allowStore(c, req.StoreId) {
+			return
+		}
+		req.StoreId = scope
+	}
+
+	if asCSV {
+		h.exportCSV(c, "Storage export order", "orders", orderCSVHeader, func(write func([]string) error) error {
+			return h.storages.Order().Export(context.Background(), req, func(order *models.Order) error {
+				return write(orderCSVRecord(order))
+			})
+		})
+		return
+	}
+
+	resp, err := h.storages.Order().GetList(context.Background(), req)
 	if err != nil {
-		h.handlerResponse(c, "Storage get list order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list order", err)
 		return
 	}
 
@@ -123,11 +291,14 @@ func (h *Handler) GetListOrder(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Order body models.UpdateOrder true "UpdateOrderRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateOrder(c *gin.Context) {
 	var updateOrder models.UpdateOrder
@@ -146,20 +317,24 @@ func (h *Handler) UpdateOrder(c *gin.Context) {
 	}
 	updateOrder.OrderId = idInt
 
+	if _, ok := h.allowOrder(c, idInt); !ok || !h.allowStore(c, updateOrder.StoreId) {
+		return
+	}
+
 	rowsAffected, err := h.storages.Order().Update(context.Background(), &updateOrder)
 	if err != nil {
-		h.handlerResponse(c, "Storage update order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update order", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update order", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update order", http.StatusNotFound, "not found")
 		return
 	}
 
 	resp, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id order", err)
 		return
 	}
 
@@ -174,11 +349,12 @@ func (h *Handler) UpdateOrder(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Order body models.OrderPrimaryKey true "DeleteOrderRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteOrder(c *gin.Context) {
 	id := c.Param("id")
@@ -188,20 +364,162 @@ func (h *Handler) DeleteOrder(c *gin.Context) {
 		return
 	}
 
+	order, ok := h.allowOrder(c, idInt)
+	if !ok {
+		return
+	}
+
 	rowsAffected, err := h.storages.Order().Delete(context.Background(), &models.OrderPrimaryKey{OrderId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete order", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete order", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete order", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete order", http.StatusNotFound, "not found")
 		return
 	}
+	if models.OrderStatusHoldsStock(order.OrderStatus) {
+		h.invalidateStockPages(order.StoreId)
+	}
 
 	h.handlerResponse(c, "Delete order", http.StatusNoContent, "Deleted Successfully")
 }
 
+// Process Order godoc
+// @ID process_order
+// @Router /order/{id}/process [PUT]
+// @Summary Process Order
+// @Description Move a pending Order to processing
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param Order body models.ChangeOrderStatus false "ChangeOrderStatusRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ProcessOrder(c *gin.Context) {
+	h.changeOrderStatus(c, models.OrderStatusProcessing)
+}
+
+// Ship Order godoc
+// @ID ship_order
+// @Router /order/{id}/ship [PUT]
+// @Summary Ship Order
+// @Description Mark a processing Order as shipped and stamp its shipped date
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param Order body models.ChangeOrderStatus false "ChangeOrderStatusRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ShipOrder(c *gin.Context) {
+	h.changeOrderStatus(c, models.OrderStatusShipped)
+}
+
+// Complete Order godoc
+// @ID complete_order
+// @Router /order/{id}/complete [PUT]
+// @Summary Complete Order
+// @Description Mark a shipped Order as completed
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param Order body models.ChangeOrderStatus false "ChangeOrderStatusRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) CompleteOrder(c *gin.Context) {
+	h.changeOrderStatus(c, models.OrderStatusCompleted)
+}
+
+// Reject Order godoc
+// @ID reject_order
+// @Router /order/{id}/reject [PUT]
+// @Summary Reject Order
+// @Description Reject a pending or processing Order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param Order body models.ChangeOrderStatus false "ChangeOrderStatusRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) RejectOrder(c *gin.Context) {
+	h.changeOrderStatus(c, models.OrderStatusRejected)
+}
+
+// Cancel Order godoc
+// @ID cancel_order
+// @Router /order/{id}/cancel [PUT]
+// @Summary Cancel Order
+// @Description Cancel a pending or processing Order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param Order body models.ChangeOrderStatus false "ChangeOrderStatusRequest"
+// @Success 200 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) CancelOrder(c *gin.Context) {
+	h.changeOrderStatus(c, models.OrderStatusCancelled)
+}
+
+// Get Order Status History godoc
+// @ID get_order_status_history
+// @Router /order/{id}/history [GET]
+// @Summary Get Order Status History
+// @Description Get Order Status History
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 200 {object} Response{data=models.GetOrderStatusHistoryResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetOrderStatusHistory(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err order status history", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := h.allowOrder(c, idInt); !ok {
+		return
+	}
+
+	resp, err := h.storages.Order().GetStatusHistory(context.Background(), &models.OrderPrimaryKey{OrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage order status history", err)
+		return
+	}
+
+	h.handlerResponse(c, "Order status history", http.StatusOK, resp)
+}
+
 // Create Order Item godoc
 // @ID create_order_item
 // @Router /order_item [POST]
@@ -210,10 +528,11 @@ func (h *Handler) DeleteOrder(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param order_item body models.CreateOrderItem true "CreateOrderItemRequest"
 // @Success 201 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateOrderItem(c *gin.Context) {
 
@@ -225,17 +544,17 @@ func (h *Handler) CreateOrderItem(c *gin.Context) {
 		return
 	}
 
-	err = h.storages.Order().CheckStock(context.Background(), &createOrderItem)
-	if err != nil {
-		h.handlerResponse(c, "Check stock", http.StatusBadRequest, err.Error())
+	order, ok := h.allowOrder(c, createOrderItem.OrderId)
+	if !ok {
 		return
 	}
 
 	err = h.storages.Order().AddOrderItem(context.Background(), &createOrderItem)
 	if err != nil {
-		h.handlerResponse(c, "Storage order create item", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage order create item", err)
 		return
 	}
+	h.invalidateStockPages(order.StoreId)
 
 	h.handlerResponse(c, "Create order item", http.StatusCreated, "Added Successfully")
 }
@@ -248,12 +567,13 @@ func (h *Handler) CreateOrderItem(c *gin.Context) {
 // @Tags Order
 // @Accept json
 // @Produce json
-// @Param Password header string true "Password"
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param item_id query string true "item_id"
 // @Param orderItem body models.OrderItemPrimaryKey true "DeleteOrderItemRequest"
 // @Success 204 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteOrderItem(c *gin.Context) {
 
@@ -272,16 +592,81 @@ func (h *Handler) DeleteOrderItem(c *gin.Context) {
 		return
 	}
 
+	order, ok := h.allowOrder(c, idInt)
+	if !ok {
+		return
+	}
+
 	rowsAffected, err := h.storages.Order().RemoveOrderItem(context.Background(), &models.OrderItemPrimaryKey{OrderId: idInt, ItemId: idItemInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage order delete", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage order delete", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage order item delete", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "Storage order item delete", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateStockPages(order.StoreId)
 
 	h.handlerResponse(c, "Delete order", http.StatusNoContent, "Deleted succesfully")
 }
+
+// allowOrder checks that the caller may change the order, based on the store the order belongs to,
+// and returns the order it loaded.
+func (h *Handler) allowOrder(c *gin.Context, orderId int) (*models.Order, bool) {
+
+	order, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: orderId})
+	if err != nil {
+		h.handleError(c, "Storage get by id order", err)
+		return nil, false
+	}
+
+	return order, h.allowStore(c, order.StoreId)
+}
+
+func (h *Handler) changeOrderStatus(c *gin.Context, status int16) {
+	var changeStatus models.ChangeOrderStatus
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err change order status", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// the body only carries an optional comment
+	if c.Request.ContentLength > 0 {
+		err = c.ShouldBindJSON(&changeStatus)
+		if err != nil {
+			h.handlerResponse(c, "Change order status", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	changeStatus.OrderId = idInt
+	changeStatus.Status = status
+	changeStatus.ChangedBy = c.GetString(ctxUserId)
+
+	order, ok := h.allowOrder(c, idInt)
+	if !ok {
+		return
+	}
+
+	err = h.storages.Order().ChangeStatus(context.Background(), &changeStatus)
+	if err != nil {
+		h.handleError(c, "Storage change order status", err)
+		return
+	}
+
+	// cancelling and rejecting give the items back to the store
+	if status == models.OrderStatusCancelled || status == models.OrderStatusRejected {
+		h.invalidateStockPages(order.StoreId)
+	}
+
+	resp, err := h.storages.Order().GetById(context.Background(), &models.OrderPrimaryKey{OrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage get by id order", err)
+		return
+	}
+
+	h.handlerResponse(c, "Change order status", http.StatusOK, resp)
+}
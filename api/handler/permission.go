@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"app/api/models"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Permission string
+
+const (
+	PermCategoryRead   Permission = "category:read"
+	PermCategoryCreate Permission = "category:create"
+	PermCategoryUpdate Permission = "category:update"
+	PermCategoryDelete Permission = "category:delete"
+
+	PermBrandRead   Permission = "brand:read"
+	PermBrandCreate Permission = "brand:create"
+	PermBrandUpdate Permission = "brand:update"
+	PermBrandDelete Permission = "brand:delete"
+
+	PermProductRead   Permission = "product:read"
+	PermProductCreate Permission = "product:create"
+	PermProductUpdate Permission = "product:update"
+	PermProductDelete Permission = "product:delete"
+
+	PermStockRead     Permission = "stock:read"
+	PermStockCreate   Permission = "stock:create"
+	PermStockUpdate   Permission = "stock:update"
+	PermStockTransfer Permission = "stock:transfer"
+
+	PermStoreRead   Permission = "store:read"
+	PermStoreCreate Permission = "store:create"
+	PermStoreUpdate Permission = "store:update"
+	PermStoreDelete Permission = "store:delete"
+
+	PermCustomerRead   Permission = "customer:read"
+	PermCustomerCreate Permission = "customer:create"
+	PermCustomerUpdate Permission = "customer:update"
+	PermCustomerDelete Permission = "customer:delete"
+
+	PermStaffRead   Permission = "staff:read"
+	PermStaffCreate Permission = "staff:create"
+	PermStaffUpdate Permission = "staff:update"
+	PermStaffDelete Permission = "staff:delete"
+
+	PermOrderRead   Permission = "order:read"
+	PermOrderCreate Permission = "order:create"
+	PermOrderUpdate Permission = "order:update"
+	PermOrderDelete Permission = "order:delete"
+	PermOrderReject Permission = "order:reject"
+
+	PermPromocodeRead   Permission = "promocode:read"
+	PermPromocodeCreate Permission = "promocode:create"
+	PermPromocodeDelete Permission = "promocode:delete"
+
+	PermSupplierRead   Permission = "supplier:read"
+	PermSupplierCreate Permission = "supplier:create"
+	PermSupplierUpdate Permission = "supplier:update"
+	PermSupplierDelete Permission = "supplier:delete"
+
+	PermPurchaseOrderRead    Permission = "purchase_order:read"
+	PermPurchaseOrderCreate  Permission = "purchase_order:create"
+	PermPurchaseOrderUpdate  Permission = "purchase_order:update"
+	PermPurchaseOrderDelete  Permission = "purchase_order:delete"
+	PermPurchaseOrderReceive Permission = "purchase_order:receive"
+
+	PermReportRead Permission = "report:read"
+
+	PermUserManage Permission = "user:manage"
+)
+
+var readPermissions = []Permission{
+	PermCategoryRead,
+	PermBrandRead,
+	PermProductRead,
+	PermStockRead,
+	PermStoreRead,
+	PermCustomerRead,
+	PermStaffRead,
+	PermOrderRead,
+	PermPromocodeRead,
+	PermSupplierRead,
+	PermPurchaseOrderRead,
+}
+
+// rolePermissions is the permission matrix. Admins are not listed: they hold every permission.
+var rolePermissions = map[string]map[Permission]bool{
+	models.RoleReadOnly: permissionSet(readPermissions),
+	models.RoleCashier: permissionSet(readPermissions,
+		PermCustomerCreate,
+		PermCustomerUpdate,
+		PermOrderCreate,
+		PermOrderUpdate,
+	),
+	models.RoleStoreManager: permissionSet(readPermissions,
+		PermProductCreate,
+		PermProductUpdate,
+		PermStockCreate,
+		PermStockUpdate,
+		PermStockTransfer,
+		PermStaffCreate,
+		PermStaffUpdate,
+		PermCustomerCreate,
+		PermCustomerUpdate,
+		PermCustomerDelete,
+		PermOrderCreate,
+		PermOrderUpdate,
+		PermOrderReject,
+		PermOrderDelete,
+		PermPromocodeCreate,
+		PermPurchaseOrderCreate,
+		PermPurchaseOrderUpdate,
+		PermPurchaseOrderReceive,
+		PermReportRead,
+	),
+}
+
+func permissionSet(base []Permission, extra ...Permission) map[Permission]bool {
+	set := make(map[Permission]bool, len(base)+len(extra))
+	for _, p := range base {
+		set[p] = true
+	}
+	for _, p := range extra {
+		set[p] = true
+	}
+	return set
+}
+
+func IsValidRole(role string) bool {
+	if role == models.RoleAdmin {
+		return true
+	}
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func HasPermission(role string, permission Permission) bool {
+	if role == models.RoleAdmin {
+		return true
+	}
+	return rolePermissions[role][permission]
+}
+
+// RequirePermission must run after AuthMiddleware, which puts the caller's role into the context.
+func (h *Handler) RequirePermission(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		if !HasPermission(c.GetString(ctxRole), permission) {
+			h.handlerResponse(c, "permission middleware", http.StatusForbidden, fmt.Sprintf("permission %s is required", permission))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// storeScopedRoles only see and change the data of the store their staff record belongs to.
+var storeScopedRoles = map[string]bool{
+	models.RoleStoreManager: true,
+	models.RoleCashier:      true,
+}
+
+// storeScope returns the store the caller is restricted to, or 0 when the caller may work with
+// every store. A store scoped caller that is not bound to a staff member gets a 403 and ok is false.
+func (h *Handler) storeScope(c *gin.Context) (storeId int, ok bool) {
+
+	if !storeScopedRoles[c.GetString(ctxRole)] {
+		return 0, true
+	}
+
+	storeId = c.GetInt(ctxStoreId)
+	if storeId <= 0 {
+		h.handlerResponse(c, "store scope", http.StatusForbidden, "user is not bound to a staff member")
+		return 0, false
+	}
+
+	return storeId, true
+}
+
+// allowStore writes a 403 and returns false when the caller may not touch the given store.
+func (h *Handler) allowStore(c *gin.Context, storeId int) bool {
+
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return false
+	}
+
+	if scope > 0 && scope != storeId {
+		h.handlerResponse(c, "store scope", http.StatusForbidden, fmt.Sprintf("access to store %d is not allowed", storeId))
+		return false
+	}
+
+	return true
+}
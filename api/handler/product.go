@@ -2,8 +2,10 @@ package handler
 
 import (
 	"app/api/models"
+	"app/pkg/logger"
+	"app/storage"
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -18,9 +20,11 @@ import (
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param product body models.CreateProduct true "CreateProductRequest"
 // @Success 201 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateProduct(c *gin.Context) {
 
@@ -34,13 +38,14 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 
 	id, err := h.storages.Product().Create(context.Background(), &createProduct)
 	if err != nil {
-		h.handlerResponse(c, "storage.product.create", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.create", err)
 		return
 	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
 
 	resp, err := h.storages.Product().GetById(context.Background(), &models.ProductPrimaryKey{ProductId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage.product.getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.getByID", err)
 		return
 	}
 
@@ -55,9 +60,11 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdProduct(c *gin.Context) {
 
@@ -71,7 +78,7 @@ func (h *Handler) GetByIdProduct(c *gin.Context) {
 
 	resp, err := h.storages.Product().GetById(context.Background(), &models.ProductPrimaryKey{ProductId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "storage.product.getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.getByID", err)
 		return
 	}
 
@@ -86,13 +93,29 @@ func (h *Handler) GetByIdProduct(c *gin.Context) {
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "json or csv, Accept: text/csv works too; csv has every matching row, offset, limit and after are ignored"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Param brand_id query int false "brand_id"
+// @Param category_id query int false "category_id"
+// @Param model_year_from query int false "model_year_from"
+// @Param model_year_to query int false "model_year_to"
+// @Param price_from query number false "price_from"
+// @Param price_to query number false "price_to"
+// @Param in_stock_store_id query int false "only products in stock at this store"
+// @Param sort query string false "comma separated product_id, product_name, brand_name, category_name, model_year, list_price; prefix - for descending"
+// @Param after query string false "next_cursor of the previous page, offset is ignored and sort is not allowed"
+// @Success 200 {object} Response{data=models.GetListProductResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetListProduct(c *gin.Context) {
+	asCSV, ok := h.wantsCSV(c, "get list product")
+	if !ok {
+		return
+	}
 
 	offset, err := h.getOffsetQuery(c.Query("offset"))
 	if err != nil {
@@ -106,42 +129,96 @@ func (h *Handler) GetListProduct(c *gin.Context) {
 		return
 	}
 
-	r := fmt.Sprintf("%v-%v-%s", offset, limit, c.Query("search"))
+	req := &models.GetListProductRequest{
+		Offset: offset,
+		Limit:  limit,
+		Search: c.Query("search"),
+		Sort:   c.Query("sort"),
+		After:  c.Query("after"),
+	}
 
-	exists, err := h.cache.Product().Exists(r)
-	if err != nil {
-		h.handlerResponse(c, "cache.product.exists", http.StatusInternalServerError, err.Error())
+	if len(req.Sort) > 0 && len(req.After) > 0 {
+		h.handlerResponse(c, "get list product", http.StatusBadRequest, "after cannot be combined with sort")
 		return
 	}
 
-	if exists {
-		resp, err := h.cache.Product().GetAll(r)
+	intParams := []struct {
+		name string
+		dst  *int
+	}{
+		{"brand_id", &req.BrandId},
+		{"category_id", &req.CategoryId},
+		{"model_year_from", &req.ModelYearFrom},
+		{"model_year_to", &req.ModelYearTo},
+		{"in_stock_store_id", &req.InStockStoreId},
+	}
+	for _, param := range intParams {
+		*param.dst, err = h.getIntQuery(c.Query(param.name))
 		if err != nil {
-			h.handlerResponse(c, "cache.product.get all", http.StatusInternalServerError, err.Error())
+			h.handlerResponse(c, "get list product", http.StatusBadRequest, "invalid "+param.name)
 			return
 		}
+	}
 
-		h.handlerResponse(c, "get list product response from redis", http.StatusOK, resp)
+	req.PriceFrom, err = h.getFloatQuery(c.Query("price_from"))
+	if err != nil {
+		h.handlerResponse(c, "get list product", http.StatusBadRequest, "invalid price_from")
 		return
 	}
 
-	resp, err := h.storages.Product().GetList(context.Background(), &models.GetListProductRequest{
-		Offset: offset,
-		Limit:  limit,
-		Search: c.Query("search"),
-	})
+	req.PriceTo, err = h.getFloatQuery(c.Query("price_to"))
+	if err != nil {
+		h.handlerResponse(c, "get list product", http.StatusBadRequest, "invalid price_to")
+		return
+	}
 
-	err = h.cache.Product().Create(r, resp)
+	if req.ModelYearTo > 0 && req.ModelYearFrom > req.ModelYearTo {
+		h.handlerResponse(c, "get list product", http.StatusBadRequest, "model_year_from is after model_year_to")
+		return
+	}
+	if req.PriceTo > 0 && req.PriceFrom > req.PriceTo {
+		h.handlerResponse(c, "get list product", http.StatusBadRequest, "price_from is greater than price_to")
+		return
+	}
+
+	// exports are read straight from the storage, the cache only holds pages
+	if asCSV {
+		h.exportCSV(c, "storage.product.export", "products", productCSVHeader, func(write func([]string) error) error {
+			return h.storages.Product().Export(context.Background(), req, func(product *models.Product) error {
+				return write(productCSVRecord(product))
+			})
+		})
+		return
+	}
+
+	r, err := json.Marshal(req)
 	if err != nil {
-		h.handlerResponse(c, "cache.product.create", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "get list product cache key", err)
 		return
 	}
 
+	// the cache only saves a query, when it fails the list is read from postgres
+	cached, ok, err := h.cache.Product().Get(string(r))
 	if err != nil {
-		h.handlerResponse(c, "storage.product.getlist", http.StatusInternalServerError, err.Error())
+		h.logger.Error("cache.product.get", logger.Error(err))
+	}
+
+	if ok {
+		h.handlerResponse(c, "get list product response from redis", http.StatusOK, cached)
+		return
+	}
+
+	resp, err := h.storages.Product().GetList(context.Background(), req)
+	if err != nil {
+		h.handleError(c, "storage.product.getlist", err)
 		return
 	}
 
+	err = h.cache.Product().Set(string(r), resp, productPageTags(req, resp))
+	if err != nil {
+		h.logger.Error("cache.product.set", logger.Error(err))
+	}
+
 	h.handlerResponse(c, "get list product response from postgres", http.StatusOK, resp)
 }
 
@@ -153,10 +230,13 @@ func (h *Handler) GetListProduct(c *gin.Context) {
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param product body models.UpdateProduct true "UpdateProductRequest"
 // @Success 202 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateProduct(c *gin.Context) {
 
@@ -180,18 +260,19 @@ func (h *Handler) UpdateProduct(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Product().Update(context.Background(), &updateProduct)
 	if err != nil {
-		h.handlerResponse(c, "storage.product.update", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.update", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "storage.product.update", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "storage.product.update", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
 
 	resp, err := h.storages.Product().GetById(context.Background(), &models.ProductPrimaryKey{ProductId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "storage.product.getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.getByID", err)
 		return
 	}
 
@@ -206,10 +287,12 @@ func (h *Handler) UpdateProduct(c *gin.Context) {
 // @Tags Product
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param product body models.ProductPrimaryKey true "DeleteProductRequest"
 // @Success 204 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteProduct(c *gin.Context) {
 
@@ -223,13 +306,14 @@ func (h *Handler) DeleteProduct(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Product().Delete(context.Background(), &models.ProductPrimaryKey{ProductId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "storage.product.delete", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage.product.delete", err)
 		return
 	}
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "storage.product.delete", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "storage.product.delete", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateProductCache("cache.product.invalidate", storage.ProductCacheTagAll)
 
 	h.handlerResponse(c, "delete product", http.StatusNoContent, nil)
 }
@@ -17,9 +17,11 @@ import (
 // @Tags Promocode
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param Promocode body models.CreatePromocode true "CreatePromocodeRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreatePromocode(c *gin.Context) {
 	var createPromocode models.CreatePromocode
@@ -32,13 +34,13 @@ func (h *Handler) CreatePromocode(c *gin.Context) {
 
 	id, err := h.storages.Promocode().Create(context.Background(), &createPromocode)
 	if err != nil {
-		h.handlerResponse(c, "storage create promocode", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create promocode", err)
 		return
 	}
 
 	promocode, err := h.storages.Promocode().GetById(context.Background(), &models.PromocodePrimaryKey{PromocodeId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id promocode", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id promocode", err)
 		return
 	}
 
@@ -53,9 +55,11 @@ func (h *Handler) CreatePromocode(c *gin.Context) {
 // @Tags Promocode
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdPromocode(c *gin.Context) {
 	id := c.Param("id")
@@ -67,7 +71,7 @@ func (h *Handler) GetByIdPromocode(c *gin.Context) {
 
 	promocode, err := h.storages.Promocode().GetById(context.Background(), &models.PromocodePrimaryKey{PromocodeId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id promocode", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id promocode", err)
 		return
 	}
 
@@ -82,6 +86,7 @@ func (h *Handler) GetByIdPromocode(c *gin.Context) {
 // @Tags Promocode
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -107,7 +112,7 @@ func (h *Handler) GetListPromocode(c *gin.Context) {
 		Search: c.Query("search"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage get list promocode", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list promocode", err)
 		return
 	}
 
@@ -122,10 +127,12 @@ func (h *Handler) GetListPromocode(c *gin.Context) {
 // @Tags Promocode
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Promocode body models.PromocodePrimaryKey true "DeletePromocodeRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeletePromocode(c *gin.Context) {
 	id := c.Param("id")
@@ -137,12 +144,12 @@ func (h *Handler) DeletePromocode(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Promocode().Delete(context.Background(), &models.PromocodePrimaryKey{PromocodeId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete promocode", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete promocode", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete promocode", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete promocode", http.StatusNotFound, "not found")
 		return
 	}
 
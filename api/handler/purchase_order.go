@@ -0,0 +1,481 @@
+package handler
+
+import (
+	"app/api/models"
+	"app/pkg/helper"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Purchase Order godoc
+// @ID create_purchase_order
+// @Router /purchase_order [POST]
+// @Summary Create Purchase Order
+// @Description Create a draft Purchase Order with all of its items, every item is delivered to one store
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param purchase_order body models.CreatePurchaseOrder true "CreatePurchaseOrderRequest"
+// @Success 201 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) CreatePurchaseOrder(c *gin.Context) {
+	var createPurchaseOrder models.CreatePurchaseOrder
+
+	err := c.ShouldBindJSON(&createPurchaseOrder)
+	if err != nil {
+		h.handlerResponse(c, "create purchase order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(createPurchaseOrder.Items) == 0 {
+		h.handlerResponse(c, "create purchase order", http.StatusBadRequest, "purchase order must have at least one item")
+		return
+	}
+	if len(createPurchaseOrder.ExpectedDate) > 0 && !helper.IsValidDate(createPurchaseOrder.ExpectedDate) {
+		h.handlerResponse(c, "create purchase order", http.StatusBadRequest, "expected_date must be YYYY-MM-DD")
+		return
+	}
+
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+	for _, item := range createPurchaseOrder.Items {
+		if item == nil {
+			h.handlerResponse(c, "create purchase order", http.StatusBadRequest, "items must not be null")
+			return
+		}
+		if item.StoreId == 0 {
+			item.StoreId = scope
+		}
+		if !h.allowStore(c, item.StoreId) {
+			return
+		}
+	}
+	createPurchaseOrder.CreatedBy = c.GetString(ctxUserId)
+
+	id, err := h.storages.PurchaseOrder().Create(context.Background(), &createPurchaseOrder)
+	if err != nil {
+		h.handleError(c, "storage create purchase order", err)
+		return
+	}
+
+	resp, err := h.storages.PurchaseOrder().GetById(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	if err != nil {
+		h.handleError(c, "storage get by id purchase order", err)
+		return
+	}
+
+	h.handlerResponse(c, "create purchase order", http.StatusCreated, resp)
+}
+
+// Get By ID Purchase Order godoc
+// @ID get_by_id_purchase_order
+// @Router /purchase_order/{id} [GET]
+// @Summary Get By ID Purchase Order
+// @Description Get By ID Purchase Order
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 200 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetByIdPurchaseOrder(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err get by id purchase order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, ok := h.allowPurchaseOrder(c, idInt, false)
+	if !ok {
+		return
+	}
+
+	h.handlerResponse(c, "Get by id purchase order", http.StatusOK, resp)
+}
+
+// Get List Purchase Order godoc
+// @ID get_list_purchase_order
+// @Router /purchase_order [GET]
+// @Summary Get List Purchase Order
+// @Description Get List Purchase Order
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param supplier_id query int false "supplier_id"
+// @Param store_id query int false "only purchase orders with an item for the store"
+// @Param status query int false "1 draft, 2 ordered, 3 partially received, 4 received, 5 cancelled"
+// @Success 200 {object} Response{data=models.GetListPurchaseOrderResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetListPurchaseOrder(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.handlerResponse(c, "Get list purchase order", http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.handlerResponse(c, "Get list purchase order", http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	req := &models.GetListPurchaseOrderRequest{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	intParams := []struct {
+		name string
+		dst  *int
+	}{
+		{"supplier_id", &req.SupplierId},
+		{"store_id", &req.StoreId},
+	}
+	for _, param := range intParams {
+		*param.dst, err = h.getIntQuery(c.Query(param.name))
+		if err != nil {
+			h.handlerResponse(c, "Get list purchase order", http.StatusBadRequest, "invalid "+param.name)
+			return
+		}
+	}
+
+	status, err := h.getIntQuery(c.Query("status"))
+	if err != nil || status < 0 || status > int(models.PurchaseOrderStatusCancelled) {
+		h.handlerResponse(c, "Get list purchase order", http.StatusBadRequest, "invalid status")
+		return
+	}
+	req.Status = int16(status)
+
+	// store scoped users only see purchase orders delivering to their own store
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+	if scope > 0 {
+		if req.StoreId > 0 && !h.allowStore(c, req.StoreId) {
+			return
+		}
+		req.StoreId = scope
+	}
+
+	resp, err := h.storages.PurchaseOrder().GetList(context.Background(), req)
+	if err != nil {
+		h.handleError(c, "Storage get list purchase order", err)
+		return
+	}
+
+	h.handlerResponse(c, "Get list purchase order", http.StatusOK, resp)
+}
+
+// Delete Purchase Order godoc
+// @ID delete_purchase_order
+// @Router /purchase_order/{id} [DELETE]
+// @Summary Delete Purchase Order
+// @Description Delete a Purchase Order with its items and history, goods already received stay in stock
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) DeletePurchaseOrder(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err delete purchase order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := h.allowPurchaseOrder(c, idInt, true); !ok {
+		return
+	}
+
+	rowsAffected, err := h.storages.PurchaseOrder().Delete(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage purchase order delete", err)
+		return
+	}
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "Storage purchase order delete", http.StatusNotFound, "not found")
+		return
+	}
+
+	h.handlerResponse(c, "Delete purchase order", http.StatusNoContent, nil)
+}
+
+// Submit Purchase Order godoc
+// @ID submit_purchase_order
+// @Router /purchase_order/{id}/submit [PUT]
+// @Summary Submit Purchase Order
+// @Description Mark a draft Purchase Order as ordered from the supplier, goods can be received from then on
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param purchase_order body models.ChangePurchaseOrderStatus false "ChangePurchaseOrderStatusRequest"
+// @Success 200 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) SubmitPurchaseOrder(c *gin.Context) {
+	h.changePurchaseOrderStatus(c, models.PurchaseOrderStatusOrdered)
+}
+
+// Cancel Purchase Order godoc
+// @ID cancel_purchase_order
+// @Router /purchase_order/{id}/cancel [PUT]
+// @Summary Cancel Purchase Order
+// @Description Cancel a draft or ordered Purchase Order that has nothing received yet
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param purchase_order body models.ChangePurchaseOrderStatus false "ChangePurchaseOrderStatusRequest"
+// @Success 200 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) CancelPurchaseOrder(c *gin.Context) {
+	h.changePurchaseOrderStatus(c, models.PurchaseOrderStatusCancelled)
+}
+
+// Close Purchase Order godoc
+// @ID close_purchase_order
+// @Router /purchase_order/{id}/close [PUT]
+// @Summary Close Purchase Order
+// @Description Mark a partially received Purchase Order as received when the rest will not be delivered
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param purchase_order body models.ChangePurchaseOrderStatus false "ChangePurchaseOrderStatusRequest"
+// @Success 200 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ClosePurchaseOrder(c *gin.Context) {
+	h.changePurchaseOrderStatus(c, models.PurchaseOrderStatusReceived)
+}
+
+// Receive Purchase Order godoc
+// @ID receive_purchase_order
+// @Router /purchase_order/{id}/receive [POST]
+// @Summary Receive Purchase Order
+// @Description Book a goods receipt: the received quantities are added to the items and to the stock of their stores. Partial receipts move the Purchase Order to partially received, it is received once every item is complete.
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param purchase_order body models.ReceivePurchaseOrder true "ReceivePurchaseOrderRequest"
+// @Success 200 {object} Response{data=models.PurchaseOrder} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) ReceivePurchaseOrder(c *gin.Context) {
+	var receive models.ReceivePurchaseOrder
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err receive purchase order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.ShouldBindJSON(&receive)
+	if err != nil {
+		h.handlerResponse(c, "Receive purchase order", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(receive.Items) == 0 {
+		h.handlerResponse(c, "Receive purchase order", http.StatusBadRequest, "receipt must have at least one item")
+		return
+	}
+	receive.PurchaseOrderId = idInt
+	receive.ReceivedBy = c.GetString(ctxUserId)
+
+	purchaseOrder, ok := h.allowPurchaseOrder(c, idInt, false)
+	if !ok {
+		return
+	}
+
+	// store scoped users only receive the items delivered to their store, unknown items are
+	// left to the storage
+	stores := map[int]int{}
+	for _, item := range purchaseOrder.Items {
+		stores[item.ItemId] = item.StoreId
+	}
+	for _, item := range receive.Items {
+		if item == nil {
+			h.handlerResponse(c, "Receive purchase order", http.StatusBadRequest, "items must not be null")
+			return
+		}
+		if storeId, ok := stores[item.ItemId]; ok && !h.allowStore(c, storeId) {
+			return
+		}
+	}
+
+	err = h.storages.PurchaseOrder().Receive(context.Background(), &receive)
+	if err != nil {
+		h.handleError(c, "Storage receive purchase order", err)
+		return
+	}
+
+	received := make([]int, 0, len(receive.Items))
+	for _, item := range receive.Items {
+		received = append(received, stores[item.ItemId])
+	}
+	h.invalidateStockPages(received...)
+
+	resp, err := h.storages.PurchaseOrder().GetById(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage get by id purchase order", err)
+		return
+	}
+
+	h.handlerResponse(c, "Receive purchase order", http.StatusOK, resp)
+}
+
+// Get Purchase Order Status History godoc
+// @ID get_purchase_order_status_history
+// @Router /purchase_order/{id}/history [GET]
+// @Summary Get Purchase Order Status History
+// @Description Get Purchase Order Status History
+// @Tags Purchase Order
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 200 {object} Response{data=models.GetPurchaseOrderStatusHistoryResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "Forbidden"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetPurchaseOrderStatusHistory(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err purchase order status history", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := h.allowPurchaseOrder(c, idInt, false); !ok {
+		return
+	}
+
+	resp, err := h.storages.PurchaseOrder().GetStatusHistory(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage purchase order status history", err)
+		return
+	}
+
+	h.handlerResponse(c, "Purchase order status history", http.StatusOK, resp)
+}
+
+// allowPurchaseOrder returns the purchase order when the caller may work with it. A store scoped
+// caller may read a purchase order with an item for their store, changing it needs every item
+// to be for their store.
+func (h *Handler) allowPurchaseOrder(c *gin.Context, purchaseOrderId int, change bool) (*models.PurchaseOrder, bool) {
+
+	purchaseOrder, err := h.storages.PurchaseOrder().GetById(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: purchaseOrderId})
+	if err != nil {
+		h.handleError(c, "Storage get by id purchase order", err)
+		return nil, false
+	}
+
+	scope, ok := h.storeScope(c)
+	if !ok {
+		return nil, false
+	}
+	if scope == 0 {
+		return purchaseOrder, true
+	}
+
+	delivers := false
+	for _, item := range purchaseOrder.Items {
+		if item.StoreId == scope {
+			delivers = true
+		} else if change {
+			return nil, h.allowStore(c, item.StoreId)
+		}
+	}
+	if !delivers {
+		h.handlerResponse(c, "store scope", http.StatusForbidden, fmt.Sprintf("access to purchase order %d is not allowed", purchaseOrderId))
+		return nil, false
+	}
+
+	return purchaseOrder, true
+}
+
+func (h *Handler) changePurchaseOrderStatus(c *gin.Context, status int16) {
+	var changeStatus models.ChangePurchaseOrderStatus
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Atoi err change purchase order status", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// the body only carries an optional comment
+	if c.Request.ContentLength > 0 {
+		err = c.ShouldBindJSON(&changeStatus)
+		if err != nil {
+			h.handlerResponse(c, "Change purchase order status", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	changeStatus.PurchaseOrderId = idInt
+	changeStatus.Status = status
+	changeStatus.ChangedBy = c.GetString(ctxUserId)
+
+	if _, ok := h.allowPurchaseOrder(c, idInt, true); !ok {
+		return
+	}
+
+	err = h.storages.PurchaseOrder().ChangeStatus(context.Background(), &changeStatus)
+	if err != nil {
+		h.handleError(c, "Storage change purchase order status", err)
+		return
+	}
+
+	resp, err := h.storages.PurchaseOrder().GetById(context.Background(), &models.PurchaseOrderPrimaryKey{PurchaseOrderId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage get by id purchase order", err)
+		return
+	}
+
+	h.handlerResponse(c, "Change purchase order status", http.StatusOK, resp)
+}
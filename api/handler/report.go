@@ -3,7 +3,6 @@ package handler
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -18,9 +17,11 @@ import (
 // @Tags Report
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param report body models.SendProduct true "SendProductRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) SendProductToStore(c *gin.Context) {
 	var sendProduct models.SendProduct
@@ -31,11 +32,16 @@ func (h *Handler) SendProductToStore(c *gin.Context) {
 		return
 	}
 
+	if !h.allowStore(c, sendProduct.SenderId) {
+		return
+	}
+
 	err = h.storages.Report().SendProduct(context.Background(), &sendProduct)
 	if err != nil {
-		h.handlerResponse(c, "Storage report  send product", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage report  send product", err)
 		return
 	}
+	h.invalidateStockPages(sendProduct.SenderId, sendProduct.ReceiverId)
 
 	h.handlerResponse(c, "Get store by id", http.StatusOK, "Success")
 }
@@ -48,6 +54,9 @@ func (h *Handler) SendProductToStore(c *gin.Context) {
 // @Tags Report
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "json or csv, Accept: text/csv works too; csv has every matching row, offset and limit are ignored"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -55,6 +64,11 @@ func (h *Handler) SendProductToStore(c *gin.Context) {
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetListStaffReport(c *gin.Context) {
+	asCSV, ok := h.wantsCSV(c, "Staff report")
+	if !ok {
+		return
+	}
+
 	offset, err := h.getOffsetQuery(c.Query("offset"))
 	if err != nil {
 		h.handlerResponse(c, "Staff report", http.StatusBadRequest, "invalid offset")
@@ -67,20 +81,81 @@ func (h *Handler) GetListStaffReport(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.storages.Report().StaffReport(context.Background(), &models.StaffListRequest{
-		Offset: offset,
-		Limit:  limit,
-		Search: c.Query("search"),
-	})
+	storeId, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+
+	req := &models.StaffListRequest{
+		Offset:  offset,
+		Limit:   limit,
+		Search:  c.Query("search"),
+		StoreId: storeId,
+	}
 
+	if asCSV {
+		h.exportCSV(c, "Storage export staff report", "staff_report", staffReportCSVHeader, func(write func([]string) error) error {
+			return h.storages.Report().ExportStaffReport(context.Background(), req, func(report *models.StaffReport) error {
+				return write(staffReportCSVRecord(report))
+			})
+		})
+		return
+	}
+
+	resp, err := h.storages.Report().StaffReport(context.Background(), req)
 	if err != nil {
-		h.handlerResponse(c, "Storage staff report", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage staff report", err)
 		return
 	}
 
 	h.handlerResponse(c, "Staff report", http.StatusOK, resp)
 }
 
+// Stock Reconciliation godoc
+// @ID stock_reconciliation
+// @Router /report/stock_reconciliation [GET]
+// @Summary Stock Reconciliation
+// @Description List order lines whose items and stock movements do not match
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} Response{data=models.StockReconciliationResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) StockReconciliation(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.handlerResponse(c, "Stock reconciliation", http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.handlerResponse(c, "Stock reconciliation", http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	storeId, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storages.Report().StockReconciliation(context.Background(), &models.StockReconciliationRequest{
+		Offset:  offset,
+		Limit:   limit,
+		StoreId: storeId,
+	})
+	if err != nil {
+		h.handleError(c, "Storage stock reconciliation", err)
+		return
+	}
+
+	h.handlerResponse(c, "Stock reconciliation", http.StatusOK, resp)
+}
+
 // Total Sum Order godoc
 // @ID total_sum_order
 // @Router /report/total_sum [GET]
@@ -89,6 +164,7 @@ func (h *Handler) GetListStaffReport(c *gin.Context) {
 // @Tags Report
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param order_id query string true "order_id"
 // @Param promocode_name query string false "promocode_name"
 // @Success 200 {object} Response{data=string} "Success Request"
@@ -103,15 +179,18 @@ func (h *Handler) OrderTotalSum(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.allowOrder(c, orderId); !ok {
+		return
+	}
+
 	orderSum.OrderId = orderId
 	orderSum.PromocodeName = c.Query("promocode_name")
 
 	totalSum, err := h.storages.Report().OrderTotalSum(context.Background(), &orderSum)
 	if err != nil {
-		h.handlerResponse(c, "Storage order total sum", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage order total sum", err)
 		return
 	}
-	fmt.Println("Hello World")
 
 	h.handlerResponse(c, "Order total sum", http.StatusOK, totalSum)
 }
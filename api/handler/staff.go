@@ -17,9 +17,11 @@ import (
 // @Tags Staff
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param Staff body models.CreateStaff true "CreateStaffRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateStaff(c *gin.Context) {
 	var createStaff models.CreateStaff
@@ -32,13 +34,13 @@ func (h *Handler) CreateStaff(c *gin.Context) {
 
 	id, err := h.storages.Staff().Create(context.Background(), &createStaff)
 	if err != nil {
-		h.handlerResponse(c, "storage create staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage create staff", err)
 		return
 	}
 
 	staff, err := h.storages.Staff().GetById(context.Background(), &models.StaffPrimaryKey{StaffId: id})
 	if err != nil {
-		h.handlerResponse(c, "storage get by id staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "storage get by id staff", err)
 		return
 	}
 
@@ -53,9 +55,11 @@ func (h *Handler) CreateStaff(c *gin.Context) {
 // @Tags Staff
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdStaff(c *gin.Context) {
 	id := c.Param("id")
@@ -67,7 +71,7 @@ func (h *Handler) GetByIdStaff(c *gin.Context) {
 
 	category, err := h.storages.Staff().GetById(context.Background(), &models.StaffPrimaryKey{StaffId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id staff", err)
 		return
 	}
 
@@ -82,6 +86,7 @@ func (h *Handler) GetByIdStaff(c *gin.Context) {
 // @Tags Staff
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -107,7 +112,7 @@ func (h *Handler) GetListStaff(c *gin.Context) {
 		Search: c.Query("search"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage get list staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list staff", err)
 		return
 	}
 
@@ -122,10 +127,13 @@ func (h *Handler) GetListStaff(c *gin.Context) {
 // @Tags Staff
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Staff body models.UpdateStaff true "UpdateStaffRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateStaff(c *gin.Context) {
 	var updateStaff models.UpdateStaff
@@ -146,18 +154,18 @@ func (h *Handler) UpdateStaff(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Staff().Update(context.Background(), &updateStaff)
 	if err != nil {
-		h.handlerResponse(c, "Storage update staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update staff", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update staff", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update staff", http.StatusNotFound, "not found")
 		return
 	}
 
 	resp, err := h.storages.Staff().GetById(context.Background(), &models.StaffPrimaryKey{StaffId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id staff", err)
 		return
 	}
 
@@ -172,10 +180,12 @@ func (h *Handler) UpdateStaff(c *gin.Context) {
 // @Tags Staff
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param Staff body models.StaffPrimaryKey true "DeleteStaffRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteStaff(c *gin.Context) {
 	id := c.Param("id")
@@ -187,12 +197,12 @@ func (h *Handler) DeleteStaff(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Staff().Delete(context.Background(), &models.StaffPrimaryKey{StaffId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage delete staff", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage delete staff", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage delete staff", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage delete staff", http.StatusNotFound, "not found")
 		return
 	}
 
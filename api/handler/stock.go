@@ -17,9 +17,11 @@ import (
 // @Tags Stock
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param stock body models.CreateStock true "CreateStockRequest"
 // @Success 201 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateStock(c *gin.Context) {
 	var createStock models.CreateStock
@@ -30,15 +32,20 @@ func (h *Handler) CreateStock(c *gin.Context) {
 		return
 	}
 
+	if !h.allowStore(c, createStock.StoreId) {
+		return
+	}
+
 	storeId, err := h.storages.Stock().Create(context.Background(), &createStock)
 	if err != nil {
-		h.handlerResponse(c, "Storage stock create", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage stock create", err)
 		return
 	}
+	h.invalidateStockPages(createStock.StoreId)
 
 	resp, err := h.storages.Stock().GetById(context.Background(), &models.StockPrimaryKey{StoreId: storeId})
 	if err != nil {
-		h.handlerResponse(c, "Storage stock get by id", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage stock get by id", err)
 		return
 	}
 
@@ -53,9 +60,11 @@ func (h *Handler) CreateStock(c *gin.Context) {
 // @Tags Stock
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdStock(c *gin.Context) {
 	id := c.Param("id")
@@ -65,9 +74,13 @@ func (h *Handler) GetByIdStock(c *gin.Context) {
 		return
 	}
 
+	if !h.allowStore(c, idInt) {
+		return
+	}
+
 	stock, err := h.storages.Stock().GetById(context.Background(), &models.StockPrimaryKey{StoreId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage get by id stock", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get by id stock", err)
 		return
 	}
 
@@ -78,17 +91,26 @@ func (h *Handler) GetByIdStock(c *gin.Context) {
 // @ID get_list_stock
 // @Router /stock [GET]
 // @Summary Get List Stock
-// @Description Get List Stock
+// @Description Get List Stock. As CSV it has one line per store and product.
 // @Tags Stock
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Param Authorization header string true "Bearer access token"
+// @Param format query string false "json or csv, Accept: text/csv works too; csv has every matching row, offset, limit and after are ignored"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
-// @Success 200 {object} Response{data=string} "Success Request"
+// @Param after query string false "next_cursor of the previous page, offset is ignored"
+// @Success 200 {object} Response{data=models.GetListStockResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetListStock(c *gin.Context) {
+	asCSV, ok := h.wantsCSV(c, "Get list stock")
+	if !ok {
+		return
+	}
+
 	offset, err := h.getOffsetQuery(c.Query("offset"))
 	if err != nil {
 		h.handlerResponse(c, "Get list stock", http.StatusBadRequest, "invalid offset")
@@ -101,12 +123,30 @@ func (h *Handler) GetListStock(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.storages.Stock().GetList(context.Background(), &models.GetListStockRequest{
-		Offset: offset,
-		Limit:  limit,
-	})
+	storeId, ok := h.storeScope(c)
+	if !ok {
+		return
+	}
+
+	req := &models.GetListStockRequest{
+		Offset:  offset,
+		Limit:   limit,
+		StoreId: storeId,
+		After:   c.Query("after"),
+	}
+
+	if asCSV {
+		h.exportCSV(c, "Storage export stock", "stocks", stockCSVHeader, func(write func([]string) error) error {
+			return h.storages.Stock().Export(context.Background(), req, func(stock *models.Stock) error {
+				return write(stockCSVRecord(stock))
+			})
+		})
+		return
+	}
+
+	resp, err := h.storages.Stock().GetList(context.Background(), req)
 	if err != nil {
-		h.handlerResponse(c, "Storage get list stock", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage get list stock", err)
 		return
 	}
 
@@ -121,11 +161,13 @@ func (h *Handler) GetListStock(c *gin.Context) {
 // @Tags Stock
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param storeId query string true "storeId"
 // @Param productId query string true "productId"
 // @Param Stock body models.UpdateStock true "UpdateStockRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateStock(c *gin.Context) {
 	var updateStock models.UpdateStock
@@ -150,16 +192,21 @@ func (h *Handler) UpdateStock(c *gin.Context) {
 	updateStock.StoreId = storeId
 	updateStock.ProductId = productId
 
+	if !h.allowStore(c, storeId) {
+		return
+	}
+
 	rowsAffected, err := h.storages.Stock().Update(context.Background(), &updateStock)
 	if err != nil {
-		h.handlerResponse(c, "Storage update stock", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage update stock", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage update stock", http.StatusBadRequest, "no rows affected")
+		h.handlerResponse(c, "Storage update stock", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateStockPages(storeId)
 
 	h.handlerResponse(c, "Update stock", http.StatusOK, "Updated Successfully")
 }
@@ -172,10 +219,12 @@ func (h *Handler) UpdateStock(c *gin.Context) {
 // @Tags Stock
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param stock body models.StockPrimaryKey true "DeleteStockRequest"
 // @Success 204 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteStock(c *gin.Context) {
 
@@ -187,15 +236,20 @@ func (h *Handler) DeleteStock(c *gin.Context) {
 		return
 	}
 
-	rowsAffected, err := h.storages.Store().Delete(context.Background(), &models.StorePrimaryKey{StoreId: idInt})
+	if !h.allowStore(c, idInt) {
+		return
+	}
+
+	rowsAffected, err := h.storages.Stock().Delete(context.Background(), &models.StockPrimaryKey{StoreId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage store delete", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage stock delete", err)
 		return
 	}
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage store delete", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "Storage stock delete", http.StatusNotFound, "not found")
 		return
 	}
+	h.invalidateStockPages(idInt)
 
-	h.handlerResponse(c, "Delete store", http.StatusNoContent, nil)
-}
\ No newline at end of file
+	h.handlerResponse(c, "Delete stock", http.StatusNoContent, nil)
+}
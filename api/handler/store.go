@@ -17,9 +17,11 @@ import (
 // @Tags Store
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param store body models.CreateStore true "CreateStoreRequest"
 // @Success 201 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) CreateStore(c *gin.Context) {
 
@@ -33,13 +35,13 @@ func (h *Handler) CreateStore(c *gin.Context) {
 
 	id, err := h.storages.Store().Create(context.Background(), &createStore)
 	if err != nil {
-		h.handlerResponse(c, "Storage store create", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store create", err)
 		return
 	}
 
 	resp, err := h.storages.Store().GetById(context.Background(), &models.StorePrimaryKey{StoreId: id})
 	if err != nil {
-		h.handlerResponse(c, "Storage store getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store getByID", err)
 		return
 	}
 
@@ -54,9 +56,11 @@ func (h *Handler) CreateStore(c *gin.Context) {
 // @Tags Store
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) GetByIdStore(c *gin.Context) {
 
@@ -70,7 +74,7 @@ func (h *Handler) GetByIdStore(c *gin.Context) {
 
 	resp, err := h.storages.Store().GetById(context.Background(), &models.StorePrimaryKey{StoreId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage store getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store getByID", err)
 		return
 	}
 
@@ -85,6 +89,7 @@ func (h *Handler) GetByIdStore(c *gin.Context) {
 // @Tags Store
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param search query string false "search"
@@ -111,7 +116,7 @@ func (h *Handler) GetListStore(c *gin.Context) {
 		Search: c.Query("search"),
 	})
 	if err != nil {
-		h.handlerResponse(c, "Storage store getlist", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store getlist", err)
 		return
 	}
 
@@ -126,10 +131,13 @@ func (h *Handler) GetListStore(c *gin.Context) {
 // @Tags Store
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param store body models.UpdateStore true "UpdateStoreRequest"
 // @Success 202 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) UpdateStore(c *gin.Context) {
 
@@ -153,18 +161,18 @@ func (h *Handler) UpdateStore(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Store().Update(context.Background(), &updateStore)
 	if err != nil {
-		h.handlerResponse(c, "Storage store.update", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store.update", err)
 		return
 	}
 
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage store.update", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "Storage store.update", http.StatusNotFound, "not found")
 		return
 	}
 
 	resp, err := h.storages.Store().GetById(context.Background(), &models.StorePrimaryKey{StoreId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage store getByID", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store getByID", err)
 		return
 	}
 
@@ -179,10 +187,12 @@ func (h *Handler) UpdateStore(c *gin.Context) {
 // @Tags Store
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer access token"
 // @Param id path string true "id"
 // @Param store body models.StorePrimaryKey true "DeleteStoreRequest"
 // @Success 204 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
 // @Failure 500 {object} Response{data=string} "Server Error"
 func (h *Handler) DeleteStore(c *gin.Context) {
 
@@ -196,13 +206,15 @@ func (h *Handler) DeleteStore(c *gin.Context) {
 
 	rowsAffected, err := h.storages.Store().Delete(context.Background(), &models.StorePrimaryKey{StoreId: idInt})
 	if err != nil {
-		h.handlerResponse(c, "Storage store delete", http.StatusInternalServerError, err.Error())
+		h.handleError(c, "Storage store delete", err)
 		return
 	}
 	if rowsAffected <= 0 {
-		h.handlerResponse(c, "Storage store delete", http.StatusBadRequest, "now rows affected")
+		h.handlerResponse(c, "Storage store delete", http.StatusNotFound, "not found")
 		return
 	}
+	// its stock went with it
+	h.invalidateStockPages(idInt)
 
 	h.handlerResponse(c, "Delete store", http.StatusNoContent, nil)
 }
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"app/api/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Supplier godoc
+// @ID create_supplier
+// @Router /supplier [POST]
+// @Summary Create Supplier
+// @Description Create Supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param supplier body models.CreateSupplier true "CreateSupplierRequest"
+// @Success 201 {object} Response{data=models.Supplier} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) CreateSupplier(c *gin.Context) {
+
+	var createSupplier models.CreateSupplier
+
+	err := c.ShouldBindJSON(&createSupplier)
+	if err != nil {
+		h.handlerResponse(c, "Create supplier", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storages.Supplier().Create(context.Background(), &createSupplier)
+	if err != nil {
+		h.handleError(c, "Storage supplier create", err)
+		return
+	}
+
+	resp, err := h.storages.Supplier().GetById(context.Background(), &models.SupplierPrimaryKey{SupplierId: id})
+	if err != nil {
+		h.handleError(c, "Storage supplier getByID", err)
+		return
+	}
+
+	h.handlerResponse(c, "Create supplier", http.StatusCreated, resp)
+}
+
+// Get By ID Supplier godoc
+// @ID get_by_id_supplier
+// @Router /supplier/{id} [GET]
+// @Summary Get By ID Supplier
+// @Description Get By ID Supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 200 {object} Response{data=models.Supplier} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetByIdSupplier(c *gin.Context) {
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Storage supplier getByID", http.StatusBadRequest, "id incorrect")
+		return
+	}
+
+	resp, err := h.storages.Supplier().GetById(context.Background(), &models.SupplierPrimaryKey{SupplierId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage supplier getByID", err)
+		return
+	}
+
+	h.handlerResponse(c, "Get supplier by id", http.StatusOK, resp)
+}
+
+// Get List Supplier godoc
+// @ID get_list_supplier
+// @Router /supplier [GET]
+// @Summary Get List Supplier
+// @Description Get List Supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param search query string false "supplier name, contact name or email"
+// @Success 200 {object} Response{data=models.GetListSupplierResponse} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) GetListSupplier(c *gin.Context) {
+
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.handlerResponse(c, "Get list supplier", http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.handlerResponse(c, "Get list supplier", http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	resp, err := h.storages.Supplier().GetList(context.Background(), &models.GetListSupplierRequest{
+		Offset: offset,
+		Limit:  limit,
+		Search: c.Query("search"),
+	})
+	if err != nil {
+		h.handleError(c, "Storage supplier getlist", err)
+		return
+	}
+
+	h.handlerResponse(c, "Get list supplier response", http.StatusOK, resp)
+}
+
+// Update Supplier godoc
+// @ID update_supplier
+// @Router /supplier/{id} [PUT]
+// @Summary Update Supplier
+// @Description Update Supplier
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Param supplier body models.UpdateSupplier true "UpdateSupplierRequest"
+// @Success 202 {object} Response{data=models.Supplier} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 409 {object} Response{data=string} "Conflict"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) UpdateSupplier(c *gin.Context) {
+
+	var updateSupplier models.UpdateSupplier
+
+	err := c.ShouldBindJSON(&updateSupplier)
+	if err != nil {
+		h.handlerResponse(c, "Update supplier", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Storage supplier update", http.StatusBadRequest, "id incorrect")
+		return
+	}
+
+	updateSupplier.SupplierId = idInt
+
+	rowsAffected, err := h.storages.Supplier().Update(context.Background(), &updateSupplier)
+	if err != nil {
+		h.handleError(c, "Storage supplier.update", err)
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "Storage supplier.update", http.StatusNotFound, "not found")
+		return
+	}
+
+	resp, err := h.storages.Supplier().GetById(context.Background(), &models.SupplierPrimaryKey{SupplierId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage supplier getByID", err)
+		return
+	}
+
+	h.handlerResponse(c, "Update supplier", http.StatusAccepted, resp)
+}
+
+// DELETE Supplier godoc
+// @ID delete_supplier
+// @Router /supplier/{id} [DELETE]
+// @Summary Delete Supplier
+// @Description Delete a Supplier that has no purchase orders
+// @Tags Supplier
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Param id path string true "id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 404 {object} Response{data=string} "Not Found"
+// @Failure 422 {object} Response{data=string} "Unprocessable Entity"
+// @Failure 500 {object} Response{data=string} "Server Error"
+func (h *Handler) DeleteSupplier(c *gin.Context) {
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.handlerResponse(c, "Storage supplier delete", http.StatusBadRequest, "id incorrect")
+		return
+	}
+
+	rowsAffected, err := h.storages.Supplier().Delete(context.Background(), &models.SupplierPrimaryKey{SupplierId: idInt})
+	if err != nil {
+		h.handleError(c, "Storage supplier delete", err)
+		return
+	}
+	if rowsAffected <= 0 {
+		h.handlerResponse(c, "Storage supplier delete", http.StatusNotFound, "not found")
+		return
+	}
+
+	h.handlerResponse(c, "Delete supplier", http.StatusNoContent, nil)
+}
@@ -0,0 +1,12 @@
+package models
+
+type CacheStats struct {
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+type GetCacheStatsResponse struct {
+	Product CacheStats `json:"product"`
+}
@@ -43,9 +43,11 @@ type GetListCustomerRequest struct {
 	Offset int    `json:"offset"`
 	Limit  int    `json:"limit"`
 	Search string `json:"search"`
+	After  string `json:"after"`
 }
 
 type GetListCustomerResponse struct {
-	Count     int         `json:"count"`
-	Customers []*Customer `json:"customers"`
+	Count      int         `json:"count"`
+	Customers  []*Customer `json:"customers"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,18 @@
+package models
+
+// ImportRowError is a row that failed validation. Line is the line of the file, Field the
+// column or JSON key at fault, empty when the whole row is.
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReport is the result of a bulk import. Nothing is imported when a row has errors, so
+// Imported is 0 unless Errors is empty and DryRun is false.
+type ImportReport struct {
+	Total    int               `json:"total"`
+	Imported int64             `json:"imported"`
+	DryRun   bool              `json:"dry_run"`
+	Errors   []*ImportRowError `json:"errors"`
+}
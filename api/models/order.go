@@ -1,5 +1,54 @@
 package models
 
+// Order statuses. Orders are created Pending and only move along orderTransitions.
+const (
+	OrderStatusPending    int16 = 1
+	OrderStatusProcessing int16 = 2
+	OrderStatusRejected   int16 = 3
+	OrderStatusCompleted  int16 = 4
+	OrderStatusShipped    int16 = 5
+	OrderStatusCancelled  int16 = 6
+)
+
+var orderTransitions = map[int16][]int16{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusRejected, OrderStatusCancelled},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusRejected, OrderStatusCancelled},
+	OrderStatusShipped:    {OrderStatusCompleted},
+}
+
+var orderStatusNames = map[int16]string{
+	OrderStatusPending:    "pending",
+	OrderStatusProcessing: "processing",
+	OrderStatusRejected:   "rejected",
+	OrderStatusCompleted:  "completed",
+	OrderStatusShipped:    "shipped",
+	OrderStatusCancelled:  "cancelled",
+}
+
+// OrderStatusHoldsStock reports whether the items of an order in this status are still
+// reserved in the store, so removing them or giving up the order returns them to stock.
+// Shipped and completed orders have left the store and keep their stock consumed.
+func OrderStatusHoldsStock(status int16) bool {
+	return status == OrderStatusPending || status == OrderStatusProcessing
+}
+
+// CanChangeOrderStatus reports whether an order in status from may be moved to status to.
+func CanChangeOrderStatus(from, to int16) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func OrderStatusName(status int16) string {
+	if name, ok := orderStatusNames[status]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 type Order struct {
 	OrderId      int          `json:"order_id"`
 	CustomerId   int          `json:"customer_id"`
@@ -21,32 +70,81 @@ type OrderPrimaryKey struct {
 
 type CreateOrder struct {
 	CustomerId   int    `json:"customer_id"`
-	OrderStatus  int16  `json:"order_status"`
 	RequiredDate string `json:"required_date"`
-	ShippedDate  string `json:"shipped_date"`
 	StoreId      int    `json:"store_id"`
 	StaffId      int    `json:"staff_id"`
+	CreatedBy    string `json:"-"`
 }
 
 type UpdateOrder struct {
 	OrderId      int    `json:"order_id"`
 	CustomerId   int    `json:"customer_id"`
-	OrderStatus  int16  `json:"order_status"`
 	RequiredDate string `json:"required_date"`
-	ShippedDate  string `json:"shipped_date"`
 	StoreId      int    `json:"store_id"`
 	StaffId      int    `json:"staff_id"`
 }
 
+type PlaceOrder struct {
+	CustomerId   int               `json:"customer_id"`
+	RequiredDate string            `json:"required_date"`
+	StoreId      int               `json:"store_id"`
+	StaffId      int               `json:"staff_id"`
+	Items        []*PlaceOrderItem `json:"items"`
+	CreatedBy    string            `json:"-"`
+}
+
+// PlaceOrderItem is sold at the product's current list price.
+type PlaceOrderItem struct {
+	ProductId int     `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Discount  float64 `json:"discount"`
+}
+
+type ChangeOrderStatus struct {
+	OrderId   int    `json:"-"`
+	Status    int16  `json:"-"`
+	ChangedBy string `json:"-"`
+	Comment   string `json:"comment"`
+}
+
+type OrderStatusHistory struct {
+	HistoryId  int    `json:"history_id"`
+	OrderId    int    `json:"order_id"`
+	FromStatus int16  `json:"from_status"`
+	ToStatus   int16  `json:"to_status"`
+	ChangedBy  string `json:"changed_by"`
+	Comment    string `json:"comment"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+type GetOrderStatusHistoryResponse struct {
+	Count   int                   `json:"count"`
+	History []*OrderStatusHistory `json:"history"`
+}
+
 type GetListOrderRequest struct {
-	Offset int    `json:"offset"`
-	Limit  int    `json:"limit"`
-	Search string `json:"search"`
+	Offset      int    `json:"offset"`
+	Limit       int    `json:"limit"`
+	Search      string `json:"search"`
+	CustomerId  int    `json:"customer_id"`
+	StoreId     int    `json:"store_id"`
+	StaffId     int    `json:"staff_id"`
+	OrderStatus int16  `json:"order_status"`
+	// dates are YYYY-MM-DD and the ranges include both ends
+	OrderDateFrom    string `json:"order_date_from"`
+	OrderDateTo      string `json:"order_date_to"`
+	RequiredDateFrom string `json:"required_date_from"`
+	RequiredDateTo   string `json:"required_date_to"`
+	// Late keeps only orders shipped after their required date
+	Late  bool   `json:"late"`
+	Sort  string `json:"sort"`
+	After string `json:"after"`
 }
 
 type GetListOrderResponse struct {
-	Count  int      `json:"count"`
-	Orders []*Order `json:"orders"`
+	Count      int      `json:"count"`
+	Orders     []*Order `json:"orders"`
+	NextCursor string   `json:"next_cursor,omitempty"`
 }
 
 // -----------------------ITEM------------------
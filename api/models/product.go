@@ -33,12 +33,24 @@ type UpdateProduct struct {
 }
 
 type GetListProductRequest struct {
-	Offset int    `json:"offset"`
-	Limit  int    `json:"limit"`
-	Search string `json:"search"`
+	Offset         int     `json:"offset"`
+	Limit          int     `json:"limit"`
+	Search         string  `json:"search"`
+	BrandId        int     `json:"brand_id"`
+	CategoryId     int     `json:"category_id"`
+	ModelYearFrom  int     `json:"model_year_from"`
+	ModelYearTo    int     `json:"model_year_to"`
+	PriceFrom      float64 `json:"price_from"`
+	PriceTo        float64 `json:"price_to"`
+	InStockStoreId int     `json:"in_stock_store_id"`
+	// Sort is a comma separated list of fields, a leading - sorts descending: -list_price,product_name
+	Sort string `json:"sort"`
+	// After is the next_cursor of the previous page, it cannot be combined with Sort
+	After string `json:"after"`
 }
 
 type GetListProductResponse struct {
-	Count    int        `json:"count"`
-	Products []*Product `json:"products"`
+	Count      int        `json:"count"`
+	Products   []*Product `json:"products"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
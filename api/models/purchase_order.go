@@ -0,0 +1,139 @@
+package models
+
+// Purchase order statuses. A purchase order is created as a Draft and submitted to the supplier
+// as Ordered. Goods receipts move it to PartiallyReceived and Received, never a manual change.
+const (
+	PurchaseOrderStatusDraft             int16 = 1
+	PurchaseOrderStatusOrdered           int16 = 2
+	PurchaseOrderStatusPartiallyReceived int16 = 3
+	PurchaseOrderStatusReceived          int16 = 4
+	PurchaseOrderStatusCancelled         int16 = 5
+)
+
+// purchaseOrderTransitions are the manual status changes. A partially received purchase order
+// is closed as received when the rest will not be delivered.
+var purchaseOrderTransitions = map[int16][]int16{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusOrdered, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusOrdered:           {PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusReceived},
+}
+
+var purchaseOrderStatusNames = map[int16]string{
+	PurchaseOrderStatusDraft:             "draft",
+	PurchaseOrderStatusOrdered:           "ordered",
+	PurchaseOrderStatusPartiallyReceived: "partially_received",
+	PurchaseOrderStatusReceived:          "received",
+	PurchaseOrderStatusCancelled:         "cancelled",
+}
+
+// CanChangePurchaseOrderStatus reports whether a purchase order in status from may be moved to
+// status to by hand.
+func CanChangePurchaseOrderStatus(from, to int16) bool {
+	for _, next := range purchaseOrderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PurchaseOrderCanReceive reports whether goods may still be received for a purchase order in this status.
+func PurchaseOrderCanReceive(status int16) bool {
+	return status == PurchaseOrderStatusOrdered || status == PurchaseOrderStatusPartiallyReceived
+}
+
+func PurchaseOrderStatusName(status int16) string {
+	if name, ok := purchaseOrderStatusNames[status]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+type PurchaseOrder struct {
+	PurchaseOrderId int                  `json:"purchase_order_id"`
+	SupplierId      int                  `json:"supplier_id"`
+	SupplierData    *Supplier            `json:"supplier_data"`
+	Status          int16                `json:"status"`
+	OrderDate       string               `json:"order_date"`
+	ExpectedDate    string               `json:"expected_date"`
+	Items           []*PurchaseOrderItem `json:"items"`
+}
+
+type PurchaseOrderItem struct {
+	PurchaseOrderId  int     `json:"purchase_order_id"`
+	ItemId           int     `json:"item_id"`
+	StoreId          int     `json:"store_id"`
+	ProductId        int     `json:"product_id"`
+	Quantity         int     `json:"quantity"`
+	ReceivedQuantity int     `json:"received_quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+}
+
+type PurchaseOrderPrimaryKey struct {
+	PurchaseOrderId int `json:"purchase_order_id"`
+}
+
+// CreatePurchaseOrder creates a draft with all of its items.
+type CreatePurchaseOrder struct {
+	SupplierId   int                        `json:"supplier_id"`
+	ExpectedDate string                     `json:"expected_date"`
+	Items        []*CreatePurchaseOrderItem `json:"items"`
+	CreatedBy    string                     `json:"-"`
+}
+
+type CreatePurchaseOrderItem struct {
+	StoreId   int     `json:"store_id"`
+	ProductId int     `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	UnitCost  float64 `json:"unit_cost"`
+}
+
+type ChangePurchaseOrderStatus struct {
+	PurchaseOrderId int    `json:"-"`
+	Status          int16  `json:"-"`
+	ChangedBy       string `json:"-"`
+	Comment         string `json:"comment"`
+}
+
+// ReceivePurchaseOrder is one goods receipt, it adds the quantities to the items and to the
+// stock of their stores.
+type ReceivePurchaseOrder struct {
+	PurchaseOrderId int                         `json:"-"`
+	Items           []*ReceivePurchaseOrderItem `json:"items"`
+	ReceivedBy      string                      `json:"-"`
+	Comment         string                      `json:"comment"`
+}
+
+type ReceivePurchaseOrderItem struct {
+	ItemId   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+type PurchaseOrderStatusHistory struct {
+	HistoryId       int    `json:"history_id"`
+	PurchaseOrderId int    `json:"purchase_order_id"`
+	FromStatus      int16  `json:"from_status"`
+	ToStatus        int16  `json:"to_status"`
+	ChangedBy       string `json:"changed_by"`
+	Comment         string `json:"comment"`
+	ChangedAt       string `json:"changed_at"`
+}
+
+type GetPurchaseOrderStatusHistoryResponse struct {
+	Count   int                           `json:"count"`
+	History []*PurchaseOrderStatusHistory `json:"history"`
+}
+
+type GetListPurchaseOrderRequest struct {
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	SupplierId int `json:"supplier_id"`
+	// StoreId keeps purchase orders with at least one item for the store
+	StoreId int   `json:"store_id"`
+	Status  int16 `json:"status"`
+}
+
+type GetListPurchaseOrderResponse struct {
+	Count          int              `json:"count"`
+	PurchaseOrders []*PurchaseOrder `json:"purchase_orders"`
+}
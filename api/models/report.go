@@ -18,9 +18,10 @@ type StaffReport struct {
 }
 
 type StaffListRequest struct {
-	Offset int    `json:"offset"`
-	Limit  int    `json:"limit"`
-	Search string `json:"search"`
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+	Search  string `json:"search"`
+	StoreId int    `json:"store_id"`
 }
 
 type StaffListResponse struct {
@@ -31,4 +32,27 @@ type StaffListResponse struct {
 type OrderTotalSum struct {
 	OrderId       int    `json:"order_id"`
 	PromocodeName string `json:"promocode_name"`
-}
\ No newline at end of file
+}
+
+// StockReconciliation is one order line whose items and stock movements disagree.
+// ItemQuantity is what the order holds, MovedQuantity what was taken out of stock for it.
+type StockReconciliation struct {
+	OrderId       int   `json:"order_id"`
+	StoreId       int   `json:"store_id"`
+	ProductId     int   `json:"product_id"`
+	OrderStatus   int16 `json:"order_status"`
+	ItemQuantity  int   `json:"item_quantity"`
+	MovedQuantity int   `json:"moved_quantity"`
+	Drift         int   `json:"drift"`
+}
+
+type StockReconciliationRequest struct {
+	Offset  int `json:"offset"`
+	Limit   int `json:"limit"`
+	StoreId int `json:"store_id"`
+}
+
+type StockReconciliationResponse struct {
+	Count  int                    `json:"count"`
+	Drifts []*StockReconciliation `json:"drifts"`
+}
@@ -43,11 +43,37 @@ type UpdateStock struct {
 }
 
 type GetListStockRequest struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+	StoreId int    `json:"store_id"`
+	After   string `json:"after"`
 }
 
 type GetListStockResponse struct {
-	Count  int         `json:"count"`
-	Stocks []*GetStock `json:"stocks"`
+	Count      int         `json:"count"`
+	Stocks     []*GetStock `json:"stocks"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// Stock movement reasons. Quantity is negative when stock leaves the store.
+const (
+	StockMovementOrderItem        = "order_item"
+	StockMovementItemRemoved      = "order_item_removed"
+	StockMovementOrderDeleted     = "order_deleted"
+	StockMovementOrderCancelled   = "order_cancelled"
+	StockMovementOrderRejected    = "order_rejected"
+	StockMovementPurchaseReceived = "purchase_received"
+)
+
+type StockMovement struct {
+	MovementId int    `json:"movement_id"`
+	StoreId    int    `json:"store_id"`
+	ProductId  int    `json:"product_id"`
+	Quantity   int    `json:"quantity"`
+	Reason     string `json:"reason"`
+	OrderId    int    `json:"order_id"`
+	ItemId     int    `json:"item_id"`
+	// PurchaseOrderId is set for goods receipts, ItemId is then the item of the purchase order
+	PurchaseOrderId int    `json:"purchase_order_id"`
+	CreatedAt       string `json:"created_at"`
 }
@@ -0,0 +1,51 @@
+package models
+
+type Supplier struct {
+	SupplierId   int    `json:"supplier_id"`
+	SupplierName string `json:"supplier_name"`
+	ContactName  string `json:"contact_name"`
+	Phone        string `json:"phone"`
+	Email        string `json:"email"`
+	Street       string `json:"street"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	ZipCode      string `json:"zip_code"`
+}
+
+type SupplierPrimaryKey struct {
+	SupplierId int `json:"supplier_id"`
+}
+
+type CreateSupplier struct {
+	SupplierName string `json:"supplier_name"`
+	ContactName  string `json:"contact_name"`
+	Phone        string `json:"phone"`
+	Email        string `json:"email"`
+	Street       string `json:"street"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	ZipCode      string `json:"zip_code"`
+}
+
+type UpdateSupplier struct {
+	SupplierId   int    `json:"supplier_id"`
+	SupplierName string `json:"supplier_name"`
+	ContactName  string `json:"contact_name"`
+	Phone        string `json:"phone"`
+	Email        string `json:"email"`
+	Street       string `json:"street"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	ZipCode      string `json:"zip_code"`
+}
+
+type GetListSupplierRequest struct {
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Search string `json:"search"`
+}
+
+type GetListSupplierResponse struct {
+	Count     int         `json:"count"`
+	Suppliers []*Supplier `json:"suppliers"`
+}
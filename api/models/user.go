@@ -1,21 +1,81 @@
 package models
 
+const (
+	RoleAdmin        = "admin"
+	RoleStoreManager = "store_manager"
+	RoleCashier      = "cashier"
+	RoleReadOnly     = "read_only"
+)
+
 type User struct {
 	UserId   string `json:"user_id"`
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"-"`
+	Role     string `json:"role"`
+	StaffId  int    `json:"staff_id"`
+	StoreId  int    `json:"store_id"`
 }
 
 type UserPrimaryKey struct {
-	UserId string `json:"user_id"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
 }
 
 type CreateUser struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Role     string `json:"-"`
 }
 
 type LoginUser struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+type UpdateUserPassword struct {
+	UserId   string `json:"user_id"`
+	Password string `json:"password"`
+}
+
+type UpdateUserRole struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type UpdateUserStaff struct {
+	UserId  string `json:"user_id"`
+	StaffId int    `json:"staff_id"`
+}
+
+type RefreshToken struct {
+	TokenHash string `json:"token_hash"`
+	UserId    string `json:"user_id"`
+	ExpiresAt string `json:"expires_at"`
+	RevokedAt string `json:"revoked_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+type RefreshTokenPrimaryKey struct {
+	TokenHash string `json:"token_hash"`
+}
+
+type CreateRefreshToken struct {
+	TokenHash string `json:"token_hash"`
+	UserId    string `json:"user_id"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutUser struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"app/api/models"
+	"app/config"
+	"app/migrations"
+	"app/pkg/admin"
+	"app/pkg/importer"
+	"app/pkg/logger"
+	"app/storage"
+	"app/storage/cached"
+	"app/storage/postgresql"
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const commandUsage = `usage:
+  migrate up [N|all] | down [N|all] | status | version
+  create-admin <username>      reads the password from stdin
+  reset-password <username>    reads the password from stdin, revokes the user's refresh tokens
+  seed bikestores              loads the BikeStores sample data into an empty database
+  seed fake [-stores 2 -products 50 ...]
+  purge -yes                   deletes all shop data, users stay
+  import products|customers|stocks [-dry-run -format csv|ndjson] <file>`
+
+// command runs one of the maintenance subcommands instead of the HTTP server.
+func command(cfg *config.Config, log logger.LoggerI, args []string) error {
+	if args[0] == "migrate" {
+		return migrate(cfg, args[1:])
+	}
+
+	if !cfg.PostgresEnabled {
+		return errors.New("admin commands need postgres, the in-memory storage is gone when they exit")
+	}
+
+	switch args[0] {
+	case "create-admin", "reset-password", "seed", "purge", "import":
+	default:
+		return errors.New(commandUsage)
+	}
+
+	db, err := connectPostgres(cfg)
+	if err != nil {
+		return err
+	}
+
+	// entity writes go through the cache layer and bump the versions the running servers read.
+	// The product lists are only invalidated by the product handlers, and the SQL scripts go
+	// around the cache layer entirely, so the commands that write those drop them below.
+	cache := newCache(cfg, log)
+	defer cache.CloseDB()
+
+	store := cached.NewStorage(postgresql.NewStore(db), cache, cfg, log)
+	defer store.CloseDB()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create-admin", "reset-password":
+		if len(args) != 2 {
+			return errors.New(commandUsage)
+		}
+
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+
+		if args[0] == "create-admin" {
+			id, err := admin.CreateAdmin(ctx, cfg, store, args[1], password)
+			if err != nil {
+				return err
+			}
+			fmt.Println("created admin", args[1], id)
+			return nil
+		}
+
+		err = admin.ResetPassword(ctx, cfg, store, args[1], password)
+		if err != nil {
+			return err
+		}
+		fmt.Println("password reset for", args[1])
+	case "seed":
+		return seed(ctx, db, store, cache, args[1:])
+	case "purge":
+		flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+		yes := flags.Bool("yes", false, "confirm deleting all shop data")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if !*yes {
+			return errors.New("purge deletes all shop data, run it with -yes")
+		}
+
+		counts, err := admin.Purge(ctx, store)
+		printCounts("deleted", counts)
+
+		// a purge that stopped half way has still deleted products
+		invalidateErr := invalidateProducts(cache)
+		if err != nil {
+			return err
+		}
+		return invalidateErr
+	case "import":
+		err := importFile(ctx, cfg, store, args[1:])
+		if err != nil {
+			return err
+		}
+		if args[1] == "products" || args[1] == "stocks" {
+			return invalidateProducts(cache)
+		}
+	}
+
+	return nil
+}
+
+func importFile(ctx context.Context, cfg *config.Config, store storage.StorageI, args []string) error {
+	if len(args) == 0 {
+		return errors.New(commandUsage)
+	}
+
+	var run func(context.Context, storage.StorageI, io.Reader, importer.Options) (*models.ImportReport, error)
+	switch args[0] {
+	case "products":
+		run = importer.Products
+	case "customers":
+		run = importer.Customers
+	case "stocks":
+		run = importer.Stocks
+	default:
+		return errors.New(commandUsage)
+	}
+
+	var opts importer.Options
+
+	flags := flag.NewFlagSet("import "+args[0], flag.ContinueOnError)
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "only validate the file and report")
+	flags.StringVar(&opts.Format, "format", "", "csv or ndjson, defaults to the file extension")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(commandUsage)
+	}
+
+	name := flags.Arg(0)
+	if opts.Format == "" {
+		opts.Format = name
+	}
+	opts.Format = importer.FormatOf(opts.Format)
+	opts.MaxRows = cfg.ImportMaxRows
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := run(ctx, store, file, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range report.Errors {
+		if rowErr.Field != "" {
+			fmt.Printf("line %d: %s %s\n", rowErr.Line, rowErr.Field, rowErr.Message)
+			continue
+		}
+		fmt.Printf("line %d: %s\n", rowErr.Line, rowErr.Message)
+	}
+
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d errors in %s, nothing was imported", len(report.Errors), name)
+	}
+	if report.DryRun {
+		fmt.Printf("%d rows are valid, nothing was imported (dry run)\n", report.Total)
+		return nil
+	}
+
+	fmt.Printf("imported %d of %d rows\n", report.Imported, report.Total)
+	return nil
+}
+
+func seed(ctx context.Context, db *pgxpool.Pool, store storage.StorageI, cache storage.StorageCacheI, args []string) error {
+	if len(args) == 0 {
+		return errors.New(commandUsage)
+	}
+
+	switch args[0] {
+	case "bikestores":
+		if len(args) != 1 {
+			return errors.New(commandUsage)
+		}
+
+		script, err := fs.ReadFile(migrations.Postgres(), "03_insert_tables.up.sql")
+		if err != nil {
+			return err
+		}
+
+		err = postgresql.RunScript(ctx, db, string(script))
+		if err != nil {
+			return fmt.Errorf("%w (the sample data needs an empty database, see purge)", err)
+		}
+		fmt.Println("loaded the BikeStores sample data")
+
+		err = cached.BumpAll(cache)
+		if err != nil {
+			return err
+		}
+		return invalidateProducts(cache)
+	case "fake":
+		var data admin.FakeData
+
+		flags := flag.NewFlagSet("seed fake", flag.ContinueOnError)
+		flags.IntVar(&data.Stores, "stores", 2, "stores to create")
+		flags.IntVar(&data.StaffPerStore, "staff", 3, "staff members per store")
+		flags.IntVar(&data.Categories, "categories", 5, "categories to create")
+		flags.IntVar(&data.Brands, "brands", 5, "brands to create")
+		flags.IntVar(&data.Products, "products", 50, "products to create, every store stocks all of them")
+		flags.IntVar(&data.Customers, "customers", 100, "customers to create")
+		flags.IntVar(&data.Orders, "orders", 200, "orders to place")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		counts, err := admin.SeedFake(ctx, store, data)
+		printCounts("created", counts)
+
+		invalidateErr := invalidateProducts(cache)
+		if err != nil {
+			return err
+		}
+		return invalidateErr
+	default:
+		return errors.New(commandUsage)
+	}
+}
+
+// invalidateProducts drops the product lists the running servers cached.
+func invalidateProducts(cache storage.StorageCacheI) error {
+	return cache.Product().Invalidate(storage.ProductCacheTagAll)
+}
+
+// readPassword reads one line from stdin, so passwords stay out of the shell history.
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "password: ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password on stdin")
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func printCounts(action string, counts admin.Counts) {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		fmt.Printf("%s %d %s\n", action, counts[table], table)
+	}
+}
@@ -3,12 +3,20 @@ package main
 import (
 	"app/api"
 	"app/config"
+	"app/migrations"
 	"app/pkg/logger"
+	"app/storage"
+	"app/storage/cached"
+	"app/storage/memcache"
+	"app/storage/memory"
 	"app/storage/postgresql"
 	"app/storage/redis"
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 func main() {
@@ -38,20 +46,32 @@ func main() {
 		}
 	}()
 
-	store, err := postgresql.NewConnectPostgresql(&cfg)
-	if err != nil {
-		log.Panic("Error connect to postgresql: ", logger.Error(err))
+	if len(os.Args) > 1 {
+		err := command(&cfg, log, os.Args[1:])
+		if err != nil {
+			log.Fatal("Error running "+os.Args[1]+": ", logger.Error(err))
+		}
 		return
 	}
-	defer store.CloseDB()
 
-	cache, err := redis.NewConnectRedis(&cfg)
-	if err != nil {
-		log.Panic("Error connect to redis: ", logger.Error(err))
-		return
+	var store storage.StorageI
+	if cfg.PostgresEnabled {
+		db, err := connectPostgres(&cfg)
+		if err != nil {
+			log.Panic("Error connect to postgresql: ", logger.Error(err))
+			return
+		}
+		store = postgresql.NewStore(db)
+	} else {
+		store = memory.NewStorage()
 	}
+	defer store.CloseDB()
+
+	cache := newCache(&cfg, log)
 	defer cache.CloseDB()
 
+	store = cached.NewStorage(store, cache, &cfg, log)
+
 	r := gin.New()
 
 	r.Use(gin.Recovery(), gin.Logger())
@@ -59,9 +79,38 @@ func main() {
 	api.NewApi(r, &cfg, store, cache, log)
 
 	fmt.Println("Listening Server", cfg.ServerHost+cfg.ServerPort)
-	err = r.Run(cfg.ServerHost + cfg.ServerPort)
+	err := r.Run(cfg.ServerHost + cfg.ServerPort)
 	if err != nil {
 		log.Panic("Error listening server:", logger.Error(err))
 		return
 	}
 }
+
+// connectPostgres opens the pool and refuses a schema the code does not match yet.
+func connectPostgres(cfg *config.Config) (*pgxpool.Pool, error) {
+	db, err := postgresql.NewPool(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := postgresql.NewMigrator(db, migrations.Postgres())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	err = migrator.Check(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func newCache(cfg *config.Config, log logger.LoggerI) storage.StorageCacheI {
+	if cfg.RedisEnabled {
+		return redis.NewConnectRedis(cfg, log)
+	}
+	return memcache.NewCache(cfg)
+}
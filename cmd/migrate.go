@@ -0,0 +1,94 @@
+package main
+
+import (
+	"app/config"
+	"app/migrations"
+	"app/storage/postgresql"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate up [N|all] | down [N|all] | status | version"
+
+// migrate runs `migrate <command>` against the configured database. up applies every pending
+// migration by default, down reverts only the newest one unless told otherwise.
+func migrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := postgresql.NewPool(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := postgresql.NewMigrator(db, migrations.Postgres())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up", "down":
+		steps := 0
+		if args[0] == "down" {
+			steps = 1
+		}
+		if len(args) == 2 && args[1] == "all" {
+			steps = 0
+		} else if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return errors.New(migrateUsage)
+			}
+		}
+
+		var done []*postgresql.Migration
+		if args[0] == "up" {
+			done, err = migrator.Up(ctx, steps)
+		} else {
+			done, err = migrator.Down(ctx, steps)
+		}
+		for _, migration := range done {
+			fmt.Printf("%s %d_%s\n", args[0], migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			fmt.Println("no change")
+		}
+	case "status":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range status {
+			appliedAt := row.AppliedAt
+			if appliedAt == "" {
+				appliedAt = "pending"
+			}
+			fmt.Printf("%d_%s\t%s\n", row.Version, row.Name, appliedAt)
+		}
+	case "version":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d (latest %d)\n", version, migrator.Latest())
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
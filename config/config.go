@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -29,15 +30,45 @@ type Config struct {
 	PostgresPassword string
 	PostgresPort     string
 
+	// PostgresEnabled false runs on the in-memory storage, the data is lost on restart
+	PostgresEnabled bool
+
 	RedisHost     string
 	RedisPort     string
 	RedisPassword string
 	RedisDB       int
 
+	// RedisEnabled false runs on the in-memory cache only
+	RedisEnabled        bool
+	RedisHealthInterval time.Duration
+
+	CacheNamespace  string
+	ProductCacheTTL time.Duration
+
+	// read-through cache TTLs per entity, 0 disables caching of that entity
+	CategoryCacheTTL  time.Duration
+	BrandCacheTTL     time.Duration
+	StoreCacheTTL     time.Duration
+	CustomerCacheTTL  time.Duration
+	StaffCacheTTL     time.Duration
+	PromocodeCacheTTL time.Duration
+
 	DefaultOffset int
 	DefaultLimit  int
 
-	SecretKey string
+	// ImportMaxRows is the most rows a bulk import takes in one file
+	ImportMaxRows int
+
+	SecretKey       string
+	TokenIssuer     string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	BcryptCost             int
+	PasswordMinLength      int
+	PasswordRequireDigit   bool
+	PasswordRequireUpper   bool
+	PasswordRequireSpecial bool
 }
 
 func Load() Config {
@@ -56,16 +87,38 @@ func Load() Config {
 	cfg.PostgresUser = cast.ToString(getOrReturnDefaultValue("POSTGRES_USER", "shokhrukh"))
 	cfg.PostgresPassword = cast.ToString(getOrReturnDefaultValue("POSTGRES_PASSWORD", "12345"))
 	cfg.PostgresDatabase = cast.ToString(getOrReturnDefaultValue("POSTGRES_DATABASE", "exam"))
+	cfg.PostgresEnabled = cast.ToBool(getOrReturnDefaultValue("POSTGRES_ENABLED", true))
 
 	cfg.RedisHost = cast.ToString(getOrReturnDefaultValue("REDIS_HOST", "localhost:"))
 	cfg.RedisPort = cast.ToString(getOrReturnDefaultValue("REDIS_PORT", 6379))
 	cfg.RedisPassword = cast.ToString(getOrReturnDefaultValue("REDIS_PASSWORD", ""))
 	cfg.RedisDB = cast.ToInt(getOrReturnDefaultValue("REDIS_DB", "shokhrukh"))
+	cfg.RedisEnabled = cast.ToBool(getOrReturnDefaultValue("REDIS_ENABLED", true))
+	cfg.RedisHealthInterval = cast.ToDuration(getOrReturnDefaultValue("REDIS_HEALTH_INTERVAL", "5s"))
+
+	cfg.CacheNamespace = cast.ToString(getOrReturnDefaultValue("CACHE_NAMESPACE", "app"))
+	cfg.ProductCacheTTL = cast.ToDuration(getOrReturnDefaultValue("PRODUCT_CACHE_TTL", "5m"))
+	cfg.CategoryCacheTTL = cast.ToDuration(getOrReturnDefaultValue("CATEGORY_CACHE_TTL", "10m"))
+	cfg.BrandCacheTTL = cast.ToDuration(getOrReturnDefaultValue("BRAND_CACHE_TTL", "10m"))
+	cfg.StoreCacheTTL = cast.ToDuration(getOrReturnDefaultValue("STORE_CACHE_TTL", "10m"))
+	cfg.CustomerCacheTTL = cast.ToDuration(getOrReturnDefaultValue("CUSTOMER_CACHE_TTL", "1m"))
+	cfg.StaffCacheTTL = cast.ToDuration(getOrReturnDefaultValue("STAFF_CACHE_TTL", "5m"))
+	cfg.PromocodeCacheTTL = cast.ToDuration(getOrReturnDefaultValue("PROMOCODE_CACHE_TTL", "1m"))
 
 	cfg.SecretKey = cast.ToString(getOrReturnDefaultValue("SERVER_KEY", "hello"))
+	cfg.TokenIssuer = cast.ToString(getOrReturnDefaultValue("TOKEN_ISSUER", "app"))
+	cfg.AccessTokenTTL = cast.ToDuration(getOrReturnDefaultValue("ACCESS_TOKEN_TTL", "15m"))
+	cfg.RefreshTokenTTL = cast.ToDuration(getOrReturnDefaultValue("REFRESH_TOKEN_TTL", "720h"))
+
+	cfg.BcryptCost = cast.ToInt(getOrReturnDefaultValue("BCRYPT_COST", 10))
+	cfg.PasswordMinLength = cast.ToInt(getOrReturnDefaultValue("PASSWORD_MIN_LENGTH", 8))
+	cfg.PasswordRequireDigit = cast.ToBool(getOrReturnDefaultValue("PASSWORD_REQUIRE_DIGIT", true))
+	cfg.PasswordRequireUpper = cast.ToBool(getOrReturnDefaultValue("PASSWORD_REQUIRE_UPPER", false))
+	cfg.PasswordRequireSpecial = cast.ToBool(getOrReturnDefaultValue("PASSWORD_REQUIRE_SPECIAL", false))
 
 	cfg.DefaultOffset = cast.ToInt(getOrReturnDefaultValue("OFFSET", 0))
 	cfg.DefaultLimit = cast.ToInt(getOrReturnDefaultValue("LIMIT", 10))
+	cfg.ImportMaxRows = cast.ToInt(getOrReturnDefaultValue("IMPORT_MAX_ROWS", 10000))
 
 	return cfg
 }
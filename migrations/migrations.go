@@ -0,0 +1,20 @@
+// Package migrations embeds the SQL migrations so the service binary can apply them itself.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed postgres/*.sql
+var postgres embed.FS
+
+// Postgres returns the postgres migrations, every version has a
+// <version>_<name>.up.sql and a <version>_<name>.down.sql file.
+func Postgres() fs.FS {
+	sub, err := fs.Sub(postgres, "postgres")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
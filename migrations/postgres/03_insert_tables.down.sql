@@ -0,0 +1 @@
+TRUNCATE order_items, orders, stocks, products, categories, brands, customers, staffs, stores RESTART IDENTITY CASCADE;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS users (
+	user_id VARCHAR PRIMARY KEY,
+	username VARCHAR NOT NULL,
+	password VARCHAR NOT NULL
+);
+
+CREATE TABLE refresh_tokens (
+	token_hash VARCHAR (64) PRIMARY KEY,
+	user_id VARCHAR NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	revoked_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX refresh_tokens_user_id_idx ON refresh_tokens (user_id);
@@ -0,0 +1,3 @@
+-- Roles: admin, store_manager, cashier, read_only
+ALTER TABLE users ADD COLUMN role VARCHAR (20) NOT NULL DEFAULT 'read_only';
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'store_manager', 'cashier', 'read_only'));
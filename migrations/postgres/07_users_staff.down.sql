@@ -0,0 +1,2 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_staff_id_fkey;
+ALTER TABLE users DROP COLUMN IF EXISTS staff_id;
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN staff_id INT UNIQUE;
+ALTER TABLE users ADD CONSTRAINT users_staff_id_fkey FOREIGN KEY (staff_id) REFERENCES staffs (staff_id) ON DELETE SET NULL ON UPDATE CASCADE;
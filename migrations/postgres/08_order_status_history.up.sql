@@ -0,0 +1,16 @@
+-- Order status: 1 = Pending; 2 = Processing; 3 = Rejected; 4 = Completed; 5 = Shipped; 6 = Cancelled
+ALTER TABLE orders ADD CONSTRAINT orders_order_status_check CHECK (order_status BETWEEN 1 AND 6);
+
+CREATE TABLE order_status_history (
+	history_id SERIAL PRIMARY KEY,
+	order_id INT NOT NULL,
+	from_status SMALLINT,
+	to_status SMALLINT NOT NULL,
+	changed_by VARCHAR,
+	comment VARCHAR (255) NOT NULL DEFAULT '',
+	changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	FOREIGN KEY (order_id) REFERENCES orders (order_id) ON DELETE CASCADE ON UPDATE CASCADE,
+	FOREIGN KEY (changed_by) REFERENCES users (user_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX order_status_history_order_id_idx ON order_status_history (order_id);
@@ -0,0 +1,24 @@
+-- Ledger of every stock change made by orders, quantity is negative when stock leaves the store.
+-- order_id has no foreign key so the ledger outlives deleted orders.
+CREATE TABLE stock_movements (
+	movement_id SERIAL PRIMARY KEY,
+	store_id INT NOT NULL,
+	product_id INT NOT NULL,
+	quantity INT NOT NULL,
+	reason VARCHAR (30) NOT NULL,
+	order_id INT,
+	item_id INT,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	FOREIGN KEY (store_id) REFERENCES stores (store_id) ON DELETE CASCADE ON UPDATE CASCADE,
+	FOREIGN KEY (product_id) REFERENCES products (product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX stock_movements_order_id_idx ON stock_movements (order_id);
+CREATE INDEX stock_movements_store_product_idx ON stock_movements (store_id, product_id);
+
+-- Existing items of orders that were not rejected consumed their stock when they were added.
+INSERT INTO stock_movements (store_id, product_id, quantity, reason, order_id, item_id)
+SELECT o.store_id, oi.product_id, -oi.quantity, 'order_item', oi.order_id, oi.item_id
+FROM order_items AS oi
+JOIN orders AS o ON o.order_id = oi.order_id
+WHERE o.order_status NOT IN (3, 6);
@@ -0,0 +1,6 @@
+ALTER TABLE promocodes ALTER COLUMN promocode_id DROP IDENTITY IF EXISTS;
+ALTER TABLE orders ALTER COLUMN order_id DROP IDENTITY IF EXISTS;
+ALTER TABLE staffs ALTER COLUMN staff_id DROP IDENTITY IF EXISTS;
+ALTER TABLE products ALTER COLUMN product_id DROP IDENTITY IF EXISTS;
+ALTER TABLE categories ALTER COLUMN category_id DROP IDENTITY IF EXISTS;
+ALTER TABLE brands ALTER COLUMN brand_id DROP IDENTITY IF EXISTS;
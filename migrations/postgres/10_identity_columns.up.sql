@@ -0,0 +1,18 @@
+-- Let the database number new rows instead of SELECT MAX(id) + 1, which collides under concurrent inserts.
+ALTER TABLE brands ALTER COLUMN brand_id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE categories ALTER COLUMN category_id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE products ALTER COLUMN product_id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE staffs ALTER COLUMN staff_id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE orders ALTER COLUMN order_id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE promocodes ALTER COLUMN promocode_id ADD GENERATED BY DEFAULT AS IDENTITY;
+
+-- Existing rows were inserted with explicit ids, move every sequence past them.
+-- customers and stores were already SERIAL but their sequences never advanced.
+SELECT setval(pg_get_serial_sequence('brands', 'brand_id'), COALESCE((SELECT MAX(brand_id) FROM brands), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('categories', 'category_id'), COALESCE((SELECT MAX(category_id) FROM categories), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('products', 'product_id'), COALESCE((SELECT MAX(product_id) FROM products), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('staffs', 'staff_id'), COALESCE((SELECT MAX(staff_id) FROM staffs), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('orders', 'order_id'), COALESCE((SELECT MAX(order_id) FROM orders), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('promocodes', 'promocode_id'), COALESCE((SELECT MAX(promocode_id) FROM promocodes), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('customers', 'customer_id'), COALESCE((SELECT MAX(customer_id) FROM customers), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('stores', 'store_id'), COALESCE((SELECT MAX(store_id) FROM stores), 0) + 1, false);
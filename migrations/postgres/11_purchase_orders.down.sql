@@ -0,0 +1,6 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP TABLE IF EXISTS purchase_order_status_history;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
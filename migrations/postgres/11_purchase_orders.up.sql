@@ -0,0 +1,62 @@
+CREATE TABLE suppliers (
+	supplier_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	supplier_name VARCHAR (255) NOT NULL,
+	contact_name VARCHAR (255),
+	phone VARCHAR (25),
+	email VARCHAR (255) NOT NULL UNIQUE,
+	street VARCHAR (255),
+	city VARCHAR (50),
+	state VARCHAR (25),
+	zip_code VARCHAR (5)
+);
+
+-- Purchase order status: 1 = Draft; 2 = Ordered; 3 = Partially received; 4 = Received; 5 = Cancelled
+CREATE TABLE purchase_orders (
+	purchase_order_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	supplier_id INT NOT NULL,
+	status SMALLINT NOT NULL CHECK (status BETWEEN 1 AND 5),
+	order_date DATE NOT NULL,
+	expected_date DATE,
+	FOREIGN KEY (supplier_id) REFERENCES suppliers (supplier_id) ON UPDATE CASCADE
+);
+
+CREATE INDEX purchase_orders_supplier_id_idx ON purchase_orders (supplier_id);
+
+-- Every line is delivered to one store, received_quantity grows with each goods receipt.
+CREATE TABLE purchase_order_items (
+	purchase_order_id INT NOT NULL,
+	item_id INT NOT NULL,
+	store_id INT NOT NULL,
+	product_id INT NOT NULL,
+	quantity INT NOT NULL CHECK (quantity > 0),
+	received_quantity INT NOT NULL DEFAULT 0,
+	unit_cost DECIMAL (10, 2) NOT NULL CHECK (unit_cost >= 0),
+	PRIMARY KEY (purchase_order_id, item_id),
+	UNIQUE (purchase_order_id, store_id, product_id),
+	CONSTRAINT purchase_order_items_received_quantity_check CHECK (received_quantity BETWEEN 0 AND quantity),
+	FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (purchase_order_id) ON DELETE CASCADE ON UPDATE CASCADE,
+	FOREIGN KEY (store_id) REFERENCES stores (store_id) ON DELETE CASCADE ON UPDATE CASCADE,
+	FOREIGN KEY (product_id) REFERENCES products (product_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX purchase_order_items_store_id_idx ON purchase_order_items (store_id);
+
+CREATE TABLE purchase_order_status_history (
+	history_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	purchase_order_id INT NOT NULL,
+	from_status SMALLINT,
+	to_status SMALLINT NOT NULL,
+	changed_by VARCHAR,
+	comment VARCHAR (255) NOT NULL DEFAULT '',
+	changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (purchase_order_id) ON DELETE CASCADE ON UPDATE CASCADE,
+	FOREIGN KEY (changed_by) REFERENCES users (user_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX purchase_order_status_history_purchase_order_id_idx ON purchase_order_status_history (purchase_order_id);
+
+-- Goods receipts are recorded in the ledger with the purchase order and its item, like order_id
+-- it has no foreign key so the ledger outlives deleted purchase orders.
+ALTER TABLE stock_movements ADD COLUMN purchase_order_id INT;
+
+CREATE INDEX stock_movements_purchase_order_id_idx ON stock_movements (purchase_order_id);
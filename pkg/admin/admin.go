@@ -0,0 +1,79 @@
+// Package admin holds the maintenance jobs behind the command line tool. Everything goes
+// through storage.StorageI, so the jobs keep the same rules and cache invalidation as the API.
+package admin
+
+import (
+	"app/api/models"
+	"app/config"
+	"app/pkg/helper"
+	"app/storage"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+var ErrInvalidUsername = errors.New("username must start with a letter and be 6-30 letters, digits or underscores")
+
+func passwordPolicy(cfg *config.Config) helper.PasswordPolicy {
+	return helper.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		RequireDigit:   cfg.PasswordRequireDigit,
+		RequireUpper:   cfg.PasswordRequireUpper,
+		RequireSpecial: cfg.PasswordRequireSpecial,
+	}
+}
+
+// CreateAdmin registers a user with the admin role, with the same username and password rules
+// as /register. An existing username is a unique violation.
+func CreateAdmin(ctx context.Context, cfg *config.Config, store storage.StorageI, username, password string) (string, error) {
+	if !helper.IsValidLogin(username) {
+		return "", ErrInvalidUsername
+	}
+
+	err := helper.ValidPassword(password, passwordPolicy(cfg))
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := helper.HashPassword(password, cfg.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+
+	// the role goes in with the insert, a failed second write would leave a read_only user
+	// holding the username
+	return store.User().Create(ctx, &models.CreateUser{Username: username, Password: hash, Role: models.RoleAdmin})
+}
+
+// ResetPassword sets a new password and revokes the user's refresh tokens, so every session
+// has to log in again once its access token expires.
+func ResetPassword(ctx context.Context, cfg *config.Config, store storage.StorageI, username, password string) error {
+	user, err := store.User().GetById(ctx, &models.UserPrimaryKey{Username: username})
+	if err != nil {
+		return err
+	}
+
+	err = helper.ValidPassword(password, passwordPolicy(cfg))
+	if err != nil {
+		return err
+	}
+
+	hash, err := helper.HashPassword(password, cfg.BcryptCost)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.User().UpdatePassword(ctx, &models.UpdateUserPassword{UserId: user.UserId, Password: hash})
+	if err != nil {
+		return err
+	}
+
+	_, err = store.RefreshToken().RevokeByUser(ctx, &models.UserPrimaryKey{UserId: user.UserId})
+	return err
+}
+
+// notFound reports whether err means the row is already gone.
+func notFound(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
@@ -0,0 +1,234 @@
+package admin
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+)
+
+// purgePage is how many rows Purge lists at a time.
+const purgePage = 100
+
+// Purge deletes every order, purchase order, supplier, staff member, store, customer, product,
+// category, brand and promocode. Users and their tokens stay, so the admins can still log in afterwards.
+func Purge(ctx context.Context, store storage.StorageI) (Counts, error) {
+	counts := Counts{}
+
+	// orders first, they reference staff of any store
+	err := purge(ctx, counts, "orders", func() ([]int, error) {
+		list, err := store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, order := range list.Orders {
+			ids = append(ids, order.OrderId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Order().Delete(ctx, &models.OrderPrimaryKey{OrderId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	// purchase orders before their suppliers, received stock goes with the stores
+	err = purge(ctx, counts, "purchase_orders", func() ([]int, error) {
+		list, err := store.PurchaseOrder().GetList(ctx, &models.GetListPurchaseOrderRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, purchaseOrder := range list.PurchaseOrders {
+			ids = append(ids, purchaseOrder.PurchaseOrderId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.PurchaseOrder().Delete(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = purge(ctx, counts, "suppliers", func() ([]int, error) {
+		list, err := store.Supplier().GetList(ctx, &models.GetListSupplierRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, supplier := range list.Suppliers {
+			ids = append(ids, supplier.SupplierId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Supplier().Delete(ctx, &models.SupplierPrimaryKey{SupplierId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = purgeStaff(ctx, counts, store)
+	if err != nil {
+		return counts, err
+	}
+
+	err = purge(ctx, counts, "stores", func() ([]int, error) {
+		list, err := store.Store().GetList(ctx, &models.GetListStoreRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, row := range list.Stores {
+			ids = append(ids, row.StoreId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Store().Delete(ctx, &models.StorePrimaryKey{StoreId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = purge(ctx, counts, "customers", func() ([]int, error) {
+		list, err := store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, customer := range list.Customers {
+			ids = append(ids, customer.CustomerId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Customer().Delete(ctx, &models.CustomerPrimaryKey{CustomerId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	// products go with their category
+	err = purge(ctx, counts, "categories", func() ([]int, error) {
+		list, err := store.Category().GetList(ctx, &models.GetListCategoryRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, category := range list.Categories {
+			ids = append(ids, category.CategoryId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Category().Delete(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = purge(ctx, counts, "brands", func() ([]int, error) {
+		list, err := store.Brand().GetList(ctx, &models.GetListBrandRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, brand := range list.Brands {
+			ids = append(ids, brand.BrandId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Brand().Delete(ctx, &models.BrandPrimaryKey{BrandId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = purge(ctx, counts, "promocodes", func() ([]int, error) {
+		list, err := store.Promocode().GetList(ctx, &models.GetListPromocodeRequest{Limit: purgePage})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, promocode := range list.Promocodes {
+			ids = append(ids, promocode.PromocodeId)
+		}
+		return ids, nil
+	}, func(id int) (int64, error) {
+		return store.Promocode().Delete(ctx, &models.PromocodePrimaryKey{PromocodeId: id})
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	return counts, nil
+}
+
+// purge deletes the first page of a table until the page comes back empty.
+func purge(ctx context.Context, counts Counts, table string, page func() ([]int, error), remove func(id int) (int64, error)) error {
+	for {
+		ids, err := page()
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		var deleted int64
+		for _, id := range ids {
+			rows, err := remove(id)
+			if err != nil && !notFound(err) {
+				return fmt.Errorf("delete from %s %d: %w", table, id, err)
+			}
+			deleted += rows
+		}
+		counts[table] += int(deleted)
+
+		if deleted == 0 {
+			return fmt.Errorf("delete from %s: rows are listed but nothing was deleted", table)
+		}
+	}
+}
+
+// purgeStaff deletes staff members who manage nobody first, until everybody is gone.
+func purgeStaff(ctx context.Context, counts Counts, store storage.StorageI) error {
+	var ids []int
+	for offset := 0; ; offset += purgePage {
+		list, err := store.Staff().GetList(ctx, &models.GetListStaffRequest{Offset: offset, Limit: purgePage})
+		if err != nil {
+			return err
+		}
+		for _, staff := range list.Staffs {
+			ids = append(ids, staff.StaffId)
+		}
+		if len(list.Staffs) < purgePage {
+			break
+		}
+	}
+
+	for len(ids) > 0 {
+		var managers []int
+		for _, id := range ids {
+			rows, err := store.Staff().Delete(ctx, &models.StaffPrimaryKey{StaffId: id})
+
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				managers = append(managers, id)
+				continue
+			}
+			if err != nil && !notFound(err) {
+				return fmt.Errorf("delete from staffs %d: %w", id, err)
+			}
+			counts["staffs"] += int(rows)
+		}
+
+		if len(managers) == len(ids) {
+			return fmt.Errorf("delete from staffs: %d staff members still reference each other", len(managers))
+		}
+		ids = managers
+	}
+
+	return nil
+}
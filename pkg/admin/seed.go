@@ -0,0 +1,174 @@
+package admin
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/bxcodec/faker/v3"
+)
+
+// FakeData is how much generated data SeedFake creates.
+type FakeData struct {
+	Stores        int
+	StaffPerStore int
+	Categories    int
+	Brands        int
+	Products      int
+	Customers     int
+	Orders        int
+}
+
+// Counts is the number of rows a job created or deleted, by table.
+type Counts map[string]int
+
+// SeedFake fills the storage with generated data: every store stocks every product and the
+// orders are placed like the API does, so stock and the stock ledger stay consistent. Orders
+// a store no longer has stock for are skipped.
+func SeedFake(ctx context.Context, store storage.StorageI, data FakeData) (Counts, error) {
+	counts := Counts{}
+
+	// emails are unique per table, the suffix keeps faker's picks from colliding
+	suffix := time.Now().Format("20060102150405")
+	email := func(table string, i int) string {
+		return fmt.Sprintf("%s.%d.%s@%s", table, i, suffix, strings.Split(faker.Email(), "@")[1])
+	}
+
+	var categoryIds, brandIds, productIds, storeIds, customerIds []int
+	staffIds := map[int][]int{}
+
+	for i := 0; i < data.Categories; i++ {
+		id, err := store.Category().Create(ctx, &models.CreateCategory{CategoryName: faker.Word() + " " + faker.Word()})
+		if err != nil {
+			return counts, err
+		}
+		categoryIds = append(categoryIds, id)
+		counts["categories"]++
+	}
+
+	for i := 0; i < data.Brands; i++ {
+		id, err := store.Brand().Create(ctx, &models.CreateBrand{BrandName: faker.LastName()})
+		if err != nil {
+			return counts, err
+		}
+		brandIds = append(brandIds, id)
+		counts["brands"]++
+	}
+
+	if data.Products > 0 && (len(categoryIds) == 0 || len(brandIds) == 0) {
+		return counts, errors.New("products need at least one category and one brand")
+	}
+
+	for i := 0; i < data.Products; i++ {
+		id, err := store.Product().Create(ctx, &models.CreateProduct{
+			ProductName: faker.LastName() + " " + faker.Word(),
+			BrandId:     brandIds[rand.Intn(len(brandIds))],
+			CategoryId:  categoryIds[rand.Intn(len(categoryIds))],
+			ModelYear:   2016 + rand.Intn(8),
+			ListPrice:   float32(100+rand.Intn(5000)) - 0.01,
+		})
+		if err != nil {
+			return counts, err
+		}
+		productIds = append(productIds, id)
+		counts["products"]++
+	}
+
+	for i := 0; i < data.Stores; i++ {
+		storeId, err := store.Store().Create(ctx, &models.CreateStore{
+			StoreName: faker.LastName() + " Bikes",
+			Phone:     faker.Phonenumber(),
+			Email:     email("store", i),
+		})
+		if err != nil {
+			return counts, err
+		}
+		storeIds = append(storeIds, storeId)
+		counts["stores"]++
+
+		// the first staff member of a store manages the others
+		var managerId int
+		for j := 0; j < data.StaffPerStore; j++ {
+			staffId, err := store.Staff().Create(ctx, &models.CreateStaff{
+				FirstName: faker.FirstName(),
+				LastName:  faker.LastName(),
+				Email:     email("staff", i*data.StaffPerStore+j),
+				Phone:     faker.Phonenumber(),
+				Active:    1,
+				StoreId:   storeId,
+				ManagerId: managerId,
+			})
+			if err != nil {
+				return counts, err
+			}
+			if managerId == 0 {
+				managerId = staffId
+			}
+			staffIds[storeId] = append(staffIds[storeId], staffId)
+			counts["staffs"]++
+		}
+
+		for _, productId := range productIds {
+			_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: storeId, ProductId: productId, Quantity: 10 + rand.Intn(40)})
+			if err != nil {
+				return counts, err
+			}
+			counts["stocks"]++
+		}
+	}
+
+	for i := 0; i < data.Customers; i++ {
+		id, err := store.Customer().Create(ctx, &models.CreateCustomer{
+			FirstName: faker.FirstName(),
+			LastName:  faker.LastName(),
+			Phone:     faker.Phonenumber(),
+			Email:     email("customer", i),
+		})
+		if err != nil {
+			return counts, err
+		}
+		customerIds = append(customerIds, id)
+		counts["customers"]++
+	}
+
+	if data.Orders > 0 && (len(customerIds) == 0 || len(productIds) == 0 || data.StaffPerStore == 0 || len(storeIds) == 0) {
+		return counts, errors.New("orders need customers, products and stores with staff")
+	}
+
+	for i := 0; i < data.Orders; i++ {
+		storeId := storeIds[rand.Intn(len(storeIds))]
+
+		place := &models.PlaceOrder{
+			CustomerId:   customerIds[rand.Intn(len(customerIds))],
+			RequiredDate: time.Now().AddDate(0, 0, 1+rand.Intn(14)).Format("2006-01-02"),
+			StoreId:      storeId,
+			StaffId:      staffIds[storeId][rand.Intn(len(staffIds[storeId]))],
+		}
+		for _, n := range rand.Perm(len(productIds))[:1+rand.Intn(min(3, len(productIds)))] {
+			place.Items = append(place.Items, &models.PlaceOrderItem{ProductId: productIds[n], Quantity: 1 + rand.Intn(2)})
+		}
+
+		_, err := store.Order().Place(ctx, place)
+		if errors.Is(err, storage.ErrNotEnoughStock) {
+			continue
+		}
+		if err != nil {
+			return counts, err
+		}
+		counts["orders"]++
+	}
+
+	return counts, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor turns a page position into an opaque, URL safe cursor.
+func EncodeCursor(v interface{}) (string, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(body), nil
+}
+
+// DecodeCursor reads a cursor made by EncodeCursor into v.
+func DecodeCursor(cursor string, v interface{}) error {
+	body, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
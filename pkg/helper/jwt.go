@@ -2,30 +2,46 @@ package helper
 
 import (
 	"app/api/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
-var jwtKey = []byte("secret_key")
-
 type Claims struct {
+	UserId   string `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role"`
+	StaffId  int    `json:"staff_id,omitempty"`
+	StoreId  int    `json:"store_id,omitempty"`
 	jwt.StandardClaims
 }
 
-func MakeJWT(user *models.LoginUser) (string, error) {
-	expirationTime := time.Now().Add(time.Hour * 10)
+func MakeJWT(user *models.User, secretKey, issuer string, ttl time.Duration) (string, error) {
+	now := time.Now()
 
 	claims := &Claims{
+		UserId:   user.UserId,
 		Username: user.Username,
+		Role:     user.Role,
+		StaffId:  user.StaffId,
+		StoreId:  user.StoreId,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
+			Id:        uuid.New().String(),
+			Subject:   user.UserId,
+			Issuer:    issuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	tokenString, err := token.SignedString([]byte(secretKey))
 
 	if err != nil {
 		return "", err
@@ -33,3 +49,50 @@ func MakeJWT(user *models.LoginUser) (string, error) {
 
 	return tokenString, nil
 }
+
+// ParseJWT verifies the signature, expiry and issuer of the token and returns its claims.
+func ParseJWT(tokenString, secretKey, issuer string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if !claims.VerifyIssuer(issuer, true) {
+		return nil, errors.New("invalid token issuer")
+	}
+
+	if claims.UserId == "" || claims.Id == "" {
+		return nil, errors.New("token has no user or id")
+	}
+
+	return claims, nil
+}
+
+// NewRefreshToken returns an opaque refresh token and the hash under which it is stored.
+func NewRefreshToken() (string, string, error) {
+	buffer := make([]byte, 32)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := hex.EncodeToString(buffer)
+
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,49 @@
+package helper
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash is compared against when a user does not exist, so that unknown
+// usernames take as long to reject as wrong passwords.
+var (
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+func HashPassword(password string, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CheckPassword compares a stored password with the given one. Rows created before
+// passwords were hashed still hold plain text; they are compared in constant time and
+// reported with needsRehash so the caller can upgrade them.
+func CheckPassword(stored, password string) (match bool, needsRehash bool) {
+	if !IsPasswordHash(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1, true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil, false
+}
+
+// WastePasswordCheck burns the same time as a real bcrypt comparison.
+func WastePasswordCheck(password string, cost int) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), cost)
+	})
+
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+func IsPasswordHash(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") || strings.HasPrefix(stored, "$2b$") || strings.HasPrefix(stored, "$2y$")
+}
@@ -2,7 +2,10 @@ package helper
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"time"
+	"unicode"
 )
 
 func ValidPinfl(pinfl string) error {
@@ -63,3 +66,52 @@ func IsValidPrice(price string) bool {
 	r := regexp.MustCompile(`^\d+$`)
 	return r.MatchString(price)
 }
+
+// IsValidDate reports whether date is a calendar date in YYYY-MM-DD form
+func IsValidDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
+}
+
+type PasswordPolicy struct {
+	MinLength      int
+	RequireDigit   bool
+	RequireUpper   bool
+	RequireSpecial bool
+}
+
+// ValidPassword ...
+func ValidPassword(password string, policy PasswordPolicy) error {
+	if len(password) < policy.MinLength {
+		return fmt.Errorf("password must be at least %d characters", policy.MinLength)
+	}
+
+	// bcrypt ignores everything after 72 bytes
+	if len(password) > 72 {
+		return errors.New("password must be at most 72 characters")
+	}
+
+	var hasDigit, hasUpper, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	if policy.RequireDigit && !hasDigit {
+		return errors.New("password must contain a digit")
+	}
+	if policy.RequireUpper && !hasUpper {
+		return errors.New("password must contain an upper case letter")
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		return errors.New("password must contain a special character")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"app/api/models"
+	"app/pkg/helper"
+	"app/storage"
+	"context"
+	"io"
+)
+
+var customerColumns = []column[models.CreateCustomer]{
+	stringColumn("first_name", func(c *models.CreateCustomer) *string { return &c.FirstName }),
+	stringColumn("last_name", func(c *models.CreateCustomer) *string { return &c.LastName }),
+	stringColumn("phone", func(c *models.CreateCustomer) *string { return &c.Phone }),
+	stringColumn("email", func(c *models.CreateCustomer) *string { return &c.Email }),
+	stringColumn("street", func(c *models.CreateCustomer) *string { return &c.Street }),
+	stringColumn("city", func(c *models.CreateCustomer) *string { return &c.City }),
+	stringColumn("state", func(c *models.CreateCustomer) *string { return &c.State }),
+	floatColumn("zip_code", func(c *models.CreateCustomer) *float64 { return &c.ZipCode }),
+}
+
+// customerLengths are the VARCHAR sizes of the customers table.
+var customerLengths = []struct {
+	field  string
+	value  func(c *models.CreateCustomer) string
+	length int
+}{
+	{"first_name", func(c *models.CreateCustomer) string { return c.FirstName }, 255},
+	{"last_name", func(c *models.CreateCustomer) string { return c.LastName }, 255},
+	{"phone", func(c *models.CreateCustomer) string { return c.Phone }, 25},
+	{"email", func(c *models.CreateCustomer) string { return c.Email }, 255},
+	{"street", func(c *models.CreateCustomer) string { return c.Street }, 255},
+	{"city", func(c *models.CreateCustomer) string { return c.City }, 50},
+	{"state", func(c *models.CreateCustomer) string { return c.State }, 25},
+}
+
+// Customers imports customers, each needs a name and a valid email.
+func Customers(ctx context.Context, store storage.StorageI, r io.Reader, opts Options) (*models.ImportReport, error) {
+	rows, err := decode(r, opts, customerColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			continue
+		}
+		customer := row.value
+
+		if !required(customer.FirstName) {
+			row.fail("first_name", "is required")
+		}
+		if !required(customer.LastName) {
+			row.fail("last_name", "is required")
+		}
+		if !required(customer.Email) {
+			row.fail("email", "is required")
+		} else if !helper.IsValidEmail(customer.Email) {
+			row.fail("email", "is not a valid email")
+		}
+
+		for _, column := range customerLengths {
+			if len(column.value(customer)) > column.length {
+				row.fail(column.field, "must be at most %d characters", column.length)
+			}
+		}
+
+		if customer.ZipCode < 0 {
+			row.fail("zip_code", "must not be negative")
+		}
+	}
+
+	return run(ctx, rows, opts, store.Customer().Import)
+}
@@ -0,0 +1,313 @@
+// Package importer parses bulk imports of products, customers and stock, validates every row
+// and hands the valid ones to the storage in one go. The API and the command line share it.
+package importer
+
+import (
+	"app/api/models"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// ErrInvalidFile is returned when the file as a whole can not be read, as opposed to a row
+// that fails validation and ends up in the report.
+var ErrInvalidFile = errors.New("invalid import file")
+
+type Options struct {
+	Format string
+	// DryRun validates every row and reports without importing anything
+	DryRun bool
+	// MaxRows refuses larger files, 0 means no limit
+	MaxRows int
+	// StoreId limits a stock import to one store, 0 allows every store
+	StoreId int
+}
+
+// FormatOf returns the format of a content type such as text/csv or of a file name such as
+// stock.ndjson, "" when it is neither.
+func FormatOf(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.Split(name, ";")[0]))
+
+	switch strings.TrimPrefix(path.Ext(name), ".") {
+	case "csv":
+		return FormatCSV
+	case "ndjson", "jsonl":
+		return FormatNDJSON
+	}
+
+	switch name {
+	case "csv", "text/csv", "application/csv":
+		return FormatCSV
+	case "ndjson", "jsonl", "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON
+	}
+
+	return ""
+}
+
+// row is one record of the file and what is wrong with it.
+type row[T any] struct {
+	line   int
+	value  *T
+	errors []*models.ImportRowError
+}
+
+func (r *row[T]) fail(field, format string, args ...interface{}) {
+	r.errors = append(r.errors, &models.ImportRowError{
+		Line:    r.line,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// column parses one CSV column into the row value. Empty cells are left at their zero value.
+type column[T any] struct {
+	name string
+	set  func(value *T, cell string) error
+}
+
+func intColumn[T any](name string, field func(*T) *int) column[T] {
+	return column[T]{name, func(value *T, cell string) error {
+		n, err := strconv.Atoi(cell)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		*field(value) = n
+		return nil
+	}}
+}
+
+func floatColumn[T any](name string, field func(*T) *float64) column[T] {
+	return column[T]{name, func(value *T, cell string) error {
+		n, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		*field(value) = n
+		return nil
+	}}
+}
+
+func stringColumn[T any](name string, field func(*T) *string) column[T] {
+	return column[T]{name, func(value *T, cell string) error {
+		*field(value) = cell
+		return nil
+	}}
+}
+
+func decode[T any](r io.Reader, opts Options, columns []column[T]) ([]*row[T], error) {
+	var (
+		rows []*row[T]
+		err  error
+	)
+
+	switch opts.Format {
+	case FormatCSV:
+		rows, err = decodeCSV(r, opts.MaxRows, columns)
+	case FormatNDJSON:
+		rows, err = decodeNDJSON[T](r, opts.MaxRows)
+	default:
+		return nil, fmt.Errorf("%w: format must be %s or %s", ErrInvalidFile, FormatCSV, FormatNDJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: there are no rows", ErrInvalidFile)
+	}
+
+	return rows, nil
+}
+
+func tooManyRows(maxRows, rows int) error {
+	if maxRows > 0 && rows > maxRows {
+		return fmt.Errorf("%w: more than %d rows, split the file", ErrInvalidFile, maxRows)
+	}
+	return nil
+}
+
+// decodeCSV reads a file with a header line naming the columns, in any order.
+func decodeCSV[T any](r io.Reader, maxRows int, columns []column[T]) ([]*row[T], error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading the header: %v", ErrInvalidFile, err)
+	}
+
+	byName := map[string]column[T]{}
+	for _, column := range columns {
+		byName[column.name] = column
+	}
+
+	fields := make([]column[T], len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+
+		column, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidFile, name)
+		}
+		fields[i] = column
+	}
+
+	var rows []*row[T]
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := &row[T]{value: new(T)}
+		rows = append(rows, row)
+
+		if err := tooManyRows(maxRows, len(rows)); err != nil {
+			return nil, err
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.line = parseErr.StartLine
+			row.fail("", "%v", parseErr.Err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		row.line, _ = reader.FieldPos(0)
+
+		if len(record) != len(fields) {
+			row.fail("", "has %d fields, the header has %d", len(record), len(fields))
+			continue
+		}
+
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			if err := fields[i].set(row.value, cell); err != nil {
+				row.fail(fields[i].name, "%v", err)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// decodeNDJSON reads one JSON object per line, named like the JSON of the create endpoints.
+func decodeNDJSON[T any](r io.Reader, maxRows int) ([]*row[T], error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []*row[T]
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := &row[T]{line: line, value: new(T)}
+		rows = append(rows, row)
+
+		if err := tooManyRows(maxRows, len(rows)); err != nil {
+			return nil, err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+
+		err := decoder.Decode(row.value)
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr):
+			row.fail(typeErr.Field, "must be a %s", typeErr.Type)
+		case err != nil:
+			row.fail("", "%v", err)
+		case decoder.More():
+			row.fail("", "only one object per line")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	return rows, nil
+}
+
+// lookup remembers which ids exist, so every id is fetched from the storage once.
+type lookup struct {
+	get   func(id int) error
+	found map[int]bool
+}
+
+func newLookup(get func(id int) error) *lookup {
+	return &lookup{
+		get:   get,
+		found: map[int]bool{},
+	}
+}
+
+func (l *lookup) exists(id int) (bool, error) {
+	if found, ok := l.found[id]; ok {
+		return found, nil
+	}
+
+	err := l.get(id)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	l.found[id] = err == nil
+	return err == nil, nil
+}
+
+// run reports every row error, or imports all rows when there are none and it is no dry run.
+func run[T any](ctx context.Context, rows []*row[T], opts Options, save func(context.Context, []*T) (int64, error)) (*models.ImportReport, error) {
+	report := &models.ImportReport{
+		Total:  len(rows),
+		DryRun: opts.DryRun,
+		Errors: []*models.ImportRowError{},
+	}
+
+	values := make([]*T, 0, len(rows))
+	for _, row := range rows {
+		report.Errors = append(report.Errors, row.errors...)
+		values = append(values, row.value)
+	}
+
+	if len(report.Errors) > 0 || opts.DryRun {
+		return report, nil
+	}
+
+	imported, err := save(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Imported = imported
+	return report, nil
+}
+
+func required(value string) bool {
+	return strings.TrimSpace(value) != ""
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+)
+
+var productColumns = []column[models.CreateProduct]{
+	stringColumn("product_name", func(p *models.CreateProduct) *string { return &p.ProductName }),
+	intColumn("brand_id", func(p *models.CreateProduct) *int { return &p.BrandId }),
+	intColumn("category_id", func(p *models.CreateProduct) *int { return &p.CategoryId }),
+	intColumn("model_year", func(p *models.CreateProduct) *int { return &p.ModelYear }),
+	{"list_price", func(p *models.CreateProduct, cell string) error {
+		price, err := strconv.ParseFloat(cell, 32)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		p.ListPrice = float32(price)
+		return nil
+	}},
+}
+
+// Products imports products, every brand and category they name has to exist.
+func Products(ctx context.Context, store storage.StorageI, r io.Reader, opts Options) (*models.ImportReport, error) {
+	rows, err := decode(r, opts, productColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	brands := newLookup(func(id int) error {
+		_, err := store.Brand().GetById(ctx, &models.BrandPrimaryKey{BrandId: id})
+		return err
+	})
+	categories := newLookup(func(id int) error {
+		_, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+		return err
+	})
+
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			continue
+		}
+		product := row.value
+
+		if !required(product.ProductName) {
+			row.fail("product_name", "is required")
+		} else if len(product.ProductName) > 255 {
+			row.fail("product_name", "must be at most 255 characters")
+		}
+
+		if product.ModelYear < 1900 || product.ModelYear > 2100 {
+			row.fail("model_year", "must be between 1900 and 2100")
+		}
+
+		// list_price is DECIMAL (10, 2)
+		if product.ListPrice <= 0 || product.ListPrice >= 1e8 {
+			row.fail("list_price", "must be greater than 0 and less than 100000000")
+		}
+
+		found, err := brands.exists(product.BrandId)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			row.fail("brand_id", "brand %d does not exist", product.BrandId)
+		}
+
+		found, err = categories.exists(product.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			row.fail("category_id", "category %d does not exist", product.CategoryId)
+		}
+	}
+
+	return run(ctx, rows, opts, store.Product().Import)
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"io"
+)
+
+var stockColumns = []column[models.CreateStock]{
+	intColumn("store_id", func(s *models.CreateStock) *int { return &s.StoreId }),
+	intColumn("product_id", func(s *models.CreateStock) *int { return &s.ProductId }),
+	intColumn("quantity", func(s *models.CreateStock) *int { return &s.Quantity }),
+}
+
+// Stocks sets the quantity of store and product pairs, adding the ones the store does not
+// stock yet. Every pair may only appear once in the file.
+func Stocks(ctx context.Context, store storage.StorageI, r io.Reader, opts Options) (*models.ImportReport, error) {
+	rows, err := decode(r, opts, stockColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := newLookup(func(id int) error {
+		_, err := store.Store().GetById(ctx, &models.StorePrimaryKey{StoreId: id})
+		return err
+	})
+	products := newLookup(func(id int) error {
+		_, err := store.Product().GetById(ctx, &models.ProductPrimaryKey{ProductId: id})
+		return err
+	})
+
+	type pair struct{ storeId, productId int }
+	lines := map[pair]int{}
+
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			continue
+		}
+		stock := row.value
+
+		if opts.StoreId > 0 && stock.StoreId != opts.StoreId {
+			row.fail("store_id", "access to store %d is not allowed", stock.StoreId)
+			continue
+		}
+
+		if stock.Quantity < 0 {
+			row.fail("quantity", "must not be negative")
+		}
+
+		found, err := stores.exists(stock.StoreId)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			row.fail("store_id", "store %d does not exist", stock.StoreId)
+		}
+
+		found, err = products.exists(stock.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			row.fail("product_id", "product %d does not exist", stock.ProductId)
+		}
+
+		key := pair{stock.StoreId, stock.ProductId}
+		if line, ok := lines[key]; ok {
+			row.fail("", "store %d and product %d are already on line %d", stock.StoreId, stock.ProductId, line)
+		} else {
+			lines[key] = row.line
+		}
+	}
+
+	return run(ctx, rows, opts, store.Stock().Import)
+}
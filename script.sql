@@ -1,5 +0,0 @@
-CREATE TABLE users (
-	user_id VARCHAR PRIMARY KEY,
-	username VARCHAR NOT NULL,
-    password VARCHAR NOT NULL
-);
\ No newline at end of file
@@ -1,15 +1,59 @@
 package storage
 
-import "app/api/models"
+import (
+	"app/api/models"
+	"strconv"
+	"time"
+)
 
 type StorageCacheI interface {
 	CloseDB()
 	Product() ProductCacheRepoI
+	Token() TokenCacheRepoI
+	Entity() EntityCacheRepoI
+}
+
+// Product list pages are tagged with what they depend on so a mutation only drops
+// the pages it can have changed. Every page carries ProductCacheTagAll.
+const ProductCacheTagAll = "all"
+
+func ProductCacheBrandTag(brandId int) string {
+	return "brand:" + strconv.Itoa(brandId)
+}
+
+func ProductCacheCategoryTag(categoryId int) string {
+	return "category:" + strconv.Itoa(categoryId)
+}
+
+// ProductCacheStoreTag is carried by pages filtered on stock at the store, every change
+// to that store's stock invalidates it.
+func ProductCacheStoreTag(storeId int) string {
+	return "store:" + strconv.Itoa(storeId)
 }
 
 type ProductCacheRepoI interface {
-	Create(string, *models.GetListProductResponse) error
-	GetAll(string) (*models.GetListProductResponse, error)
-	Exists(string) (bool, error)
-	Delete() error
+	// Get returns false on a miss.
+	Get(string) (*models.GetListProductResponse, bool, error)
+	Set(string, *models.GetListProductResponse, []string) error
+	Invalidate(...string) error
+	Stats() models.CacheStats
+}
+
+// EntityCacheRepoI is the key value store behind the read-through decorator in
+// storage/cached. Keys embed an entity version, so bumping the version drops every
+// cached read of that entity at once without scanning for keys.
+type EntityCacheRepoI interface {
+	// Get decodes the value into dst and returns false on a miss.
+	Get(key string, dst interface{}) (bool, error)
+	Set(key string, value interface{}, ttl time.Duration) error
+	Version(entity string) (int64, error)
+	Bump(entity string) error
+}
+
+// TokenCacheRepoI holds the ids of revoked access tokens until they expire. IsRevoked
+// returns an error when it can not tell, the auth middleware then rejects the request
+// rather than accept a token that may have been revoked.
+type TokenCacheRepoI interface {
+	Revoke(string, time.Duration) error
+	IsRevoked(string) (bool, error)
 }
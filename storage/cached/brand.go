@@ -0,0 +1,60 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+type brandRepo struct {
+	repo   storage.BrandRepoI
+	loader *loader
+}
+
+func (r *brandRepo) Create(ctx context.Context, req *models.CreateBrand) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return id, nil
+}
+
+func (r *brandRepo) GetById(ctx context.Context, req *models.BrandPrimaryKey) (*models.Brand, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.BrandId), func() (*models.Brand, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *brandRepo) GetList(ctx context.Context, req *models.GetListBrandRequest) (*models.GetListBrandResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListBrandResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *brandRepo) Update(ctx context.Context, req *models.UpdateBrand) (int64, error) {
+	rowsAffected, err := r.repo.Update(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
+
+func (r *brandRepo) Delete(ctx context.Context, req *models.BrandPrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
@@ -0,0 +1,183 @@
+// Package cached wraps a storage.StorageI with a read-through cache for the reference
+// entities: categories, brands, stores, customers, staff and promocodes. GetById and
+// GetList are served from the cache, writes through the wrapped repositories bump the
+// entity version so every cached read of it is dropped. Writes that go around this
+// package (other services, psql) are only picked up when the TTL runs out.
+//
+// The entity cache is taken from the storage.StorageCacheI on every read and write, so
+// a switch between Redis and the in-memory fallback applies to it straight away.
+package cached
+
+import (
+	"app/config"
+	"app/pkg/logger"
+	"app/storage"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	entityCategory  = "category"
+	entityBrand     = "brand"
+	entityStore     = "store"
+	entityCustomer  = "customer"
+	entityStaff     = "staff"
+	entityPromocode = "promocode"
+)
+
+var entities = []string{entityCategory, entityBrand, entityStore, entityCustomer, entityStaff, entityPromocode}
+
+// BumpAll drops every cached entity read. It is for writes that went around this package,
+// like the SQL scripts of the admin CLI.
+func BumpAll(cache storage.StorageCacheI) error {
+	entityCache := cache.Entity()
+	for _, entity := range entities {
+		err := entityCache.Bump(entity)
+		if err != nil {
+			return fmt.Errorf("bump %s: %w", entity, err)
+		}
+	}
+
+	return nil
+}
+
+type Store struct {
+	storage.StorageI
+
+	category  *categoryRepo
+	brand     *brandRepo
+	store     *storeRepo
+	customer  *customerRepo
+	staff     *staffRepo
+	promocode *promocodeRepo
+}
+
+func NewStorage(store storage.StorageI, cache storage.StorageCacheI, cfg *config.Config, log logger.LoggerI) storage.StorageI {
+	flights := &group{}
+	newLoader := func(entity string, ttl time.Duration) *loader {
+		return &loader{cache: cache, group: flights, logger: log, entity: entity, ttl: ttl}
+	}
+
+	return &Store{
+		StorageI:  store,
+		category:  &categoryRepo{repo: store.Category(), loader: newLoader(entityCategory, cfg.CategoryCacheTTL)},
+		brand:     &brandRepo{repo: store.Brand(), loader: newLoader(entityBrand, cfg.BrandCacheTTL)},
+		store:     &storeRepo{repo: store.Store(), loader: newLoader(entityStore, cfg.StoreCacheTTL)},
+		customer:  &customerRepo{repo: store.Customer(), loader: newLoader(entityCustomer, cfg.CustomerCacheTTL)},
+		staff:     &staffRepo{repo: store.Staff(), loader: newLoader(entityStaff, cfg.StaffCacheTTL)},
+		promocode: &promocodeRepo{repo: store.Promocode(), loader: newLoader(entityPromocode, cfg.PromocodeCacheTTL)},
+	}
+}
+
+func (s *Store) Category() storage.CategoryRepoI {
+	return s.category
+}
+
+func (s *Store) Brand() storage.BrandRepoI {
+	return s.brand
+}
+
+func (s *Store) Store() storage.StoreRepoI {
+	return s.store
+}
+
+func (s *Store) Customer() storage.CustomerRepoI {
+	return s.customer
+}
+
+func (s *Store) Staff() storage.StaffRepoI {
+	return s.staff
+}
+
+func (s *Store) Promocode() storage.PromocodeRepoI {
+	return s.promocode
+}
+
+type loader struct {
+	cache  storage.StorageCacheI
+	group  *group
+	logger logger.LoggerI
+	entity string
+	ttl    time.Duration
+}
+
+// invalidate runs after the write is committed, a failure is logged and the stale
+// entries age out with the TTL.
+func (l *loader) invalidate(entities ...string) {
+	if l.ttl <= 0 {
+		return
+	}
+
+	cache := l.cache.Entity()
+	for _, entity := range append([]string{l.entity}, entities...) {
+		err := cache.Bump(entity)
+		if err != nil {
+			l.logger.Error("cache.entity.bump "+entity, logger.Error(err))
+		}
+	}
+}
+
+// listKey hashes the request so keys have a fixed length whatever the search text is.
+func listKey(req interface{}) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	return "list:" + hex.EncodeToString(sum[:]), nil
+}
+
+// readThrough returns the cached value of key or loads and caches it. Errors from
+// load, pgx.ErrNoRows included, are returned as they are and never cached. Cache
+// errors are logged and the value is loaded from the wrapped repository instead.
+//
+// A read that races a write may store what it loaded under the old version, no
+// reader asks for that version again once the write has bumped it.
+func readThrough[T any](l *loader, key string, load func() (T, error)) (T, error) {
+	var value T
+
+	if l.ttl <= 0 {
+		return load()
+	}
+
+	// one backend for the whole read, a failover in between must not mix them
+	cache := l.cache.Entity()
+
+	version, err := cache.Version(l.entity)
+	if err != nil {
+		l.logger.Error("cache.entity.version "+l.entity, logger.Error(err))
+		return load()
+	}
+	key = fmt.Sprintf("%s:v%d:%s", l.entity, version, key)
+
+	ok, err := cache.Get(key, &value)
+	if err != nil {
+		l.logger.Error("cache.entity.get "+key, logger.Error(err))
+	} else if ok {
+		return value, nil
+	}
+
+	// callers that join a flight share the loaded value and must not modify it
+	shared, err := l.group.Do(key, func() (interface{}, error) {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		err = cache.Set(key, loaded, l.ttl)
+		if err != nil {
+			l.logger.Error("cache.entity.set "+key, logger.Error(err))
+		}
+
+		return loaded, nil
+	})
+	if err != nil {
+		return value, err
+	}
+
+	return shared.(T), nil
+}
@@ -0,0 +1,60 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+type categoryRepo struct {
+	repo   storage.CategoryRepoI
+	loader *loader
+}
+
+func (r *categoryRepo) Create(ctx context.Context, req *models.CreateCategory) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return id, nil
+}
+
+func (r *categoryRepo) GetById(ctx context.Context, req *models.CategoryPrimaryKey) (*models.Category, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.CategoryId), func() (*models.Category, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryRequest) (*models.GetListCategoryResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListCategoryResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *categoryRepo) Update(ctx context.Context, req *models.UpdateCategory) (int64, error) {
+	rowsAffected, err := r.repo.Update(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
+
+func (r *categoryRepo) Delete(ctx context.Context, req *models.CategoryPrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
@@ -0,0 +1,70 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+type customerRepo struct {
+	repo   storage.CustomerRepoI
+	loader *loader
+}
+
+func (r *customerRepo) Create(ctx context.Context, req *models.CreateCustomer) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return id, nil
+}
+
+func (r *customerRepo) GetById(ctx context.Context, req *models.CustomerPrimaryKey) (*models.Customer, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.CustomerId), func() (*models.Customer, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListCustomerResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *customerRepo) Update(ctx context.Context, req *models.UpdateCustomer) (int64, error) {
+	rowsAffected, err := r.repo.Update(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
+
+func (r *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
+
+func (r *customerRepo) Import(ctx context.Context, req []*models.CreateCustomer) (int64, error) {
+	rowsAffected, err := r.repo.Import(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
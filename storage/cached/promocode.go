@@ -0,0 +1,50 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+type promocodeRepo struct {
+	repo   storage.PromocodeRepoI
+	loader *loader
+}
+
+func (r *promocodeRepo) Create(ctx context.Context, req *models.CreatePromocode) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return id, nil
+}
+
+func (r *promocodeRepo) GetById(ctx context.Context, req *models.PromocodePrimaryKey) (*models.Promocode, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.PromocodeId), func() (*models.Promocode, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *promocodeRepo) GetList(ctx context.Context, req *models.GetListPromocodeRequest) (*models.GetListPromocodeResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListPromocodeResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *promocodeRepo) Delete(ctx context.Context, req *models.PromocodePrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
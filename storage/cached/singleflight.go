@@ -0,0 +1,43 @@
+package cached
+
+import "sync"
+
+type call struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// group collapses concurrent loads of the same key into one, so a popular key
+// expiring sends a single query to the database instead of one per waiting request.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func (g *group) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value, c.err = fn()
+	return c.value, c.err
+}
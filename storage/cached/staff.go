@@ -0,0 +1,60 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+type staffRepo struct {
+	repo   storage.StaffRepoI
+	loader *loader
+}
+
+func (r *staffRepo) Create(ctx context.Context, req *models.CreateStaff) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return id, nil
+}
+
+func (r *staffRepo) GetById(ctx context.Context, req *models.StaffPrimaryKey) (*models.Staff, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.StaffId), func() (*models.Staff, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest) (*models.GetListStaffResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListStaffResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *staffRepo) Update(ctx context.Context, req *models.UpdateStaff) (int64, error) {
+	rowsAffected, err := r.repo.Update(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
+
+func (r *staffRepo) Delete(ctx context.Context, req *models.StaffPrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate()
+	return rowsAffected, nil
+}
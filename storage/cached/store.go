@@ -0,0 +1,61 @@
+package cached
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"strconv"
+)
+
+// staff rows embed their store, so store writes drop cached staff as well
+type storeRepo struct {
+	repo   storage.StoreRepoI
+	loader *loader
+}
+
+func (r *storeRepo) Create(ctx context.Context, req *models.CreateStore) (int, error) {
+	id, err := r.repo.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate(entityStaff)
+	return id, nil
+}
+
+func (r *storeRepo) GetById(ctx context.Context, req *models.StorePrimaryKey) (*models.Store, error) {
+	return readThrough(r.loader, "id:"+strconv.Itoa(req.StoreId), func() (*models.Store, error) {
+		return r.repo.GetById(ctx, req)
+	})
+}
+
+func (r *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest) (*models.GetListStoreResponse, error) {
+	key, err := listKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return readThrough(r.loader, key, func() (*models.GetListStoreResponse, error) {
+		return r.repo.GetList(ctx, req)
+	})
+}
+
+func (r *storeRepo) Update(ctx context.Context, req *models.UpdateStore) (int64, error) {
+	rowsAffected, err := r.repo.Update(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate(entityStaff)
+	return rowsAffected, nil
+}
+
+func (r *storeRepo) Delete(ctx context.Context, req *models.StorePrimaryKey) (int64, error) {
+	rowsAffected, err := r.repo.Delete(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	r.loader.invalidate(entityStaff)
+	return rowsAffected, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "errors"
+
+var (
+	ErrInvalidQuantity = errors.New("quantity must be greater than zero")
+	ErrNotEnoughStock  = errors.New("there is not enough of this product in stock")
+)
+
+// ErrInvalidStatusTransition is returned when an order is asked to move to a status that is
+// not reachable from its current one. Repositories wrap it with the statuses involved.
+var ErrInvalidStatusTransition = errors.New("invalid order status transition")
+
+// ErrOrderClosed is returned when items are added to or removed from an order that no longer holds
+// stock, or when an order that still holds stock is moved to another store.
+var ErrOrderClosed = errors.New("order can no longer be changed")
+
+// ErrInvalidSort is returned when a list is asked to sort by a field it does not support.
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// ErrInvalidCursor is returned for an after cursor that was not handed out by a list.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrCacheUnavailable is returned by a cache that can not answer a lookup that must not be
+// guessed, like whether an access token was revoked.
+var ErrCacheUnavailable = errors.New("cache unavailable")
+
+// ErrOverReceived is returned when a goods receipt would take an item of a purchase order past
+// the quantity that was ordered.
+var ErrOverReceived = errors.New("more received than was ordered")
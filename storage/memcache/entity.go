@@ -0,0 +1,62 @@
+package memcache
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type entityCacheRepo struct {
+	values *entries
+
+	mu       sync.Mutex
+	versions map[string]int64
+}
+
+func NewEntityCacheRepo() *entityCacheRepo {
+	return &entityCacheRepo{
+		values:   newEntries(),
+		versions: map[string]int64{},
+	}
+}
+
+func (c *entityCacheRepo) Get(key string, dst interface{}) (bool, error) {
+
+	body, ok := c.values.get(key)
+	if !ok {
+		return false, nil
+	}
+
+	err := json.Unmarshal(body, dst)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *entityCacheRepo) Set(key string, value interface{}, ttl time.Duration) error {
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.values.set(key, body, ttl)
+	return nil
+}
+
+func (c *entityCacheRepo) Version(entity string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.versions[entity], nil
+}
+
+func (c *entityCacheRepo) Bump(entity string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[entity]++
+	return nil
+}
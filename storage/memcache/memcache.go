@@ -0,0 +1,110 @@
+// Package memcache is an in-process storage.StorageCacheI. It backs the service when
+// Redis is disabled or unreachable, entries are per instance and lost on restart.
+package memcache
+
+import (
+	"app/config"
+	"app/storage"
+	"sync"
+	"time"
+)
+
+type Cache struct {
+	productCacheRepo *productCacheRepo
+	tokenCacheRepo   *tokenCacheRepo
+	entityCacheRepo  *entityCacheRepo
+}
+
+func NewCache(cfg *config.Config) storage.StorageCacheI {
+	return &Cache{
+		productCacheRepo: NewProductCacheRepo(cfg.ProductCacheTTL),
+		tokenCacheRepo:   NewTokenCacheRepo(),
+		entityCacheRepo:  NewEntityCacheRepo(),
+	}
+}
+
+func (c *Cache) CloseDB() {}
+
+func (c *Cache) Product() storage.ProductCacheRepoI {
+	return c.productCacheRepo
+}
+
+func (c *Cache) Token() storage.TokenCacheRepoI {
+	return c.tokenCacheRepo
+}
+
+func (c *Cache) Entity() storage.EntityCacheRepoI {
+	return c.entityCacheRepo
+}
+
+type entry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// entries is a map with per key expiry. Expired keys are dropped when read and by a
+// sweep of the whole map at most once a minute, so keys nobody reads again go too.
+type entries struct {
+	mu        sync.Mutex
+	values    map[string]entry
+	lastSweep time.Time
+}
+
+func newEntries() *entries {
+	return &entries{values: map[string]entry{}, lastSweep: time.Now()}
+}
+
+func (e *entries) get(key string) ([]byte, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	value, ok := e.values[key]
+	if !ok {
+		return nil, false
+	}
+	if value.expired(time.Now()) {
+		delete(e.values, key)
+		return nil, false
+	}
+
+	return value.body, true
+}
+
+func (e *entries) has(key string) bool {
+	_, ok := e.get(key)
+	return ok
+}
+
+func (e *entries) set(key string, body []byte, ttl time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	value := entry{body: body}
+	if ttl > 0 {
+		value.expiresAt = now.Add(ttl)
+	}
+	e.values[key] = value
+
+	if now.Sub(e.lastSweep) > time.Minute {
+		for k, v := range e.values {
+			if v.expired(now) {
+				delete(e.values, k)
+			}
+		}
+		e.lastSweep = now
+	}
+}
+
+func (e *entries) delete(keys ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, key := range keys {
+		delete(e.values, key)
+	}
+}
@@ -0,0 +1,134 @@
+package memcache
+
+import (
+	"app/api/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type productCacheRepo struct {
+	pages *entries
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	tags      map[string]map[string]bool
+	lastPrune time.Time
+
+	hits          int64
+	misses        int64
+	invalidations int64
+}
+
+func NewProductCacheRepo(ttl time.Duration) *productCacheRepo {
+	return &productCacheRepo{
+		pages:     newEntries(),
+		ttl:       ttl,
+		tags:      map[string]map[string]bool{},
+		lastPrune: time.Now(),
+	}
+}
+
+// pageKey hashes the request, so the tag index holds keys of a fixed length whatever
+// the search text is.
+func pageKey(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *productCacheRepo) Get(name string) (*models.GetListProductResponse, bool, error) {
+
+	body, ok := c.pages.get(pageKey(name))
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false, nil
+	}
+
+	resp := models.GetListProductResponse{}
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return &resp, true, nil
+}
+
+func (c *productCacheRepo) Set(name string, req *models.GetListProductResponse, tags []string) error {
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := pageKey(name)
+	c.pages.set(key, body, c.ttl)
+	for _, tag := range tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]bool{}
+		}
+		c.tags[tag][key] = true
+	}
+
+	c.pruneTags()
+	return nil
+}
+
+// pruneTags drops the pages that expired from the tag index, at most once a minute.
+// Without it every page ever cached stays in the index until its tag is invalidated.
+// The caller must hold c.mu.
+func (c *productCacheRepo) pruneTags() {
+	now := time.Now()
+	if now.Sub(c.lastPrune) <= time.Minute {
+		return
+	}
+	c.lastPrune = now
+
+	for tag, keys := range c.tags {
+		for key := range keys {
+			if !c.pages.has(key) {
+				delete(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+func (c *productCacheRepo) Invalidate(tags ...string) error {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for name := range c.tags[tag] {
+			c.pages.delete(name)
+		}
+		delete(c.tags, tag)
+	}
+
+	atomic.AddInt64(&c.invalidations, 1)
+	return nil
+}
+
+func (c *productCacheRepo) Stats() models.CacheStats {
+
+	stats := models.CacheStats{
+		Hits:          atomic.LoadInt64(&c.hits),
+		Misses:        atomic.LoadInt64(&c.misses),
+		Invalidations: atomic.LoadInt64(&c.invalidations),
+	}
+
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return stats
+}
@@ -0,0 +1,27 @@
+package memcache
+
+import "time"
+
+type tokenCacheRepo struct {
+	revoked *entries
+}
+
+func NewTokenCacheRepo() *tokenCacheRepo {
+	return &tokenCacheRepo{
+		revoked: newEntries(),
+	}
+}
+
+func (c *tokenCacheRepo) Revoke(tokenId string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	c.revoked.set(tokenId, nil, ttl)
+	return nil
+}
+
+func (c *tokenCacheRepo) IsRevoked(tokenId string) (bool, error) {
+	_, ok := c.revoked.get(tokenId)
+	return ok, nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type brandRepo struct {
+	t *tables
+}
+
+func NewBrandRepo(t *tables) *brandRepo {
+	return &brandRepo{
+		t: t,
+	}
+}
+
+func (b *brandRepo) Create(ctx context.Context, req *models.CreateBrand) (int, error) {
+	b.t.mu.Lock()
+	defer b.t.mu.Unlock()
+
+	id := b.t.next("brands")
+	b.t.brands[id] = &models.Brand{BrandId: id, BrandName: req.BrandName}
+
+	return id, nil
+}
+
+func (b *brandRepo) GetById(ctx context.Context, req *models.BrandPrimaryKey) (*models.Brand, error) {
+	b.t.mu.Lock()
+	defer b.t.mu.Unlock()
+
+	brand, ok := b.t.brands[req.BrandId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *brand
+	return &resp, nil
+}
+
+func (b *brandRepo) GetList(ctx context.Context, req *models.GetListBrandRequest) (*models.GetListBrandResponse, error) {
+	b.t.mu.Lock()
+	defer b.t.mu.Unlock()
+
+	resp := models.GetListBrandResponse{}
+
+	var rows []*models.Brand
+	for _, id := range sortedIds(b.t.brands) {
+		brand := *b.t.brands[id]
+		if contains(req.Search, brand.BrandName) {
+			rows = append(rows, &brand)
+		}
+	}
+
+	resp.Brands = append(resp.Brands, paginate(rows, req.Offset, req.Limit)...)
+	resp.Count = len(rows)
+
+	return &resp, nil
+}
+
+func (b *brandRepo) Update(ctx context.Context, req *models.UpdateBrand) (int64, error) {
+	b.t.mu.Lock()
+	defer b.t.mu.Unlock()
+
+	brand, ok := b.t.brands[req.BrandId]
+	if !ok {
+		return 0, nil
+	}
+
+	brand.BrandName = req.BrandName
+	return 1, nil
+}
+
+// Delete cascades to the products of the brand.
+func (b *brandRepo) Delete(ctx context.Context, req *models.BrandPrimaryKey) (int64, error) {
+	b.t.mu.Lock()
+	defer b.t.mu.Unlock()
+
+	if _, ok := b.t.brands[req.BrandId]; !ok {
+		return 0, nil
+	}
+
+	for _, id := range sortedIds(b.t.products) {
+		if b.t.products[id].BrandId == req.BrandId {
+			b.t.deleteProduct(id)
+		}
+	}
+	delete(b.t.brands, req.BrandId)
+
+	return 1, nil
+}
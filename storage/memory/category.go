@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type categoryRepo struct {
+	t *tables
+}
+
+func NewCategoryRepo(t *tables) *categoryRepo {
+	return &categoryRepo{
+		t: t,
+	}
+}
+
+func (c *categoryRepo) Create(ctx context.Context, req *models.CreateCategory) (int, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	id := c.t.next("categories")
+	c.t.categories[id] = &models.Category{CategoryId: id, CategoryName: req.CategoryName}
+
+	return id, nil
+}
+
+func (c *categoryRepo) GetById(ctx context.Context, req *models.CategoryPrimaryKey) (*models.Category, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	category, ok := c.t.categories[req.CategoryId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *category
+	return &resp, nil
+}
+
+func (c *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryRequest) (*models.GetListCategoryResponse, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	resp := models.GetListCategoryResponse{}
+
+	var rows []*models.Category
+	for _, id := range sortedIds(c.t.categories) {
+		category := *c.t.categories[id]
+		if contains(req.Search, category.CategoryName) {
+			rows = append(rows, &category)
+		}
+	}
+
+	resp.Categories = append(resp.Categories, paginate(rows, req.Offset, req.Limit)...)
+	resp.Count = len(rows)
+
+	return &resp, nil
+}
+
+func (c *categoryRepo) Update(ctx context.Context, req *models.UpdateCategory) (int64, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	category, ok := c.t.categories[req.CategoryId]
+	if !ok {
+		return 0, nil
+	}
+
+	category.CategoryName = req.CategoryName
+	return 1, nil
+}
+
+// Delete cascades to the products of the category.
+func (c *categoryRepo) Delete(ctx context.Context, req *models.CategoryPrimaryKey) (int64, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	if _, ok := c.t.categories[req.CategoryId]; !ok {
+		return 0, nil
+	}
+
+	for _, id := range sortedIds(c.t.products) {
+		if c.t.products[id].CategoryId == req.CategoryId {
+			c.t.deleteProduct(id)
+		}
+	}
+	delete(c.t.categories, req.CategoryId)
+
+	return 1, nil
+}
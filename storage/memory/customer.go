@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type customerRepo struct {
+	t *tables
+}
+
+func NewCustomerRepo(t *tables) *customerRepo {
+	return &customerRepo{
+		t: t,
+	}
+}
+
+func (c *customerRepo) Create(ctx context.Context, req *models.CreateCustomer) (int, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	id := c.t.next("customers")
+	c.t.customers[id] = &models.Customer{
+		CustomerId: id,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Phone:      req.Phone,
+		Email:      req.Email,
+		Street:     req.Street,
+		City:       req.City,
+		State:      req.State,
+		ZipCode:    req.ZipCode,
+	}
+
+	return id, nil
+}
+
+func (c *customerRepo) GetById(ctx context.Context, req *models.CustomerPrimaryKey) (*models.Customer, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	customer, ok := c.t.customers[req.CustomerId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *customer
+	return &resp, nil
+}
+
+func (c *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	customers := models.GetListCustomerResponse{}
+
+	var rows []*models.Customer
+	for _, id := range sortedIds(c.t.customers) {
+		customer := *c.t.customers[id]
+		if contains(req.Search, customer.FirstName+" "+customer.LastName, customer.Email) {
+			rows = append(rows, &customer)
+		}
+	}
+
+	page, next, err := keyset(rows, func(c *models.Customer) int { return c.CustomerId }, req.Offset, req.Limit, req.After)
+	if err != nil {
+		return nil, err
+	}
+
+	customers.Customers = append(customers.Customers, page...)
+	customers.NextCursor = next
+	customers.Count = len(rows)
+
+	return &customers, nil
+}
+
+func (c *customerRepo) Update(ctx context.Context, req *models.UpdateCustomer) (int64, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	customer, ok := c.t.customers[req.CustomerId]
+	if !ok {
+		return 0, nil
+	}
+
+	customer.FirstName = req.FirstName
+	customer.LastName = req.LastName
+	customer.Phone = req.Phone
+	customer.Email = req.Email
+	customer.Street = req.Street
+	customer.City = req.City
+	customer.State = req.State
+	customer.ZipCode = req.ZipCode
+
+	return 1, nil
+}
+
+// Delete cascades to the orders of the customer.
+func (c *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimaryKey) (int64, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	if _, ok := c.t.customers[req.CustomerId]; !ok {
+		return 0, nil
+	}
+
+	for _, id := range sortedIds(c.t.orders) {
+		if c.t.orders[id].customerId == req.CustomerId {
+			c.t.deleteOrder(id)
+		}
+	}
+	delete(c.t.customers, req.CustomerId)
+
+	return 1, nil
+}
+
+func (c *customerRepo) Import(ctx context.Context, req []*models.CreateCustomer) (int64, error) {
+	c.t.mu.Lock()
+	defer c.t.mu.Unlock()
+
+	for _, customer := range req {
+		id := c.t.next("customers")
+		c.t.customers[id] = &models.Customer{
+			CustomerId: id,
+			FirstName:  customer.FirstName,
+			LastName:   customer.LastName,
+			Phone:      customer.Phone,
+			Email:      customer.Email,
+			Street:     customer.Street,
+			City:       customer.City,
+			State:      customer.State,
+			ZipCode:    customer.ZipCode,
+		}
+	}
+
+	return int64(len(req)), nil
+}
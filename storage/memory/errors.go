@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgconn"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgInvalidDatetime     = "22007"
+	pgCardinality         = "21000"
+)
+
+func uniqueViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           pgUniqueViolation,
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+// foreignKeyViolation is returned both for a missing referenced row and for a delete
+// that a NO ACTION reference blocks.
+func foreignKeyViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           pgForeignKeyViolation,
+		Message:        fmt.Sprintf("insert, update or delete on table %q violates foreign key constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func checkViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           pgCheckViolation,
+		Message:        fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func invalidDate(value string) error {
+	return &pgconn.PgError{
+		Severity: "ERROR",
+		Code:     pgInvalidDatetime,
+		Message:  fmt.Sprintf("invalid input syntax for type date: %q", value),
+	}
+}
+
+func cardinalityViolation() error {
+	return &pgconn.PgError{
+		Severity: "ERROR",
+		Code:     pgCardinality,
+		Message:  "ON CONFLICT DO UPDATE command cannot affect row a second time",
+	}
+}
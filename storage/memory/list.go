@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"app/pkg/helper"
+	"app/storage"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultListLimit = 10
+
+// dateLayout and timestampLayout are how Postgres prints a DATE or TIMESTAMP cast to VARCHAR.
+const (
+	dateLayout      = "2006-01-02"
+	timestampLayout = "2006-01-02 15:04:05"
+)
+
+// parseDate accepts the date inputs the API sends to Postgres DATE columns.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{dateLayout, timestampLayout, time.RFC3339} {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date.Truncate(24 * time.Hour), nil
+		}
+	}
+	return time.Time{}, invalidDate(value)
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// contains is ILIKE '%value%': a case insensitive substring match of any of the fields.
+func contains(value string, fields ...string) bool {
+	if len(value) == 0 {
+		return true
+	}
+
+	value = strings.ToLower(value)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// paginate is OFFSET and LIMIT, a limit below 1 falls back to the default page size.
+func paginate[T any](rows []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	if offset >= len(rows) {
+		return []T{}
+	}
+	rows = rows[offset:]
+
+	if limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// keysetCursor has the same encoding as the postgresql package, cursors work on both.
+type keysetCursor struct {
+	Id int `json:"id"`
+}
+
+// keyset pages rows ordered by id: the page starts after the row the cursor points at
+// and the cursor of the next page is returned when there is one.
+func keyset[T any](rows []T, id func(T) int, offset, limit int, after string) ([]T, string, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	if len(after) > 0 {
+		var cursor keysetCursor
+		err := helper.DecodeCursor(after, &cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", storage.ErrInvalidCursor, err)
+		}
+
+		start := sort.Search(len(rows), func(i int) bool { return id(rows[i]) > cursor.Id })
+		rows, offset = rows[start:], 0
+	}
+
+	page := paginate(rows, offset, limit+1)
+	if len(page) <= limit {
+		return page, "", nil
+	}
+
+	page = page[:limit]
+	next, _ := helper.EncodeCursor(keysetCursor{Id: id(page[limit-1])})
+	return page, next, nil
+}
+
+// compareFunc orders two rows by one sort field, negative when a comes first.
+type compareFunc[T any] func(a, b T) int
+
+// sortRows orders rows by a client supplied list like "-list_price,product_name", with the
+// same keys and errors as ListQuery.Sort. Rows are expected in id order already, the sort is
+// stable so ties keep it.
+func sortRows[T any](rows []T, fields string, columns map[string]compareFunc[T]) error {
+	if len(strings.TrimSpace(fields)) == 0 {
+		return nil
+	}
+
+	var compares []compareFunc[T]
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		compare, ok := columns[field]
+		if !ok {
+			return fmt.Errorf("%w: %q", storage.ErrInvalidSort, field)
+		}
+
+		if desc {
+			asc := compare
+			compare = func(a, b T) int { return asc(b, a) }
+		}
+		compares = append(compares, compare)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, compare := range compares {
+			if c := compare(rows[i], rows[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sortedIds returns the keys of a table in ascending order.
+func sortedIds[V any](table map[int]V) []int {
+	ids := make([]int, 0, len(table))
+	for id := range table {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
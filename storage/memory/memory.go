@@ -0,0 +1,188 @@
+// Package memory is a storage.StorageI kept in process memory, for tests and local
+// development without Postgres. It follows the postgresql package: missing rows are
+// pgx.ErrNoRows, broken constraints are *pgconn.PgError with the Postgres error code,
+// deletes cascade the way the schema does, and every method runs under one lock so
+// each call is atomic like a transaction.
+package memory
+
+import (
+	"app/api/models"
+	"app/storage"
+	"sync"
+	"time"
+)
+
+type stockKey struct {
+	storeId   int
+	productId int
+}
+
+type orderRow struct {
+	orderId      int
+	customerId   int // 0 is NULL
+	orderStatus  int16
+	orderDate    time.Time
+	requiredDate time.Time
+	shippedDate  time.Time // zero is NULL
+	storeId      int
+	staffId      int
+}
+
+type refreshTokenRow struct {
+	tokenHash string
+	userId    string
+	expiresAt time.Time
+	revokedAt time.Time // zero is NULL
+	createdAt time.Time
+}
+
+// tables holds every row. Repositories share one tables value and hold mu for the whole
+// method, rows handed out are copies so callers cannot change the tables behind the lock.
+type tables struct {
+	mu sync.Mutex
+
+	categories    map[int]*models.Category
+	brands        map[int]*models.Brand
+	products      map[int]*models.Product
+	stocks        map[stockKey]int
+	stores        map[int]*models.Store
+	customers     map[int]*models.Customer
+	staffs        map[int]*models.Staff
+	orders        map[int]*orderRow
+	orderItems    map[int][]*models.OrderItem
+	history       []*models.OrderStatusHistory
+	movements     []*models.StockMovement
+	promocodes    map[int]*models.Promocode
+	users         map[string]*models.User
+	refreshTokens map[string]*refreshTokenRow
+
+	suppliers          map[int]*models.Supplier
+	purchaseOrders     map[int]*purchaseOrderRow
+	purchaseOrderItems map[int][]*models.PurchaseOrderItem
+	purchaseHistory    []*models.PurchaseOrderStatusHistory
+
+	sequences map[string]int
+}
+
+// next returns the next identity value of a table, like GENERATED BY DEFAULT AS IDENTITY.
+func (t *tables) next(table string) int {
+	t.sequences[table]++
+	return t.sequences[table]
+}
+
+type Store struct {
+	tables    *tables
+	category  storage.CategoryRepoI
+	brand     storage.BrandRepoI
+	product   storage.ProductRepoI
+	stock     storage.StockRepoI
+	store     storage.StoreRepoI
+	customer  storage.CustomerRepoI
+	staff     storage.StaffRepoI
+	order     storage.OrderRepoI
+	promocode storage.PromocodeRepoI
+	report    storage.ReportRepoI
+	user      storage.UserRepoI
+	refresh   storage.RefreshTokenRepoI
+	supplier  storage.SupplierRepoI
+	purchase  storage.PurchaseOrderRepoI
+}
+
+func NewStorage() storage.StorageI {
+	t := &tables{
+		categories:    map[int]*models.Category{},
+		brands:        map[int]*models.Brand{},
+		products:      map[int]*models.Product{},
+		stocks:        map[stockKey]int{},
+		stores:        map[int]*models.Store{},
+		customers:     map[int]*models.Customer{},
+		staffs:        map[int]*models.Staff{},
+		orders:        map[int]*orderRow{},
+		orderItems:    map[int][]*models.OrderItem{},
+		promocodes:    map[int]*models.Promocode{},
+		users:         map[string]*models.User{},
+		refreshTokens: map[string]*refreshTokenRow{},
+
+		suppliers:          map[int]*models.Supplier{},
+		purchaseOrders:     map[int]*purchaseOrderRow{},
+		purchaseOrderItems: map[int][]*models.PurchaseOrderItem{},
+
+		sequences: map[string]int{},
+	}
+
+	return &Store{
+		tables:    t,
+		category:  NewCategoryRepo(t),
+		brand:     NewBrandRepo(t),
+		product:   NewProductRepo(t),
+		stock:     NewStockRepo(t),
+		store:     NewStoreRepo(t),
+		customer:  NewCustomerRepo(t),
+		staff:     NewStaffRepo(t),
+		order:     NewOrderRepo(t),
+		promocode: NewPromocodeRepo(t),
+		report:    NewReportRepo(t),
+		user:      NewUserRepo(t),
+		refresh:   NewRefreshTokenRepo(t),
+		supplier:  NewSupplierRepo(t),
+		purchase:  NewPurchaseOrderRepo(t),
+	}
+}
+
+func (s *Store) CloseDB() {}
+
+func (s *Store) Category() storage.CategoryRepoI {
+	return s.category
+}
+
+func (s *Store) Brand() storage.BrandRepoI {
+	return s.brand
+}
+
+func (s *Store) Product() storage.ProductRepoI {
+	return s.product
+}
+
+func (s *Store) Stock() storage.StockRepoI {
+	return s.stock
+}
+
+func (s *Store) Store() storage.StoreRepoI {
+	return s.store
+}
+
+func (s *Store) Customer() storage.CustomerRepoI {
+	return s.customer
+}
+
+func (s *Store) Staff() storage.StaffRepoI {
+	return s.staff
+}
+
+func (s *Store) Order() storage.OrderRepoI {
+	return s.order
+}
+
+func (s *Store) Promocode() storage.PromocodeRepoI {
+	return s.promocode
+}
+
+func (s *Store) Report() storage.ReportRepoI {
+	return s.report
+}
+
+func (s *Store) User() storage.UserRepoI {
+	return s.user
+}
+
+func (s *Store) RefreshToken() storage.RefreshTokenRepoI {
+	return s.refresh
+}
+
+func (s *Store) Supplier() storage.SupplierRepoI {
+	return s.supplier
+}
+
+func (s *Store) PurchaseOrder() storage.PurchaseOrderRepoI {
+	return s.purchase
+}
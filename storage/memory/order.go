@@ -0,0 +1,608 @@
+package memory
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type orderRepo struct {
+	t *tables
+}
+
+func NewOrderRepo(t *tables) *orderRepo {
+	return &orderRepo{
+		t: t,
+	}
+}
+
+// decimal reads a float32 price the way Postgres prints the DECIMAL column, so 379.99 stays
+// 379.99 instead of its nearest float32.
+func decimal(price float32) float64 {
+	value, _ := strconv.ParseFloat(strconv.FormatFloat(float64(price), 'f', -1, 32), 64)
+	return value
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(timestampLayout)
+}
+
+// checkOrder enforces the foreign keys of orders, customerId 0 is NULL.
+func (t *tables) checkOrder(customerId, storeId, staffId int) error {
+	if _, ok := t.customers[customerId]; customerId > 0 && !ok {
+		return foreignKeyViolation("orders", "orders_customer_id_fkey")
+	}
+	if _, ok := t.stores[storeId]; !ok {
+		return foreignKeyViolation("orders", "orders_store_id_fkey")
+	}
+	if _, ok := t.staffs[staffId]; !ok {
+		return foreignKeyViolation("orders", "orders_staff_id_fkey")
+	}
+	return nil
+}
+
+// order returns the order joined with its customer, store, staff and items. Like the inner
+// join in postgresql, an order without a customer is not found.
+func (t *tables) order(id int) (*models.Order, bool) {
+	row, ok := t.orders[id]
+	if !ok {
+		return nil, false
+	}
+
+	customer, ok := t.customers[row.customerId]
+	if !ok {
+		return nil, false
+	}
+	customerData := *customer
+	storeData := *t.stores[row.storeId]
+	staff := t.staffs[row.staffId]
+
+	order := &models.Order{
+		OrderId:      row.orderId,
+		CustomerId:   row.customerId,
+		CustomerData: &customerData,
+		OrderStatus:  row.orderStatus,
+		OrderDate:    formatDate(row.orderDate),
+		RequiredDate: formatDate(row.requiredDate),
+		ShippedDate:  formatDate(row.shippedDate),
+		StoreId:      row.storeId,
+		StoreData:    &storeData,
+		StaffId:      row.staffId,
+		StaffData: &models.Staff{
+			StaffId:   staff.StaffId,
+			FirstName: staff.FirstName,
+			LastName:  staff.LastName,
+			Email:     staff.Email,
+			Phone:     staff.Phone,
+			Active:    staff.Active,
+			StoreId:   staff.StoreId,
+			ManagerId: staff.ManagerId,
+		},
+		OrderItems: []*models.OrderItem{},
+	}
+
+	for _, item := range t.orderItems[id] {
+		order.OrderItems = append(order.OrderItems, &models.OrderItem{
+			OrderId:   item.OrderId,
+			ItemId:    item.ItemId,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			ListPrice: item.ListPrice,
+			Discount:  item.Discount,
+		})
+	}
+
+	return order, true
+}
+
+// deleteOrder removes the order with its items and status history. The stock ledger keeps
+// its rows, it has no foreign key to orders.
+func (t *tables) deleteOrder(id int) {
+	history := t.history[:0]
+	for _, row := range t.history {
+		if row.OrderId != id {
+			history = append(history, row)
+		}
+	}
+	t.history = history
+
+	delete(t.orderItems, id)
+	delete(t.orders, id)
+}
+
+func (t *tables) insertOrder(req *models.CreateOrder) (int, error) {
+	err := t.checkOrder(req.CustomerId, req.StoreId, req.StaffId)
+	if err != nil {
+		return 0, err
+	}
+
+	requiredDate, err := parseDate(req.RequiredDate)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.checkChangedBy(req.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	id := t.next("orders")
+	t.orders[id] = &orderRow{
+		orderId:      id,
+		customerId:   req.CustomerId,
+		orderStatus:  models.OrderStatusPending,
+		orderDate:    today(),
+		requiredDate: requiredDate,
+		storeId:      req.StoreId,
+		staffId:      req.StaffId,
+	}
+
+	t.insertOrderStatusHistory(id, 0, models.OrderStatusPending, req.CreatedBy, "")
+	return id, nil
+}
+
+func (r *orderRepo) Create(ctx context.Context, req *models.CreateOrder) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	return r.t.insertOrder(req)
+}
+
+// Place creates the order with all of its items, taking the items out of the store's stock.
+// Everything is checked before anything is written, so a failing item leaves no order behind.
+func (r *orderRepo) Place(ctx context.Context, req *models.PlaceOrder) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	items := make([]*models.PlaceOrderItem, len(req.Items))
+	copy(items, req.Items)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	err := r.t.checkOrder(req.CustomerId, req.StoreId, req.StaffId)
+	if err != nil {
+		return 0, err
+	}
+	_, err = parseDate(req.RequiredDate)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := map[int]int{}
+	for _, item := range items {
+		if _, ok := r.t.products[item.ProductId]; !ok {
+			return 0, pgx.ErrNoRows
+		}
+
+		if _, ok := remaining[item.ProductId]; !ok {
+			quantity, ok := r.t.stocks[stockKey{req.StoreId, item.ProductId}]
+			if !ok && item.Quantity > 0 {
+				return 0, fmt.Errorf("%w: product %d is not stocked in store %d", storage.ErrNotEnoughStock, item.ProductId, req.StoreId)
+			}
+			remaining[item.ProductId] = quantity
+		}
+
+		err = checkItemStock(item.ProductId, item.Quantity, remaining[item.ProductId])
+		if err != nil {
+			return 0, err
+		}
+		remaining[item.ProductId] -= item.Quantity
+	}
+
+	id, err := r.t.insertOrder(&models.CreateOrder{
+		CustomerId:   req.CustomerId,
+		RequiredDate: req.RequiredDate,
+		StoreId:      req.StoreId,
+		StaffId:      req.StaffId,
+		CreatedBy:    req.CreatedBy,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range items {
+		err = r.t.insertOrderItem(req.StoreId, &models.CreateOrderItem{
+			OrderId:   id,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			ListPrice: decimal(r.t.products[item.ProductId].ListPrice),
+			Discount:  item.Discount,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+func (r *orderRepo) GetById(ctx context.Context, req *models.OrderPrimaryKey) (*models.Order, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.order(req.OrderId)
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return order, nil
+}
+
+// compareDate orders NULL dates last, like Postgres does for ascending sorts.
+func compareDate(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+var orderSortColumns = map[string]compareFunc[*models.Order]{
+	"order_id":      func(a, b *models.Order) int { return compareInt(a.OrderId, b.OrderId) },
+	"order_date":    func(a, b *models.Order) int { return compareDate(a.OrderDate, b.OrderDate) },
+	"required_date": func(a, b *models.Order) int { return compareDate(a.RequiredDate, b.RequiredDate) },
+	"shipped_date":  func(a, b *models.Order) int { return compareDate(a.ShippedDate, b.ShippedDate) },
+	"order_status": func(a, b *models.Order) int {
+		return compareInt(int(a.OrderStatus), int(b.OrderStatus))
+	},
+	"customer_name": func(a, b *models.Order) int {
+		return strings.Compare(
+			a.CustomerData.LastName+" "+a.CustomerData.FirstName,
+			b.CustomerData.LastName+" "+b.CustomerData.FirstName,
+		)
+	},
+}
+
+func (r *orderRepo) GetList(ctx context.Context, req *models.GetListOrderRequest) (*models.GetListOrderResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	orders := &models.GetListOrderResponse{}
+	orders.Orders = []*models.Order{}
+
+	rows, err := r.t.listOrders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// keyset pages only follow the default order by id
+	var page []*models.Order
+	if len(req.Sort) == 0 {
+		page, orders.NextCursor, err = keyset(rows, func(o *models.Order) int { return o.OrderId }, req.Offset, req.Limit, req.After)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(req.After) > 0 {
+		return nil, fmt.Errorf("%w: after cannot be combined with sort", storage.ErrInvalidCursor)
+	} else {
+		page = paginate(rows, req.Offset, req.Limit)
+	}
+
+	orders.Orders = append(orders.Orders, page...)
+	orders.Count = len(rows)
+	return orders, nil
+}
+
+// Export hands every order matching the filters and sort of req to fn, ignoring pagination.
+func (r *orderRepo) Export(ctx context.Context, req *models.GetListOrderRequest, fn func(*models.Order) error) error {
+	r.t.mu.Lock()
+	rows, err := r.t.listOrders(req)
+	r.t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listOrders returns the orders matching the filters of req in the order it asks for.
+func (t *tables) listOrders(req *models.GetListOrderRequest) ([]*models.Order, error) {
+	filters := []struct {
+		value string
+		keep  func(date time.Time, value time.Time) bool
+		date  func(o *orderRow) time.Time
+	}{
+		{req.OrderDateFrom, func(d, v time.Time) bool { return !d.Before(v) }, func(o *orderRow) time.Time { return o.orderDate }},
+		{req.OrderDateTo, func(d, v time.Time) bool { return !d.After(v) }, func(o *orderRow) time.Time { return o.orderDate }},
+		{req.RequiredDateFrom, func(d, v time.Time) bool { return !d.Before(v) }, func(o *orderRow) time.Time { return o.requiredDate }},
+		{req.RequiredDateTo, func(d, v time.Time) bool { return !d.After(v) }, func(o *orderRow) time.Time { return o.requiredDate }},
+	}
+
+	var rows []*models.Order
+	for _, id := range sortedIds(t.orders) {
+		row := t.orders[id]
+
+		order, ok := t.order(id)
+		switch {
+		case !ok,
+			!contains(req.Search, order.CustomerData.FirstName+" "+order.CustomerData.LastName, order.CustomerData.Email),
+			req.CustomerId > 0 && row.customerId != req.CustomerId,
+			req.StoreId > 0 && row.storeId != req.StoreId,
+			req.StaffId > 0 && row.staffId != req.StaffId,
+			req.OrderStatus > 0 && row.orderStatus != req.OrderStatus,
+			req.Late && (row.shippedDate.IsZero() || !row.shippedDate.After(row.requiredDate)):
+			continue
+		}
+
+		keep := true
+		for _, filter := range filters {
+			if len(filter.value) == 0 {
+				continue
+			}
+
+			value, err := parseDate(filter.value)
+			if err != nil {
+				return nil, err
+			}
+			keep = keep && filter.keep(filter.date(row), value)
+		}
+
+		if keep {
+			rows = append(rows, order)
+		}
+	}
+
+	err := sortRows(rows, req.Sort, orderSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *orderRepo) Update(ctx context.Context, req *models.UpdateOrder) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.orders[req.OrderId]
+	if !ok {
+		return 0, nil
+	}
+
+	// the postgresql repo writes customer_id as given, 0 is not NULL here
+	if _, ok := r.t.customers[req.CustomerId]; !ok {
+		return 0, foreignKeyViolation("orders", "orders_customer_id_fkey")
+	}
+	err := r.t.checkOrder(req.CustomerId, req.StoreId, req.StaffId)
+	if err != nil {
+		return 0, err
+	}
+
+	requiredDate, err := parseDate(req.RequiredDate)
+	if err != nil {
+		return 0, err
+	}
+
+	if req.StoreId != order.storeId && models.OrderStatusHoldsStock(order.orderStatus) {
+		return 0, fmt.Errorf("%w: order is %s, its store can not change", storage.ErrOrderClosed, models.OrderStatusName(order.orderStatus))
+	}
+
+	order.customerId = req.CustomerId
+	order.requiredDate = requiredDate
+	order.storeId = req.StoreId
+	order.staffId = req.StaffId
+
+	return 1, nil
+}
+
+// Delete returns the items of an order that still holds stock before deleting it.
+func (r *orderRepo) Delete(ctx context.Context, req *models.OrderPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.orders[req.OrderId]
+	if !ok {
+		return 0, nil
+	}
+
+	if models.OrderStatusHoldsStock(order.orderStatus) {
+		r.t.returnOrderItems(req.OrderId, 0, order.storeId, models.StockMovementOrderDeleted)
+	}
+
+	r.t.deleteOrder(req.OrderId)
+	return 1, nil
+}
+
+// Order Item
+
+func (r *orderRepo) AddOrderItem(ctx context.Context, req *models.CreateOrderItem) error {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.orders[req.OrderId]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+
+	if !models.OrderStatusHoldsStock(order.orderStatus) {
+		return fmt.Errorf("%w: order is %s", storage.ErrOrderClosed, models.OrderStatusName(order.orderStatus))
+	}
+
+	if req.Quantity <= 0 {
+		return storage.ErrInvalidQuantity
+	}
+
+	quantity, ok := r.t.stocks[stockKey{order.storeId, req.ProductId}]
+	if !ok {
+		return fmt.Errorf("%w: product %d is not stocked in store %d", storage.ErrNotEnoughStock, req.ProductId, order.storeId)
+	}
+
+	err := checkItemStock(req.ProductId, req.Quantity, quantity)
+	if err != nil {
+		return err
+	}
+
+	return r.t.insertOrderItem(order.storeId, req)
+}
+
+func checkItemStock(productId, quantity, left int) error {
+	if quantity <= 0 {
+		return storage.ErrInvalidQuantity
+	}
+	if left < quantity {
+		return fmt.Errorf("%w: product %d has %d left", storage.ErrNotEnoughStock, productId, left)
+	}
+	return nil
+}
+
+// insertOrderItem takes the quantity out of the stock and adds the item under the next item
+// id. The caller has checked the stock already.
+func (t *tables) insertOrderItem(storeId int, req *models.CreateOrderItem) error {
+	key := stockKey{storeId, req.ProductId}
+	t.stocks[key] -= req.Quantity
+
+	itemId := 1
+	for _, item := range t.orderItems[req.OrderId] {
+		if item.ItemId >= itemId {
+			itemId = item.ItemId + 1
+		}
+	}
+
+	t.orderItems[req.OrderId] = append(t.orderItems[req.OrderId], &models.OrderItem{
+		OrderId:   req.OrderId,
+		ItemId:    itemId,
+		ProductId: req.ProductId,
+		Quantity:  req.Quantity,
+		ListPrice: req.ListPrice,
+		Discount:  req.Discount,
+	})
+
+	t.insertStockMovement(&models.StockMovement{
+		StoreId:   storeId,
+		ProductId: req.ProductId,
+		Quantity:  -req.Quantity,
+		Reason:    models.StockMovementOrderItem,
+		OrderId:   req.OrderId,
+		ItemId:    itemId,
+	})
+	return nil
+}
+
+// RemoveOrderItem returns the item to stock and deletes it. Only orders that still hold their
+// stock can lose items.
+func (r *orderRepo) RemoveOrderItem(ctx context.Context, req *models.OrderItemPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.orders[req.OrderId]
+	if !ok {
+		return 0, nil
+	}
+
+	if !models.OrderStatusHoldsStock(order.orderStatus) {
+		return 0, fmt.Errorf("%w: order is %s", storage.ErrOrderClosed, models.OrderStatusName(order.orderStatus))
+	}
+
+	r.t.returnOrderItems(req.OrderId, req.ItemId, order.storeId, models.StockMovementItemRemoved)
+
+	items := r.t.orderItems[req.OrderId]
+	for i, item := range items {
+		if item.ItemId == req.ItemId {
+			r.t.orderItems[req.OrderId] = append(items[:i:i], items[i+1:]...)
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// Order Status
+
+func (r *orderRepo) ChangeStatus(ctx context.Context, req *models.ChangeOrderStatus) error {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	order, ok := r.t.orders[req.OrderId]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	current := order.orderStatus
+
+	if !models.CanChangeOrderStatus(current, req.Status) {
+		return fmt.Errorf("%w: %s -> %s", storage.ErrInvalidStatusTransition,
+			models.OrderStatusName(current), models.OrderStatusName(req.Status))
+	}
+
+	err := r.t.checkChangedBy(req.ChangedBy)
+	if err != nil {
+		return err
+	}
+
+	order.orderStatus = req.Status
+	if req.Status == models.OrderStatusShipped {
+		order.shippedDate = today()
+	}
+
+	if models.OrderStatusHoldsStock(current) && !models.OrderStatusHoldsStock(req.Status) {
+		switch req.Status {
+		case models.OrderStatusCancelled:
+			r.t.returnOrderItems(req.OrderId, 0, order.storeId, models.StockMovementOrderCancelled)
+		case models.OrderStatusRejected:
+			r.t.returnOrderItems(req.OrderId, 0, order.storeId, models.StockMovementOrderRejected)
+		}
+	}
+
+	r.t.insertOrderStatusHistory(req.OrderId, current, req.Status, req.ChangedBy, req.Comment)
+	return nil
+}
+
+func (r *orderRepo) GetStatusHistory(ctx context.Context, req *models.OrderPrimaryKey) (*models.GetOrderStatusHistoryResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	resp := &models.GetOrderStatusHistoryResponse{}
+	resp.History = []*models.OrderStatusHistory{}
+
+	if _, ok := r.t.orders[req.OrderId]; !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	for _, row := range r.t.history {
+		if row.OrderId == req.OrderId {
+			history := *row
+			resp.History = append(resp.History, &history)
+		}
+	}
+
+	resp.Count = len(resp.History)
+	return resp, nil
+}
+
+// checkChangedBy enforces the foreign key of order_status_history.changed_by, empty is NULL.
+func (t *tables) checkChangedBy(userId string) error {
+	if _, ok := t.users[userId]; userId != "" && !ok {
+		return foreignKeyViolation("order_status_history", "order_status_history_changed_by_fkey")
+	}
+	return nil
+}
+
+func (t *tables) insertOrderStatusHistory(orderId int, from, to int16, changedBy, comment string) {
+	t.history = append(t.history, &models.OrderStatusHistory{
+		HistoryId:  t.next("order_status_history"),
+		OrderId:    orderId,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  changedBy,
+		Comment:    comment,
+		ChangedAt:  formatTimestamp(time.Now().UTC()),
+	})
+}
@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type productRepo struct {
+	t *tables
+}
+
+func NewProductRepo(t *tables) *productRepo {
+	return &productRepo{
+		t: t,
+	}
+}
+
+// checkProduct enforces the foreign keys of products.
+func (t *tables) checkProduct(brandId, categoryId int) error {
+	if _, ok := t.brands[brandId]; !ok {
+		return foreignKeyViolation("products", "products_brand_id_fkey")
+	}
+	if _, ok := t.categories[categoryId]; !ok {
+		return foreignKeyViolation("products", "products_category_id_fkey")
+	}
+	return nil
+}
+
+// product returns the product joined with its brand and category.
+func (t *tables) product(id int) (*models.Product, bool) {
+	row, ok := t.products[id]
+	if !ok {
+		return nil, false
+	}
+
+	product := *row
+	brand := *t.brands[product.BrandId]
+	category := *t.categories[product.CategoryId]
+	product.BrandData = &brand
+	product.CategoryData = &category
+
+	return &product, true
+}
+
+// deleteProduct removes the product and everything that cascades from it.
+func (t *tables) deleteProduct(id int) {
+	for key := range t.stocks {
+		if key.productId == id {
+			delete(t.stocks, key)
+		}
+	}
+
+	for orderId, items := range t.orderItems {
+		kept := items[:0]
+		for _, item := range items {
+			if item.ProductId != id {
+				kept = append(kept, item)
+			}
+		}
+		t.orderItems[orderId] = kept
+	}
+
+	t.deletePurchaseOrderItems(func(item *models.PurchaseOrderItem) bool { return item.ProductId == id })
+
+	movements := t.movements[:0]
+	for _, movement := range t.movements {
+		if movement.ProductId != id {
+			movements = append(movements, movement)
+		}
+	}
+	t.movements = movements
+
+	delete(t.products, id)
+}
+
+func (p *productRepo) Create(ctx context.Context, req *models.CreateProduct) (int, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	err := p.t.checkProduct(req.BrandId, req.CategoryId)
+	if err != nil {
+		return 0, err
+	}
+
+	id := p.t.next("products")
+	p.t.products[id] = &models.Product{
+		ProductId:   id,
+		ProductName: req.ProductName,
+		BrandId:     req.BrandId,
+		CategoryId:  req.CategoryId,
+		ModelYear:   req.ModelYear,
+		ListPrice:   req.ListPrice,
+	}
+
+	return id, nil
+}
+
+func (p *productRepo) GetById(ctx context.Context, req *models.ProductPrimaryKey) (*models.Product, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	product, ok := p.t.product(req.ProductId)
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return product, nil
+}
+
+var productSortColumns = map[string]compareFunc[*models.Product]{
+	"product_id": func(a, b *models.Product) int { return compareInt(a.ProductId, b.ProductId) },
+	"product_name": func(a, b *models.Product) int {
+		return strings.Compare(a.ProductName, b.ProductName)
+	},
+	"brand_name": func(a, b *models.Product) int {
+		return strings.Compare(a.BrandData.BrandName, b.BrandData.BrandName)
+	},
+	"category_name": func(a, b *models.Product) int {
+		return strings.Compare(a.CategoryData.CategoryName, b.CategoryData.CategoryName)
+	},
+	"model_year": func(a, b *models.Product) int { return compareInt(a.ModelYear, b.ModelYear) },
+	"list_price": func(a, b *models.Product) int {
+		return compareFloat(float64(a.ListPrice), float64(b.ListPrice))
+	},
+}
+
+func (p *productRepo) GetList(ctx context.Context, req *models.GetListProductRequest) (*models.GetListProductResponse, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	resp := models.GetListProductResponse{}
+
+	rows, err := p.t.listProducts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// keyset pages only follow the default order by id
+	var page []*models.Product
+	if len(req.Sort) == 0 {
+		page, resp.NextCursor, err = keyset(rows, func(p *models.Product) int { return p.ProductId }, req.Offset, req.Limit, req.After)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(req.After) > 0 {
+		return nil, fmt.Errorf("%w: after cannot be combined with sort", storage.ErrInvalidCursor)
+	} else {
+		page = paginate(rows, req.Offset, req.Limit)
+	}
+
+	resp.Products = append(resp.Products, page...)
+	resp.Count = len(rows)
+
+	return &resp, nil
+}
+
+// Export hands every product matching the filters and sort of req to fn, ignoring pagination.
+func (p *productRepo) Export(ctx context.Context, req *models.GetListProductRequest, fn func(*models.Product) error) error {
+	p.t.mu.Lock()
+	rows, err := p.t.listProducts(req)
+	p.t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listProducts returns the products matching the filters of req in the order it asks for.
+func (t *tables) listProducts(req *models.GetListProductRequest) ([]*models.Product, error) {
+	var rows []*models.Product
+	for _, id := range sortedIds(t.products) {
+		product, _ := t.product(id)
+
+		switch {
+		case !contains(req.Search, product.ProductName),
+			req.BrandId > 0 && product.BrandId != req.BrandId,
+			req.CategoryId > 0 && product.CategoryId != req.CategoryId,
+			req.ModelYearFrom > 0 && product.ModelYear < req.ModelYearFrom,
+			req.ModelYearTo > 0 && product.ModelYear > req.ModelYearTo,
+			req.PriceFrom > 0 && float64(product.ListPrice) < req.PriceFrom,
+			req.PriceTo > 0 && float64(product.ListPrice) > req.PriceTo,
+			req.InStockStoreId > 0 && t.stocks[stockKey{req.InStockStoreId, id}] <= 0:
+			continue
+		}
+
+		rows = append(rows, product)
+	}
+
+	err := sortRows(rows, req.Sort, productSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (p *productRepo) Update(ctx context.Context, req *models.UpdateProduct) (int64, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	product, ok := p.t.products[req.ProductId]
+	if !ok {
+		return 0, nil
+	}
+
+	err := p.t.checkProduct(req.BrandId, req.CategoryId)
+	if err != nil {
+		return 0, err
+	}
+
+	product.ProductName = req.ProductName
+	product.BrandId = req.BrandId
+	product.CategoryId = req.CategoryId
+	product.ModelYear = req.ModelYear
+	product.ListPrice = req.ListPrice
+
+	return 1, nil
+}
+
+// Delete cascades to the stock, order items and stock movements of the product.
+func (p *productRepo) Delete(ctx context.Context, req *models.ProductPrimaryKey) (int64, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	if _, ok := p.t.products[req.ProductId]; !ok {
+		return 0, nil
+	}
+
+	p.t.deleteProduct(req.ProductId)
+	return 1, nil
+}
+
+// Import checks every product before inserting any, like the transaction in postgresql.
+func (p *productRepo) Import(ctx context.Context, req []*models.CreateProduct) (int64, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	for _, product := range req {
+		err := p.t.checkProduct(product.BrandId, product.CategoryId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, product := range req {
+		id := p.t.next("products")
+		p.t.products[id] = &models.Product{
+			ProductId:   id,
+			ProductName: product.ProductName,
+			BrandId:     product.BrandId,
+			CategoryId:  product.CategoryId,
+			ModelYear:   product.ModelYear,
+			ListPrice:   product.ListPrice,
+		}
+	}
+
+	return int64(len(req)), nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type promocodeRepo struct {
+	t *tables
+}
+
+func NewPromocodeRepo(t *tables) *promocodeRepo {
+	return &promocodeRepo{
+		t: t,
+	}
+}
+
+func (p *promocodeRepo) Create(ctx context.Context, req *models.CreatePromocode) (int, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	id := p.t.next("promocodes")
+	p.t.promocodes[id] = &models.Promocode{
+		PromocodeId:     id,
+		PromocodeName:   req.PromocodeName,
+		Discount:        req.Discount,
+		DiscountType:    req.DiscountType,
+		OrderLimitPrice: req.OrderLimitPrice,
+	}
+
+	return id, nil
+}
+
+func (p *promocodeRepo) GetById(ctx context.Context, req *models.PromocodePrimaryKey) (*models.Promocode, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	promocode, ok := p.t.promocodes[req.PromocodeId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *promocode
+	return &resp, nil
+}
+
+func (p *promocodeRepo) GetList(ctx context.Context, req *models.GetListPromocodeRequest) (*models.GetListPromocodeResponse, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	promocodes := models.GetListPromocodeResponse{}
+
+	var rows []*models.Promocode
+	for _, id := range sortedIds(p.t.promocodes) {
+		promocode := *p.t.promocodes[id]
+		if contains(req.Search, promocode.PromocodeName) {
+			rows = append(rows, &promocode)
+		}
+	}
+
+	promocodes.Promocodes = append(promocodes.Promocodes, paginate(rows, req.Offset, req.Limit)...)
+	promocodes.Count = len(rows)
+
+	return &promocodes, nil
+}
+
+func (p *promocodeRepo) Delete(ctx context.Context, req *models.PromocodePrimaryKey) (int64, error) {
+	p.t.mu.Lock()
+	defer p.t.mu.Unlock()
+
+	if _, ok := p.t.promocodes[req.PromocodeId]; !ok {
+		return 0, nil
+	}
+
+	delete(p.t.promocodes, req.PromocodeId)
+	return 1, nil
+}
@@ -0,0 +1,377 @@
+package memory
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type purchaseOrderRow struct {
+	purchaseOrderId int
+	supplierId      int
+	status          int16
+	orderDate       time.Time
+	expectedDate    time.Time // zero is NULL
+}
+
+type purchaseOrderRepo struct {
+	t *tables
+}
+
+func NewPurchaseOrderRepo(t *tables) *purchaseOrderRepo {
+	return &purchaseOrderRepo{
+		t: t,
+	}
+}
+
+// purchaseOrder returns the purchase order joined with its supplier and items.
+func (t *tables) purchaseOrder(id int) (*models.PurchaseOrder, bool) {
+	row, ok := t.purchaseOrders[id]
+	if !ok {
+		return nil, false
+	}
+
+	supplierData := *t.suppliers[row.supplierId]
+
+	purchaseOrder := &models.PurchaseOrder{
+		PurchaseOrderId: row.purchaseOrderId,
+		SupplierId:      row.supplierId,
+		SupplierData:    &supplierData,
+		Status:          row.status,
+		OrderDate:       formatDate(row.orderDate),
+		ExpectedDate:    formatDate(row.expectedDate),
+		Items:           []*models.PurchaseOrderItem{},
+	}
+
+	for _, item := range t.purchaseOrderItems[id] {
+		copied := *item
+		purchaseOrder.Items = append(purchaseOrder.Items, &copied)
+	}
+
+	return purchaseOrder, true
+}
+
+// checkPurchaseOrderItem enforces the constraints of purchase_order_items on a new item.
+func (t *tables) checkPurchaseOrderItem(items []*models.PurchaseOrderItem, item *models.CreatePurchaseOrderItem) error {
+	if item.Quantity <= 0 {
+		return storage.ErrInvalidQuantity
+	}
+	if item.UnitCost < 0 {
+		return checkViolation("purchase_order_items", "purchase_order_items_unit_cost_check")
+	}
+	if _, ok := t.stores[item.StoreId]; !ok {
+		return foreignKeyViolation("purchase_order_items", "purchase_order_items_store_id_fkey")
+	}
+	if _, ok := t.products[item.ProductId]; !ok {
+		return foreignKeyViolation("purchase_order_items", "purchase_order_items_product_id_fkey")
+	}
+	for _, other := range items {
+		if other.StoreId == item.StoreId && other.ProductId == item.ProductId {
+			return uniqueViolation("purchase_order_items", "purchase_order_items_purchase_order_id_store_id_product_id_key")
+		}
+	}
+	return nil
+}
+
+// checkPurchaseOrderChangedBy enforces the foreign key of purchase_order_status_history.changed_by, empty is NULL.
+func (t *tables) checkPurchaseOrderChangedBy(userId string) error {
+	if _, ok := t.users[userId]; userId != "" && !ok {
+		return foreignKeyViolation("purchase_order_status_history", "purchase_order_status_history_changed_by_fkey")
+	}
+	return nil
+}
+
+func (r *purchaseOrderRepo) Create(ctx context.Context, req *models.CreatePurchaseOrder) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.suppliers[req.SupplierId]; !ok {
+		return 0, foreignKeyViolation("purchase_orders", "purchase_orders_supplier_id_fkey")
+	}
+
+	var (
+		expectedDate time.Time
+		err          error
+	)
+	if len(req.ExpectedDate) > 0 {
+		expectedDate, err = parseDate(req.ExpectedDate)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// check every item before anything is inserted, like the rolled back transaction
+	items := make([]*models.PurchaseOrderItem, 0, len(req.Items))
+	for i, item := range req.Items {
+		err = r.t.checkPurchaseOrderItem(items, item)
+		if err != nil {
+			return 0, err
+		}
+
+		items = append(items, &models.PurchaseOrderItem{
+			ItemId:    i + 1,
+			StoreId:   item.StoreId,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			UnitCost:  math.Round(item.UnitCost*100) / 100,
+		})
+	}
+
+	err = r.t.checkPurchaseOrderChangedBy(req.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	id := r.t.next("purchase_orders")
+	r.t.purchaseOrders[id] = &purchaseOrderRow{
+		purchaseOrderId: id,
+		supplierId:      req.SupplierId,
+		status:          models.PurchaseOrderStatusDraft,
+		orderDate:       today(),
+		expectedDate:    expectedDate,
+	}
+	for _, item := range items {
+		item.PurchaseOrderId = id
+	}
+	r.t.purchaseOrderItems[id] = items
+
+	r.t.insertPurchaseOrderStatusHistory(id, 0, models.PurchaseOrderStatusDraft, req.CreatedBy, "")
+	return id, nil
+}
+
+func (r *purchaseOrderRepo) GetById(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (*models.PurchaseOrder, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	purchaseOrder, ok := r.t.purchaseOrder(req.PurchaseOrderId)
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return purchaseOrder, nil
+}
+
+func (r *purchaseOrderRepo) GetList(ctx context.Context, req *models.GetListPurchaseOrderRequest) (*models.GetListPurchaseOrderResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	purchaseOrders := &models.GetListPurchaseOrderResponse{}
+	purchaseOrders.PurchaseOrders = []*models.PurchaseOrder{}
+
+	var rows []*models.PurchaseOrder
+	for _, id := range sortedIds(r.t.purchaseOrders) {
+		purchaseOrder, _ := r.t.purchaseOrder(id)
+
+		if req.SupplierId > 0 && purchaseOrder.SupplierId != req.SupplierId {
+			continue
+		}
+		if req.Status > 0 && purchaseOrder.Status != req.Status {
+			continue
+		}
+		if req.StoreId > 0 && !purchaseOrderDeliversTo(purchaseOrder, req.StoreId) {
+			continue
+		}
+
+		rows = append(rows, purchaseOrder)
+	}
+
+	purchaseOrders.PurchaseOrders = append(purchaseOrders.PurchaseOrders, paginate(rows, req.Offset, req.Limit)...)
+	purchaseOrders.Count = len(rows)
+
+	return purchaseOrders, nil
+}
+
+func purchaseOrderDeliversTo(purchaseOrder *models.PurchaseOrder, storeId int) bool {
+	for _, item := range purchaseOrder.Items {
+		if item.StoreId == storeId {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes the purchase order with its items and history. Goods already received stay
+// in stock and in the stock ledger.
+func (r *purchaseOrderRepo) Delete(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.purchaseOrders[req.PurchaseOrderId]; !ok {
+		return 0, nil
+	}
+
+	r.t.deletePurchaseOrder(req.PurchaseOrderId)
+	return 1, nil
+}
+
+func (t *tables) deletePurchaseOrder(id int) {
+	history := t.purchaseHistory[:0]
+	for _, row := range t.purchaseHistory {
+		if row.PurchaseOrderId != id {
+			history = append(history, row)
+		}
+	}
+	t.purchaseHistory = history
+
+	delete(t.purchaseOrderItems, id)
+	delete(t.purchaseOrders, id)
+}
+
+// deletePurchaseOrderItems removes the items of every purchase order that match, for the
+// cascades from stores and products.
+func (t *tables) deletePurchaseOrderItems(match func(item *models.PurchaseOrderItem) bool) {
+	for id, items := range t.purchaseOrderItems {
+		kept := items[:0]
+		for _, item := range items {
+			if !match(item) {
+				kept = append(kept, item)
+			}
+		}
+		t.purchaseOrderItems[id] = kept
+	}
+}
+
+func (r *purchaseOrderRepo) ChangeStatus(ctx context.Context, req *models.ChangePurchaseOrderStatus) error {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	purchaseOrder, ok := r.t.purchaseOrders[req.PurchaseOrderId]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	current := purchaseOrder.status
+
+	if !models.CanChangePurchaseOrderStatus(current, req.Status) {
+		return fmt.Errorf("%w: %s -> %s", storage.ErrInvalidStatusTransition,
+			models.PurchaseOrderStatusName(current), models.PurchaseOrderStatusName(req.Status))
+	}
+
+	err := r.t.checkPurchaseOrderChangedBy(req.ChangedBy)
+	if err != nil {
+		return err
+	}
+
+	purchaseOrder.status = req.Status
+	r.t.insertPurchaseOrderStatusHistory(req.PurchaseOrderId, current, req.Status, req.ChangedBy, req.Comment)
+	return nil
+}
+
+// Receive books one goods receipt: every received quantity is added to its item and to the
+// stock of the item's store, and the purchase order moves to partially received or, once
+// nothing is outstanding, to received. Nothing changes when any line fails.
+func (r *purchaseOrderRepo) Receive(ctx context.Context, req *models.ReceivePurchaseOrder) error {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	purchaseOrder, ok := r.t.purchaseOrders[req.PurchaseOrderId]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	current := purchaseOrder.status
+
+	if !models.PurchaseOrderCanReceive(current) {
+		return fmt.Errorf("%w: purchase order is %s", storage.ErrOrderClosed, models.PurchaseOrderStatusName(current))
+	}
+
+	receipts := make([]*models.ReceivePurchaseOrderItem, len(req.Items))
+	copy(receipts, req.Items)
+	sort.SliceStable(receipts, func(i, j int) bool { return receipts[i].ItemId < receipts[j].ItemId })
+
+	items := map[int]*models.PurchaseOrderItem{}
+	for _, item := range r.t.purchaseOrderItems[req.PurchaseOrderId] {
+		items[item.ItemId] = item
+	}
+
+	// check the whole receipt first, an item may be received on more than one line
+	received := map[int]int{}
+	for _, receipt := range receipts {
+		if receipt.Quantity <= 0 {
+			return storage.ErrInvalidQuantity
+		}
+
+		item, ok := items[receipt.ItemId]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+
+		if item.ReceivedQuantity+received[item.ItemId]+receipt.Quantity > item.Quantity {
+			return fmt.Errorf("%w: item %d has %d of %d received", storage.ErrOverReceived,
+				receipt.ItemId, item.ReceivedQuantity+received[item.ItemId], item.Quantity)
+		}
+		received[item.ItemId] += receipt.Quantity
+	}
+
+	err := r.t.checkPurchaseOrderChangedBy(req.ReceivedBy)
+	if err != nil {
+		return err
+	}
+
+	for _, receipt := range receipts {
+		item := items[receipt.ItemId]
+		item.ReceivedQuantity += receipt.Quantity
+		r.t.stocks[stockKey{item.StoreId, item.ProductId}] += receipt.Quantity
+
+		r.t.insertStockMovement(&models.StockMovement{
+			StoreId:         item.StoreId,
+			ProductId:       item.ProductId,
+			Quantity:        receipt.Quantity,
+			Reason:          models.StockMovementPurchaseReceived,
+			ItemId:          item.ItemId,
+			PurchaseOrderId: req.PurchaseOrderId,
+		})
+	}
+
+	next := models.PurchaseOrderStatusReceived
+	for _, item := range items {
+		if item.ReceivedQuantity < item.Quantity {
+			next = models.PurchaseOrderStatusPartiallyReceived
+		}
+	}
+	if next == current {
+		return nil
+	}
+
+	purchaseOrder.status = next
+	r.t.insertPurchaseOrderStatusHistory(req.PurchaseOrderId, current, next, req.ReceivedBy, req.Comment)
+	return nil
+}
+
+func (r *purchaseOrderRepo) GetStatusHistory(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (*models.GetPurchaseOrderStatusHistoryResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	resp := &models.GetPurchaseOrderStatusHistoryResponse{}
+	resp.History = []*models.PurchaseOrderStatusHistory{}
+
+	if _, ok := r.t.purchaseOrders[req.PurchaseOrderId]; !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	for _, row := range r.t.purchaseHistory {
+		if row.PurchaseOrderId == req.PurchaseOrderId {
+			history := *row
+			resp.History = append(resp.History, &history)
+		}
+	}
+
+	resp.Count = len(resp.History)
+	return resp, nil
+}
+
+func (t *tables) insertPurchaseOrderStatusHistory(purchaseOrderId int, from, to int16, changedBy, comment string) {
+	t.purchaseHistory = append(t.purchaseHistory, &models.PurchaseOrderStatusHistory{
+		HistoryId:       t.next("purchase_order_status_history"),
+		PurchaseOrderId: purchaseOrderId,
+		FromStatus:      from,
+		ToStatus:        to,
+		ChangedBy:       changedBy,
+		Comment:         comment,
+		ChangedAt:       formatTimestamp(time.Now().UTC()),
+	})
+}
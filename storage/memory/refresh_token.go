@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type refreshTokenRepo struct {
+	t *tables
+}
+
+func NewRefreshTokenRepo(t *tables) *refreshTokenRepo {
+	return &refreshTokenRepo{
+		t: t,
+	}
+}
+
+func formatTimestamp(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(timestampLayout + ".999999")
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, req *models.CreateRefreshToken) (string, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.users[req.UserId]; !ok {
+		return "", foreignKeyViolation("refresh_tokens", "refresh_tokens_user_id_fkey")
+	}
+	if _, ok := r.t.refreshTokens[req.TokenHash]; ok {
+		return "", uniqueViolation("refresh_tokens", "refresh_tokens_pkey")
+	}
+
+	now := time.Now().UTC()
+	r.t.refreshTokens[req.TokenHash] = &refreshTokenRow{
+		tokenHash: req.TokenHash,
+		userId:    req.UserId,
+		expiresAt: now.Add(time.Duration(req.ExpiresIn) * time.Second),
+		createdAt: now,
+	}
+
+	return req.TokenHash, nil
+}
+
+func (r *refreshTokenRepo) GetById(ctx context.Context, req *models.RefreshTokenPrimaryKey) (*models.RefreshToken, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	row, ok := r.t.refreshTokens[req.TokenHash]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return &models.RefreshToken{
+		TokenHash: row.tokenHash,
+		UserId:    row.userId,
+		ExpiresAt: formatTimestamp(row.expiresAt),
+		RevokedAt: formatTimestamp(row.revokedAt),
+		CreatedAt: formatTimestamp(row.createdAt),
+	}, nil
+}
+
+// Revoke marks an active token as revoked. Zero rows affected means the token
+// was already revoked or has expired, so it must not be honoured.
+func (r *refreshTokenRepo) Revoke(ctx context.Context, req *models.RefreshTokenPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	row, ok := r.t.refreshTokens[req.TokenHash]
+	if !ok || !row.revokedAt.IsZero() || !row.expiresAt.After(now) {
+		return 0, nil
+	}
+
+	row.revokedAt = now
+	return 1, nil
+}
+
+func (r *refreshTokenRepo) RevokeByUser(ctx context.Context, req *models.UserPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	var count int64
+	now := time.Now().UTC()
+	for _, row := range r.t.refreshTokens {
+		if row.userId == req.UserId && row.revokedAt.IsZero() {
+			row.revokedAt = now
+			count++
+		}
+	}
+
+	return count, nil
+}
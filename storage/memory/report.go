@@ -0,0 +1,208 @@
+package memory
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type reportRepo struct {
+	t *tables
+}
+
+func NewReportRepo(t *tables) *reportRepo {
+	return &reportRepo{
+		t: t,
+	}
+}
+
+// SendProduct moves stock between stores. Like the postgresql repo, the receiver only gets the
+// quantity when it already has a stock row for the product.
+func (r *reportRepo) SendProduct(ctx context.Context, req *models.SendProduct) error {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if req.Quantity <= 0 {
+		return storage.ErrInvalidQuantity
+	}
+
+	sender := stockKey{req.SenderId, req.ProductId}
+	senderStock, ok := r.t.stocks[sender]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+
+	if senderStock < req.Quantity {
+		return storage.ErrNotEnoughStock
+	}
+
+	r.t.stocks[sender] -= req.Quantity
+
+	receiver := stockKey{req.ReceiverId, req.ProductId}
+	if _, ok := r.t.stocks[receiver]; ok {
+		r.t.stocks[receiver] += req.Quantity
+	}
+
+	return nil
+}
+
+func (r *reportRepo) StaffReport(ctx context.Context, req *models.StaffListRequest) (*models.StaffListResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	staffs := &models.StaffListResponse{}
+
+	rows := r.t.staffReport(req)
+	staffs.StaffReport = append(staffs.StaffReport, paginate(rows, req.Offset, req.Limit)...)
+	staffs.Count = len(rows)
+
+	return staffs, nil
+}
+
+// ExportStaffReport hands every line of the staff report matching req to fn, ignoring pagination.
+func (r *reportRepo) ExportStaffReport(ctx context.Context, req *models.StaffListRequest, fn func(*models.StaffReport) error) error {
+	r.t.mu.Lock()
+	rows := r.t.staffReport(req)
+	r.t.mu.Unlock()
+
+	for _, row := range rows {
+		err := fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// staffReport returns the lines of the staff report matching req, by order and item.
+func (t *tables) staffReport(req *models.StaffListRequest) []*models.StaffReport {
+	var rows []*models.StaffReport
+	for _, id := range sortedIds(t.orders) {
+		order := t.orders[id]
+		staff := t.staffs[order.staffId]
+		name := staff.FirstName + " " + staff.LastName
+
+		if !contains(req.Search, name) || (req.StoreId > 0 && order.storeId != req.StoreId) {
+			continue
+		}
+
+		for _, item := range t.orderItems[id] {
+			product := t.products[item.ProductId]
+
+			rows = append(rows, &models.StaffReport{
+				StaffName:    name,
+				CategoryName: t.categories[product.CategoryId].CategoryName,
+				ProductName:  product.ProductName,
+				Quantity:     item.Quantity,
+				TotalSum:     decimal(product.ListPrice) * float64(item.Quantity),
+				StoreName:    t.stores[order.storeId].StoreName,
+				OrderDate:    order.orderDate.Format(dateLayout),
+			})
+		}
+	}
+
+	return rows
+}
+
+func (r *reportRepo) OrderTotalSum(ctx context.Context, req *models.OrderTotalSum) (string, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	var totalSum float64
+	for _, item := range r.t.orderItems[req.OrderId] {
+		totalSum += item.ListPrice * float64(item.Quantity)
+	}
+	if totalSum == 0.0 {
+		return "", pgx.ErrNoRows
+	}
+
+	if req.PromocodeName != "" {
+		var promocode models.Promocode
+		for _, id := range sortedIds(r.t.promocodes) {
+			if strings.EqualFold(r.t.promocodes[id].PromocodeName, req.PromocodeName) {
+				promocode = *r.t.promocodes[id]
+				break
+			}
+		}
+
+		if promocode.DiscountType == 1 && totalSum > promocode.OrderLimitPrice {
+			totalSum -= promocode.Discount
+		} else if promocode.DiscountType == 2 {
+			totalSum -= totalSum * promocode.Discount / 100
+		}
+	}
+
+	return fmt.Sprintf("%.2f", totalSum), nil
+}
+
+// StockReconciliation compares what every order holds with what the stock ledger says was taken
+// for it. Rejected and cancelled orders are expected to have given all of their stock back.
+func (r *reportRepo) StockReconciliation(ctx context.Context, req *models.StockReconciliationRequest) (*models.StockReconciliationResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	resp := &models.StockReconciliationResponse{}
+	resp.Drifts = []*models.StockReconciliation{}
+
+	type key struct {
+		orderId int
+		stockKey
+	}
+
+	drifts := map[key]*models.StockReconciliation{}
+	drift := func(k key) *models.StockReconciliation {
+		if _, ok := drifts[k]; !ok {
+			drifts[k] = &models.StockReconciliation{
+				OrderId:     k.orderId,
+				StoreId:     k.storeId,
+				ProductId:   k.productId,
+				OrderStatus: r.t.orders[k.orderId].orderStatus,
+			}
+		}
+		return drifts[k]
+	}
+
+	for id, order := range r.t.orders {
+		if order.orderStatus == models.OrderStatusRejected || order.orderStatus == models.OrderStatusCancelled {
+			continue
+		}
+		for _, item := range r.t.orderItems[id] {
+			drift(key{id, stockKey{order.storeId, item.ProductId}}).ItemQuantity += item.Quantity
+		}
+	}
+
+	for _, movement := range r.t.movements {
+		if _, ok := r.t.orders[movement.OrderId]; !ok {
+			continue
+		}
+		drift(key{movement.OrderId, stockKey{movement.StoreId, movement.ProductId}}).MovedQuantity -= movement.Quantity
+	}
+
+	var rows []*models.StockReconciliation
+	for _, row := range drifts {
+		if row.ItemQuantity == row.MovedQuantity || (req.StoreId > 0 && row.StoreId != req.StoreId) {
+			continue
+		}
+		row.Drift = row.ItemQuantity - row.MovedQuantity
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].OrderId != rows[j].OrderId {
+			return rows[i].OrderId < rows[j].OrderId
+		}
+		if rows[i].ProductId != rows[j].ProductId {
+			return rows[i].ProductId < rows[j].ProductId
+		}
+		return rows[i].StoreId < rows[j].StoreId
+	})
+
+	resp.Drifts = append(resp.Drifts, paginate(rows, req.Offset, req.Limit)...)
+	resp.Count = len(rows)
+	return resp, nil
+}
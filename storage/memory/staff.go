@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type staffRepo struct {
+	t *tables
+}
+
+func NewStaffRepo(t *tables) *staffRepo {
+	return &staffRepo{
+		t: t,
+	}
+}
+
+// checkStaff enforces the foreign keys and the unique email of staffs, staffId is the row
+// being updated or 0 on insert.
+func (t *tables) checkStaff(staffId int, email string, storeId, managerId int) error {
+	if _, ok := t.stores[storeId]; !ok {
+		return foreignKeyViolation("staffs", "staffs_store_id_fkey")
+	}
+	if _, ok := t.staffs[managerId]; managerId > 0 && !ok {
+		return foreignKeyViolation("staffs", "staffs_manager_id_fkey")
+	}
+	for _, staff := range t.staffs {
+		if staff.Email == email && staff.StaffId != staffId {
+			return uniqueViolation("staffs", "staffs_email_key")
+		}
+	}
+	return nil
+}
+
+// staff returns the staff member joined with the store.
+func (t *tables) staff(id int) (*models.Staff, bool) {
+	row, ok := t.staffs[id]
+	if !ok {
+		return nil, false
+	}
+
+	staff := *row
+	store := *t.stores[staff.StoreId]
+	staff.StoreData = &store
+
+	return &staff, true
+}
+
+// deleteStaff removes the staff member, the users bound to it are unbound.
+func (t *tables) deleteStaff(id int) {
+	for _, user := range t.users {
+		if user.StaffId == id {
+			user.StaffId = 0
+		}
+	}
+	delete(t.staffs, id)
+}
+
+func (r *staffRepo) Create(ctx context.Context, req *models.CreateStaff) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	err := r.t.checkStaff(0, req.Email, req.StoreId, req.ManagerId)
+	if err != nil {
+		return 0, err
+	}
+
+	id := r.t.next("staffs")
+	r.t.staffs[id] = &models.Staff{
+		StaffId:   id,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Active:    req.Active,
+		StoreId:   req.StoreId,
+		ManagerId: req.ManagerId,
+	}
+
+	return id, nil
+}
+
+func (r *staffRepo) GetById(ctx context.Context, req *models.StaffPrimaryKey) (*models.Staff, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	staff, ok := r.t.staff(req.StaffId)
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return staff, nil
+}
+
+func (r *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest) (*models.GetListStaffResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	staffs := &models.GetListStaffResponse{}
+
+	var rows []*models.Staff
+	for _, id := range sortedIds(r.t.staffs) {
+		staff, _ := r.t.staff(id)
+		if contains(req.Search, staff.FirstName+" "+staff.LastName) {
+			rows = append(rows, staff)
+		}
+	}
+
+	staffs.Staffs = append(staffs.Staffs, paginate(rows, req.Offset, req.Limit)...)
+	staffs.Count = len(rows)
+
+	return staffs, nil
+}
+
+func (r *staffRepo) Update(ctx context.Context, req *models.UpdateStaff) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	staff, ok := r.t.staffs[req.StaffId]
+	if !ok {
+		return 0, nil
+	}
+
+	err := r.t.checkStaff(req.StaffId, req.Email, req.StoreId, req.ManagerId)
+	if err != nil {
+		return 0, err
+	}
+
+	staff.FirstName = req.FirstName
+	staff.LastName = req.LastName
+	staff.Email = req.Email
+	staff.Phone = req.Phone
+	staff.Active = req.Active
+	staff.StoreId = req.StoreId
+	staff.ManagerId = req.ManagerId
+
+	return 1, nil
+}
+
+// Delete fails while the staff member has orders or manages someone.
+func (r *staffRepo) Delete(ctx context.Context, req *models.StaffPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.staffs[req.StaffId]; !ok {
+		return 0, nil
+	}
+
+	for _, order := range r.t.orders {
+		if order.staffId == req.StaffId {
+			return 0, foreignKeyViolation("orders", "orders_staff_id_fkey")
+		}
+	}
+	for _, staff := range r.t.staffs {
+		if staff.ManagerId == req.StaffId && staff.StaffId != req.StaffId {
+			return 0, foreignKeyViolation("staffs", "staffs_manager_id_fkey")
+		}
+	}
+
+	r.t.deleteStaff(req.StaffId)
+	return 1, nil
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+	"sort"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type stockRepo struct {
+	t *tables
+}
+
+func NewStockRepo(t *tables) *stockRepo {
+	return &stockRepo{
+		t: t,
+	}
+}
+
+// storeStock groups the stock rows of a store with their products, nil when it has none.
+func (t *tables) storeStock(storeId int) *models.GetStock {
+	var stock *models.GetStock
+
+	for key, quantity := range t.stocks {
+		if key.storeId != storeId {
+			continue
+		}
+		if stock == nil {
+			stock = &models.GetStock{StoreId: storeId, Products: []*models.ProductData{}}
+		}
+
+		product := t.products[key.productId]
+		stock.Quantity += quantity
+		stock.Products = append(stock.Products, &models.ProductData{
+			ProductId:   product.ProductId,
+			ProductName: product.ProductName,
+			BrandId:     product.BrandId,
+			CategoryId:  product.CategoryId,
+			ModelYear:   product.ModelYear,
+			ListPrice:   product.ListPrice,
+			Quantity:    quantity,
+		})
+	}
+
+	if stock != nil {
+		sort.Slice(stock.Products, func(i, j int) bool { return stock.Products[i].ProductId < stock.Products[j].ProductId })
+	}
+	return stock
+}
+
+func (r *stockRepo) Create(ctx context.Context, req *models.CreateStock) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.stores[req.StoreId]; !ok {
+		return 0, foreignKeyViolation("stocks", "stocks_store_id_fkey")
+	}
+	if _, ok := r.t.products[req.ProductId]; !ok {
+		return 0, foreignKeyViolation("stocks", "stocks_product_id_fkey")
+	}
+
+	key := stockKey{req.StoreId, req.ProductId}
+	if _, ok := r.t.stocks[key]; ok {
+		return 0, uniqueViolation("stocks", "stocks_pkey")
+	}
+	r.t.stocks[key] = req.Quantity
+
+	return req.StoreId, nil
+}
+
+func (r *stockRepo) GetById(ctx context.Context, req *models.StockPrimaryKey) (*models.GetStock, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	stock := r.t.storeStock(req.StoreId)
+	if stock == nil {
+		return nil, pgx.ErrNoRows
+	}
+
+	return stock, nil
+}
+
+func (r *stockRepo) GetList(ctx context.Context, req *models.GetListStockRequest) (*models.GetListStockResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	stocks := &models.GetListStockResponse{}
+	stocks.Stocks = []*models.GetStock{}
+
+	var rows []*models.GetStock
+	for _, storeId := range sortedIds(r.t.stores) {
+		if req.StoreId > 0 && storeId != req.StoreId {
+			continue
+		}
+		if stock := r.t.storeStock(storeId); stock != nil {
+			rows = append(rows, stock)
+		}
+	}
+
+	page, next, err := keyset(rows, func(s *models.GetStock) int { return s.StoreId }, req.Offset, req.Limit, req.After)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks.Stocks = append(stocks.Stocks, page...)
+	stocks.NextCursor = next
+	stocks.Count = len(rows)
+
+	return stocks, nil
+}
+
+// Export hands every store and product pair of req.StoreId, or of all stores, to fn ordered by
+// store and product, ignoring pagination.
+func (r *stockRepo) Export(ctx context.Context, req *models.GetListStockRequest, fn func(*models.Stock) error) error {
+	r.t.mu.Lock()
+	var rows []*models.Stock
+	for _, storeId := range sortedIds(r.t.stores) {
+		if req.StoreId > 0 && storeId != req.StoreId {
+			continue
+		}
+		for _, productId := range sortedIds(r.t.products) {
+			quantity, ok := r.t.stocks[stockKey{storeId, productId}]
+			if !ok {
+				continue
+			}
+
+			product, _ := r.t.product(productId)
+			rows = append(rows, &models.Stock{
+				StoreId:     storeId,
+				ProductId:   productId,
+				ProductData: product,
+				Quantity:    quantity,
+			})
+		}
+	}
+	r.t.mu.Unlock()
+
+	for _, row := range rows {
+		err := fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stockRepo) Update(ctx context.Context, req *models.UpdateStock) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	key := stockKey{req.StoreId, req.ProductId}
+	if _, ok := r.t.stocks[key]; !ok {
+		return 0, nil
+	}
+
+	r.t.stocks[key] = req.Quantity
+	return 1, nil
+}
+
+// Delete removes one product of the store, or every product when ProductId is 0.
+func (r *stockRepo) Delete(ctx context.Context, req *models.StockPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	var rowsAffected int64
+	for key := range r.t.stocks {
+		if key.storeId == req.StoreId && (req.ProductId <= 0 || key.productId == req.ProductId) {
+			delete(r.t.stocks, key)
+			rowsAffected++
+		}
+	}
+
+	return rowsAffected, nil
+}
+
+// Import sets the quantity of every store and product pair, checking all of them first.
+func (r *stockRepo) Import(ctx context.Context, req []*models.CreateStock) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	seen := map[stockKey]bool{}
+	for _, stock := range req {
+		if _, ok := r.t.stores[stock.StoreId]; !ok {
+			return 0, foreignKeyViolation("stocks", "stocks_store_id_fkey")
+		}
+		if _, ok := r.t.products[stock.ProductId]; !ok {
+			return 0, foreignKeyViolation("stocks", "stocks_product_id_fkey")
+		}
+
+		// postgres refuses to upsert the same row twice in one statement
+		key := stockKey{stock.StoreId, stock.ProductId}
+		if seen[key] {
+			return 0, cardinalityViolation()
+		}
+		seen[key] = true
+	}
+
+	for _, stock := range req {
+		r.t.stocks[stockKey{stock.StoreId, stock.ProductId}] = stock.Quantity
+	}
+
+	return int64(len(req)), nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"app/api/models"
+	"sort"
+	"time"
+)
+
+func (t *tables) insertStockMovement(req *models.StockMovement) {
+	movement := *req
+	movement.MovementId = t.next("stock_movements")
+	movement.CreatedAt = formatTimestamp(time.Now().UTC())
+
+	t.movements = append(t.movements, &movement)
+}
+
+// returnOrderItems puts the items of an order back into its store's stock and records why.
+// itemId limits it to a single item, 0 returns every item of the order.
+func (t *tables) returnOrderItems(orderId, itemId, storeId int, reason string) {
+	var items []*models.OrderItem
+	for _, item := range t.orderItems[orderId] {
+		if itemId == 0 || item.ItemId == itemId {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	for _, item := range items {
+		// the stock row may have been deleted since the item was added
+		t.stocks[stockKey{storeId, item.ProductId}] += item.Quantity
+
+		t.insertStockMovement(&models.StockMovement{
+			StoreId:   storeId,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			Reason:    reason,
+			OrderId:   orderId,
+			ItemId:    item.ItemId,
+		})
+	}
+}
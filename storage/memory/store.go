@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type storeRepo struct {
+	t *tables
+}
+
+func NewStoreRepo(t *tables) *storeRepo {
+	return &storeRepo{
+		t: t,
+	}
+}
+
+func (r *storeRepo) Create(ctx context.Context, req *models.CreateStore) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	id := r.t.next("stores")
+	r.t.stores[id] = &models.Store{
+		StoreId:   id,
+		StoreName: req.StoreName,
+		Phone:     req.Phone,
+		Email:     req.Email,
+		Street:    req.Street,
+		City:      req.City,
+		State:     req.State,
+		ZipCode:   req.ZipCode,
+	}
+
+	return id, nil
+}
+
+func (r *storeRepo) GetById(ctx context.Context, req *models.StorePrimaryKey) (*models.Store, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	store, ok := r.t.stores[req.StoreId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *store
+	return &resp, nil
+}
+
+func (r *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest) (*models.GetListStoreResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	stores := models.GetListStoreResponse{}
+
+	var rows []*models.Store
+	for _, id := range sortedIds(r.t.stores) {
+		store := *r.t.stores[id]
+		if contains(req.Search, store.StoreName) {
+			rows = append(rows, &store)
+		}
+	}
+
+	stores.Stores = append(stores.Stores, paginate(rows, req.Offset, req.Limit)...)
+	stores.Count = len(rows)
+
+	return &stores, nil
+}
+
+func (r *storeRepo) Update(ctx context.Context, req *models.UpdateStore) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	store, ok := r.t.stores[req.StoreId]
+	if !ok {
+		return 0, nil
+	}
+
+	store.StoreName = req.StoreName
+	store.Phone = req.Phone
+	store.Email = req.Email
+	store.Street = req.Street
+	store.City = req.City
+	store.State = req.State
+	store.ZipCode = req.ZipCode
+
+	return 1, nil
+}
+
+// Delete cascades to the staff, orders, stock, stock movements and purchase order items of the store. Staff that
+// still sold orders of another store or manage staff elsewhere block the delete.
+func (r *storeRepo) Delete(ctx context.Context, req *models.StorePrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.stores[req.StoreId]; !ok {
+		return 0, nil
+	}
+
+	for _, order := range r.t.orders {
+		staff, ok := r.t.staffs[order.staffId]
+		if ok && staff.StoreId == req.StoreId && order.storeId != req.StoreId {
+			return 0, foreignKeyViolation("orders", "orders_staff_id_fkey")
+		}
+	}
+	for _, staff := range r.t.staffs {
+		manager, ok := r.t.staffs[staff.ManagerId]
+		if ok && manager.StoreId == req.StoreId && staff.StoreId != req.StoreId {
+			return 0, foreignKeyViolation("staffs", "staffs_manager_id_fkey")
+		}
+	}
+
+	for _, id := range sortedIds(r.t.orders) {
+		if r.t.orders[id].storeId == req.StoreId {
+			r.t.deleteOrder(id)
+		}
+	}
+	for _, id := range sortedIds(r.t.staffs) {
+		if r.t.staffs[id].StoreId == req.StoreId {
+			r.t.deleteStaff(id)
+		}
+	}
+	for key := range r.t.stocks {
+		if key.storeId == req.StoreId {
+			delete(r.t.stocks, key)
+		}
+	}
+	r.t.deletePurchaseOrderItems(func(item *models.PurchaseOrderItem) bool { return item.StoreId == req.StoreId })
+
+	movements := r.t.movements[:0]
+	for _, movement := range r.t.movements {
+		if movement.StoreId != req.StoreId {
+			movements = append(movements, movement)
+		}
+	}
+	r.t.movements = movements
+
+	delete(r.t.stores, req.StoreId)
+	return 1, nil
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type supplierRepo struct {
+	t *tables
+}
+
+func NewSupplierRepo(t *tables) *supplierRepo {
+	return &supplierRepo{
+		t: t,
+	}
+}
+
+// checkSupplierEmail enforces the unique email of suppliers, id is the supplier being updated.
+func (t *tables) checkSupplierEmail(id int, email string) error {
+	for _, supplier := range t.suppliers {
+		if supplier.Email == email && supplier.SupplierId != id {
+			return uniqueViolation("suppliers", "suppliers_email_key")
+		}
+	}
+	return nil
+}
+
+func (r *supplierRepo) Create(ctx context.Context, req *models.CreateSupplier) (int, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	err := r.t.checkSupplierEmail(0, req.Email)
+	if err != nil {
+		return 0, err
+	}
+
+	id := r.t.next("suppliers")
+	r.t.suppliers[id] = &models.Supplier{
+		SupplierId:   id,
+		SupplierName: req.SupplierName,
+		ContactName:  req.ContactName,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		Street:       req.Street,
+		City:         req.City,
+		State:        req.State,
+		ZipCode:      req.ZipCode,
+	}
+
+	return id, nil
+}
+
+func (r *supplierRepo) GetById(ctx context.Context, req *models.SupplierPrimaryKey) (*models.Supplier, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	supplier, ok := r.t.suppliers[req.SupplierId]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	resp := *supplier
+	return &resp, nil
+}
+
+func (r *supplierRepo) GetList(ctx context.Context, req *models.GetListSupplierRequest) (*models.GetListSupplierResponse, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	suppliers := &models.GetListSupplierResponse{}
+	suppliers.Suppliers = []*models.Supplier{}
+
+	var rows []*models.Supplier
+	for _, id := range sortedIds(r.t.suppliers) {
+		supplier := *r.t.suppliers[id]
+		if contains(req.Search, supplier.SupplierName, supplier.ContactName, supplier.Email) {
+			rows = append(rows, &supplier)
+		}
+	}
+
+	suppliers.Suppliers = append(suppliers.Suppliers, paginate(rows, req.Offset, req.Limit)...)
+	suppliers.Count = len(rows)
+
+	return suppliers, nil
+}
+
+func (r *supplierRepo) Update(ctx context.Context, req *models.UpdateSupplier) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	supplier, ok := r.t.suppliers[req.SupplierId]
+	if !ok {
+		return 0, nil
+	}
+
+	err := r.t.checkSupplierEmail(req.SupplierId, req.Email)
+	if err != nil {
+		return 0, err
+	}
+
+	supplier.SupplierName = req.SupplierName
+	supplier.ContactName = req.ContactName
+	supplier.Phone = req.Phone
+	supplier.Email = req.Email
+	supplier.Street = req.Street
+	supplier.City = req.City
+	supplier.State = req.State
+	supplier.ZipCode = req.ZipCode
+
+	return 1, nil
+}
+
+// Delete fails with a foreign key violation while purchase orders of the supplier exist.
+func (r *supplierRepo) Delete(ctx context.Context, req *models.SupplierPrimaryKey) (int64, error) {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+
+	if _, ok := r.t.suppliers[req.SupplierId]; !ok {
+		return 0, nil
+	}
+
+	for _, purchaseOrder := range r.t.purchaseOrders {
+		if purchaseOrder.supplierId == req.SupplierId {
+			return 0, foreignKeyViolation("purchase_orders", "purchase_orders_supplier_id_fkey")
+		}
+	}
+
+	delete(r.t.suppliers, req.SupplierId)
+	return 1, nil
+}
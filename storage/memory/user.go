@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+var userRoles = map[string]bool{
+	models.RoleAdmin:        true,
+	models.RoleStoreManager: true,
+	models.RoleCashier:      true,
+	models.RoleReadOnly:     true,
+}
+
+type userRepo struct {
+	t *tables
+}
+
+func NewUserRepo(t *tables) *userRepo {
+	return &userRepo{
+		t: t,
+	}
+}
+
+// Create stores the user as given, as read_only unless a role is set; callers are expected to
+// hash the password first.
+func (u *userRepo) Create(ctx context.Context, req *models.CreateUser) (string, error) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+
+	for _, user := range u.t.users {
+		if user.Username == req.Username {
+			return "", uniqueViolation("users", "users_username_key")
+		}
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.RoleReadOnly
+	}
+
+	id := uuid.New().String()
+	u.t.users[id] = &models.User{
+		UserId:   id,
+		Username: req.Username,
+		Password: req.Password,
+		Role:     role,
+	}
+
+	return id, nil
+}
+
+// GetById looks the user up by user_id, or by username when no id is given.
+func (u *userRepo) GetById(ctx context.Context, req *models.UserPrimaryKey) (*models.User, error) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+
+	for _, row := range u.t.users {
+		if (req.UserId != "" && row.UserId != req.UserId) || (req.UserId == "" && row.Username != req.Username) {
+			continue
+		}
+
+		user := *row
+		if staff, ok := u.t.staffs[user.StaffId]; ok {
+			user.StoreId = staff.StoreId
+		}
+		return &user, nil
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+func (u *userRepo) UpdatePassword(ctx context.Context, req *models.UpdateUserPassword) (int64, error) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+
+	user, ok := u.t.users[req.UserId]
+	if !ok {
+		return 0, nil
+	}
+
+	user.Password = req.Password
+	return 1, nil
+}
+
+func (u *userRepo) UpdateRole(ctx context.Context, req *models.UpdateUserRole) (int64, error) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+
+	user, ok := u.t.users[req.UserId]
+	if !ok {
+		return 0, nil
+	}
+
+	if !userRoles[req.Role] {
+		return 0, checkViolation("users", "users_role_check")
+	}
+
+	user.Role = req.Role
+	return 1, nil
+}
+
+// UpdateStaff binds the user to a staff member, 0 unbinds it.
+func (u *userRepo) UpdateStaff(ctx context.Context, req *models.UpdateUserStaff) (int64, error) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+
+	user, ok := u.t.users[req.UserId]
+	if !ok {
+		return 0, nil
+	}
+
+	if req.StaffId > 0 {
+		if _, ok := u.t.staffs[req.StaffId]; !ok {
+			return 0, foreignKeyViolation("users", "users_staff_id_fkey")
+		}
+		for _, other := range u.t.users {
+			if other.UserId != user.UserId && other.StaffId == req.StaffId {
+				return 0, uniqueViolation("users", "users_staff_id_key")
+			}
+		}
+	}
+
+	user.StaffId = req.StaffId
+	return 1, nil
+}
@@ -3,7 +3,6 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -24,19 +23,12 @@ func (b *brandRepo) Create(ctx context.Context, req *models.CreateBrand) (int, e
 		id    int
 	)
 
-	err := b.db.QueryRow(ctx, `SELECT COALESCE(MAX(brand_id), 0) + 1 FROM brands`).Scan(
-		&id,
-	)
-	if err != nil {
-		return 0, err
-	}
-
 	query = `
-		INSERT INTO brands(brand_id, brand_name)
-		VALUES ($1, $2)
+		INSERT INTO brands(brand_name)
+		VALUES ($1) RETURNING brand_id
 	`
 
-	_, err = b.db.Exec(ctx, query, id, req.BrandName)
+	err := b.db.QueryRow(ctx, query, req.BrandName).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -69,33 +61,24 @@ func (b *brandRepo) GetById(ctx context.Context, req *models.BrandPrimaryKey) (*
 func (b *brandRepo) GetList(ctx context.Context, req *models.GetListBrandRequest) (*models.GetListBrandResponse, error) {
 	resp := models.GetListBrandResponse{}
 
-	var (
-		query string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT 
 			brand_id,
 			brand_name
 		FROM brands
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND brand_name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
-	}
+	q.Search(req.Search, "brand_name")
+	q.OrderBy("brand_id")
+	q.Paginate(req.Offset, req.Limit)
 
-	query += filter + offset + limit
+	count, err := countRows(ctx, b.db, q)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := b.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := b.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,11 +97,14 @@ func (b *brandRepo) GetList(ctx context.Context, req *models.GetListBrandRequest
 
 		resp.Brands = append(resp.Brands, &brand)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-	resp.Count = len(resp.Brands)
+	resp.Count = count
 
 	return &resp, nil
-} 
+}
 
 func (b *brandRepo) Update(ctx context.Context, req *models.UpdateBrand) (int64, error) {
 	query := `
@@ -144,4 +130,4 @@ func (b *brandRepo) Delete(ctx context.Context, req *models.BrandPrimaryKey) (in
 	}
 
 	return res.RowsAffected(), nil
-}
\ No newline at end of file
+}
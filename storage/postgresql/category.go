@@ -3,7 +3,6 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -26,19 +25,14 @@ func (c *categoryRepo) Create(ctx context.Context, req *models.CreateCategory) (
 
 	query = `
 		INSERT INTO categories(
-			category_id, 
-			category_name 
+			category_name
 		)
-		VALUES (
-			(
-				SELECT MAX(category_id) + 1 FROM categories
-			),
-			$1) RETURNING category_id
+		VALUES ($1) RETURNING category_id
 	`
 
 	err := c.db.QueryRow(ctx, query, req.CategoryName).Scan(&id)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
 	return id, nil
@@ -69,33 +63,24 @@ func (c *categoryRepo) GetById(ctx context.Context, req *models.CategoryPrimaryK
 func (c *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryRequest) (*models.GetListCategoryResponse, error) {
 	resp := models.GetListCategoryResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT 
 			category_id,
 			category_name
 		FROM categories
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND category_name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
-	}
+	q.Search(req.Search, "category_name")
+	q.OrderBy("category_id")
+	q.Paginate(req.Offset, req.Limit)
 
-	query += filter + offset + limit
+	count, err := countRows(ctx, c.db, q)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := c.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,8 +99,11 @@ func (c *categoryRepo) GetList(ctx context.Context, req *models.GetListCategoryR
 
 		resp.Categories = append(resp.Categories, &category)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-	resp.Count = len(resp.Categories)
+	resp.Count = count
 
 	return &resp, nil
 }
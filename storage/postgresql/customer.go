@@ -3,8 +3,8 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -24,14 +24,8 @@ func (c *customerRepo) Create(ctx context.Context, req *models.CreateCustomer) (
 		id    int
 	)
 
-	err := c.db.QueryRow(ctx, `SELECT MAX(customer_id) + 1 FROM customers`).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-
 	query = `
-		INSERT INTO stores (
-			customer_id,
+		INSERT INTO customers (
 			first_name,
 			last_name,
 			phone,
@@ -41,11 +35,11 @@ func (c *customerRepo) Create(ctx context.Context, req *models.CreateCustomer) (
 			state,
 			zip_code
 		)
-		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING customer_id
 	`
 
-	_, err = c.db.Exec(ctx, query,
-		id,
+	err := c.db.QueryRow(ctx, query,
 		req.FirstName,
 		req.LastName,
 		req.Phone,
@@ -54,7 +48,7 @@ func (c *customerRepo) Create(ctx context.Context, req *models.CreateCustomer) (
 		req.City,
 		req.State,
 		req.ZipCode,
-	)
+	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -103,14 +97,7 @@ func (c *customerRepo) GetById(ctx context.Context, req *models.CustomerPrimaryK
 func (c *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error) {
 	customers := models.GetListCustomerResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT
 			customer_id,
 			first_name,
@@ -122,21 +109,24 @@ func (c *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerR
 			COALESCE(state, ''),
 			COALESCE(zip_code, 0)
 		FROM customers
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND store_name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	q.Search(req.Search, "first_name || ' ' || last_name", "email")
+	q.OrderBy("customer_id")
+	q.Paginate(req.Offset, req.Limit)
+
+	count, err := countRows(ctx, c.db, q)
+	if err != nil {
+		return nil, err
 	}
 
-	query += filter + offset + limit
+	err = q.Keyset("customer_id", req.After)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := c.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +152,16 @@ func (c *customerRepo) GetList(ctx context.Context, req *models.GetListCustomerR
 
 		customers.Customers = append(customers.Customers, &customer)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if q.HasMore(len(customers.Customers)) {
+		customers.Customers = customers.Customers[:len(customers.Customers)-1]
+		customers.NextCursor = NextCursor(customers.Customers[len(customers.Customers)-1].CustomerId)
+	}
 
-	customers.Count = len(customers.Customers)
+	customers.Count = count
 
 	return &customers, nil
 }
@@ -210,3 +208,34 @@ func (c *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimaryKe
 
 	return res.RowsAffected(), nil
 }
+
+// Import copies the customers in one transaction, a failing row rolls back all of them.
+func (c *customerRepo) Import(ctx context.Context, req []*models.CreateCustomer) (int64, error) {
+	var rowsAffected int64
+
+	err := c.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var err error
+		rowsAffected, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"customers"},
+			[]string{"first_name", "last_name", "phone", "email", "street", "city", "state", "zip_code"},
+			pgx.CopyFromSlice(len(req), func(i int) ([]interface{}, error) {
+				return []interface{}{
+					req[i].FirstName,
+					req[i].LastName,
+					req[i].Phone,
+					req[i].Email,
+					req[i].Street,
+					req[i].City,
+					req[i].State,
+					req[i].ZipCode,
+				}, nil
+			}),
+		)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,402 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ErrSchemaBehind is returned by Check when the database misses migrations the binary ships with.
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// migrationLock is the advisory lock key held while migrations run, so two binaries starting
+// at the same time do not apply the same migration twice.
+const migrationLock = 7213500021
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version int
+	Name    string
+	// AppliedAt is empty while the migration is pending
+	AppliedAt string
+}
+
+// LoadMigrations reads <version>_<name>.up.sql and <version>_<name>.down.sql pairs from fsys
+// and returns them ordered by version.
+func LoadMigrations(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		match := migrationFile.FindStringSubmatch(file)
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: name must look like 01_name.up.sql", file)
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", file)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %s: version %d is already named %s", file, version, migration.Name)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator applies the migrations and keeps track of them in the schema_versions table,
+// one row per applied version.
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []*Migration
+}
+
+func NewMigrator(db *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Latest returns the version of the newest migration the binary ships with.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the highest applied version, 0 for an empty database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := appliedVersions(ctx, m.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+
+	return version, nil
+}
+
+// Status lists every known migration and every applied one the binary does not know about.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	applied, err := appliedVersions(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var status []*MigrationStatus
+	for _, migration := range m.migrations {
+		status = append(status, &MigrationStatus{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: applied[migration.Version],
+		})
+		delete(applied, migration.Version)
+	}
+	for version, appliedAt := range applied {
+		status = append(status, &MigrationStatus{Version: version, Name: "unknown", AppliedAt: appliedAt})
+	}
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+
+	return status, nil
+}
+
+// Check returns ErrSchemaBehind when a migration is still pending. A database ahead of the
+// binary is fine, the newer migrations are expected to stay compatible with the older code.
+func (m *Migrator) Check(ctx context.Context) error {
+	applied, err := appliedVersions(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	var pending int
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		return fmt.Errorf("%w: %d of %d migrations pending, run `migrate up`", ErrSchemaBehind, pending, len(m.migrations))
+	}
+
+	return nil
+}
+
+// Up applies up to steps pending migrations in version order, all of them when steps <= 0.
+// Each migration runs in its own transaction together with its schema_versions row.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && len(done) == steps {
+				break
+			}
+
+			err = conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx,
+					`INSERT INTO schema_versions(version, name) VALUES ($1, $2)`,
+					migration.Version,
+					migration.Name,
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts up to steps applied migrations, newest first, all of them when steps <= 0.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		known := map[int]*Migration{}
+		for _, migration := range m.migrations {
+			known[migration.Version] = migration
+		}
+
+		versions := make([]int, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+		for _, version := range versions {
+			if steps > 0 && len(done) == steps {
+				break
+			}
+
+			migration, ok := known[version]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but unknown to this binary, revert it with the binary that applied it", version)
+			}
+
+			err = conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx, `DELETE FROM schema_versions WHERE version = $1`, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// locked runs fn on a single connection holding the migration lock, after making sure the
+// schema_versions table exists.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLock)
+	if err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLock)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_versions (
+			version INT PRIMARY KEY,
+			name VARCHAR NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	err = m.adoptMigrateVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// adoptMigrateVersion records the migrations a database got from the migrate CLI, which kept
+// only its current version in schema_migrations, the first time the binary migrates it.
+func (m *Migrator) adoptMigrateVersion(ctx context.Context, conn *pgxpool.Conn) error {
+	var exists bool
+	err := conn.QueryRow(ctx, `
+		SELECT
+			to_regclass('schema_migrations') IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM schema_versions)
+	`).Scan(&exists)
+	if err != nil || !exists {
+		return err
+	}
+
+	var (
+		version int
+		dirty   bool
+	)
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("schema_migrations is dirty at version %d, fix the schema by hand first", version)
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+
+		_, err = conn.Exec(ctx,
+			`INSERT INTO schema_versions(version, name) VALUES ($1, $2)`,
+			migration.Version,
+			migration.Name,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// querier is a pool or a single connection.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// appliedVersions returns the applied_at of every applied version. A database without the
+// schema_versions table has nothing applied.
+func appliedVersions(ctx context.Context, db querier) (map[int]string, error) {
+	applied := map[int]string{}
+
+	var exists bool
+	err := db.QueryRow(ctx, `SELECT to_regclass('schema_versions') IS NOT NULL`).Scan(&exists)
+	if err != nil || !exists {
+		return applied, err
+	}
+
+	rows, err := db.Query(ctx, `SELECT version, CAST(applied_at AS VARCHAR) FROM schema_versions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			version   int
+			appliedAt string
+		)
+
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
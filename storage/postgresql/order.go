@@ -3,10 +3,14 @@ package postgresql
 import (
 	"app/api/models"
 	"app/pkg/helper"
+	"app/storage"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -21,33 +25,93 @@ func NewOrderRepo(db *pgxpool.Pool) *orderRepo {
 }
 
 func (r *orderRepo) Create(ctx context.Context, req *models.CreateOrder) (int, error) {
-	var (
-		query string
-		id    int
-	)
+	var id int
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		id, err = insertOrder(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Place creates the order with all of its items in one transaction, taking the items out of
+// the store's stock. Any missing product or short stock rolls the whole order back.
+func (r *orderRepo) Place(ctx context.Context, req *models.PlaceOrder) (int, error) {
+	var id int
+
+	// lock stock rows in a fixed order so concurrent orders cannot deadlock each other
+	items := make([]*models.PlaceOrderItem, len(req.Items))
+	copy(items, req.Items)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) (err error) {
+		id, err = insertOrder(ctx, tx, &models.CreateOrder{
+			CustomerId:   req.CustomerId,
+			RequiredDate: req.RequiredDate,
+			StoreId:      req.StoreId,
+			StaffId:      req.StaffId,
+			CreatedBy:    req.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			var listPrice float64
+
+			err = tx.QueryRow(ctx,
+				`SELECT list_price FROM products WHERE product_id = $1`,
+				item.ProductId,
+			).Scan(&listPrice)
+			if err != nil {
+				return err
+			}
+
+			err = insertOrderItem(ctx, tx, req.StoreId, &models.CreateOrderItem{
+				OrderId:   id,
+				ProductId: item.ProductId,
+				Quantity:  item.Quantity,
+				ListPrice: listPrice,
+				Discount:  item.Discount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func insertOrder(ctx context.Context, tx pgx.Tx, req *models.CreateOrder) (int, error) {
+	var id int
 
-	query = `
+	query := `
 		INSERT INTO orders(
-			order_id,
 			customer_id,
 			order_status,
 			order_date,
 			required_date,
-			shipped_date,
 			store_id,
 			staff_id
 		)
-		VALUES (
-			(SELECT MAX(order_id) + 1 FROM orders),
-			$1, $2, NOW()::DATE, $3, $4, $5, $6) RETURNING order_id
-		)
+		VALUES ($1, $2, NOW()::DATE, $3, $4, $5)
+		RETURNING order_id
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := tx.QueryRow(ctx, query,
 		helper.NewNullInt(int64(req.CustomerId)),
-		req.OrderStatus,
+		models.OrderStatusPending,
 		req.RequiredDate,
-		req.ShippedDate,
 		req.StoreId,
 		req.StaffId,
 	).Scan(&id)
@@ -55,6 +119,11 @@ func (r *orderRepo) Create(ctx context.Context, req *models.CreateOrder) (int, e
 		return 0, err
 	}
 
+	err = insertOrderStatusHistory(ctx, tx, id, 0, models.OrderStatusPending, req.CreatedBy, "")
+	if err != nil {
+		return 0, err
+	}
+
 	return id, nil
 }
 
@@ -122,13 +191,13 @@ func (r *orderRepo) GetById(ctx context.Context, req *models.OrderPrimaryKey) (*
 			st.store_id,
 			COALESCE(st.manager_id, 0),
 		
-			oi.order_items
+			COALESCE(oi.order_items, '[]')
 		
 		FROM orders AS o
 		JOIN customers AS c ON c.customer_id = o.customer_id
 		JOIN stores AS s ON s.store_id = o.store_id
 		JOIN staffs AS st ON st.staff_id = o.staff_id
-		JOIN order_item_data AS oi ON oi.order_id = o.order_id
+		LEFT JOIN order_item_data AS oi ON oi.order_id = o.order_id
 		WHERE o.order_id = $1
 	`
 
@@ -183,17 +252,86 @@ func (r *orderRepo) GetById(ctx context.Context, req *models.OrderPrimaryKey) (*
 	return &order, nil
 }
 
+var orderSortColumns = map[string]string{
+	"order_id":      "o.order_id",
+	"order_date":    "o.order_date",
+	"required_date": "o.required_date",
+	"shipped_date":  "o.shipped_date",
+	"order_status":  "o.order_status",
+	"customer_name": "c.last_name || ' ' || c.first_name",
+}
+
 func (r *orderRepo) GetList(ctx context.Context, req *models.GetListOrderRequest) (*models.GetListOrderResponse, error) {
 	orders := &models.GetListOrderResponse{}
 	orders.Orders = []*models.Order{}
 
-	var (
-		query  string
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
+	q, err := orderListQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	q.Paginate(req.Offset, req.Limit)
+
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	// keyset pages only follow the default order by id
+	if len(req.Sort) == 0 {
+		err = q.Keyset("o.order_id", req.After)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(req.After) > 0 {
+		return nil, fmt.Errorf("%w: after cannot be combined with sort", storage.ErrInvalidCursor)
+	}
+
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders.Orders = append(orders.Orders, order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if q.HasMore(len(orders.Orders)) {
+		orders.Orders = orders.Orders[:len(orders.Orders)-1]
+		orders.NextCursor = NextCursor(orders.Orders[len(orders.Orders)-1].OrderId)
+	}
+
+	orders.Count = count
+	return orders, nil
+}
 
-	query = `
+// Export hands every order matching the filters and sort of req to fn, ignoring pagination.
+func (r *orderRepo) Export(ctx context.Context, req *models.GetListOrderRequest, fn func(*models.Order) error) error {
+	q, err := orderListQuery(req)
+	if err != nil {
+		return err
+	}
+
+	return exportRows(ctx, r.db, q, func(rows pgx.Rows) error {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return err
+		}
+		return fn(order)
+	})
+}
+
+func orderListQuery(req *models.GetListOrderRequest) (*ListQuery, error) {
+	q := NewListQuery(`
 		WITH order_item_data AS (
 			SELECT
 				oi.order_id AS order_id,
@@ -250,143 +388,262 @@ func (r *orderRepo) GetList(ctx context.Context, req *models.GetListOrderRequest
 			st.store_id,
 			COALESCE(st.manager_id, 0),
 		
-			oi.order_items
+			COALESCE(oi.order_items, '[]')
 		
 		FROM orders AS o
 		JOIN customers AS c ON c.customer_id = o.customer_id
 		JOIN stores AS s ON s.store_id = o.store_id
 		JOIN staffs AS st ON st.staff_id = o.staff_id
-		JOIN order_item_data AS oi ON oi.order_id = o.order_id
-	`
+		LEFT JOIN order_item_data AS oi ON oi.order_id = o.order_id
+	`)
 
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	q.Search(req.Search, "c.first_name || ' ' || c.last_name", "c.email")
+	if req.CustomerId > 0 {
+		q.Where("o.customer_id = ?", req.CustomerId)
+	}
+	if req.StoreId > 0 {
+		q.Where("o.store_id = ?", req.StoreId)
+	}
+	if req.StaffId > 0 {
+		q.Where("o.staff_id = ?", req.StaffId)
+	}
+	if req.OrderStatus > 0 {
+		q.Where("o.order_status = ?", req.OrderStatus)
+	}
+	if len(req.OrderDateFrom) > 0 {
+		q.Where("o.order_date >= ?::DATE", req.OrderDateFrom)
+	}
+	if len(req.OrderDateTo) > 0 {
+		q.Where("o.order_date <= ?::DATE", req.OrderDateTo)
+	}
+	if len(req.RequiredDateFrom) > 0 {
+		q.Where("o.required_date >= ?::DATE", req.RequiredDateFrom)
+	}
+	if len(req.RequiredDateTo) > 0 {
+		q.Where("o.required_date <= ?::DATE", req.RequiredDateTo)
 	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	if req.Late {
+		q.Where("o.shipped_date > o.required_date")
 	}
-	query += offset + limit
 
-	rows, err := r.db.Query(ctx, query)
+	err := q.Sort(req.Sort, orderSortColumns)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	q.OrderBy("o.order_id")
 
-	for rows.Next() {
-		var order models.Order
-		order.CustomerData = &models.Customer{}
-		order.StoreData = &models.Store{}
-		order.StaffData = &models.Staff{}
-		var order_items pgtype.JSONB
+	return q, nil
+}
 
-		err = rows.Scan(
-			&order.OrderId,
-
-			&order.CustomerId,
-			&order.CustomerData.CustomerId,
-			&order.CustomerData.FirstName,
-			&order.CustomerData.LastName,
-			&order.CustomerData.Phone,
-			&order.CustomerData.Email,
-			&order.CustomerData.Street,
-			&order.CustomerData.City,
-			&order.CustomerData.State,
-			&order.CustomerData.ZipCode,
-			
-			&order.OrderStatus,
-			&order.OrderDate,
-			&order.RequiredDate,
-			&order.ShippedDate,
+func scanOrder(row pgx.Row) (*models.Order, error) {
+	var order models.Order
+	order.CustomerData = &models.Customer{}
+	order.StoreData = &models.Store{}
+	order.StaffData = &models.Staff{}
+	var order_items pgtype.JSONB
 
-			&order.StoreId,
-		
-			&order.StoreData.StoreId,
-			&order.StoreData.StoreName,
-			&order.StoreData.Phone,
-			&order.StoreData.Email,
-			&order.StoreData.Street,
-			&order.StoreData.City,
-			&order.StoreData.State,
-			&order.StoreData.ZipCode,
-		
-			&order.StaffId,
-			&order.StaffData.StaffId,
-			&order.StaffData.FirstName,
-			&order.StaffData.LastName,
-			&order.StaffData.Email,
-			&order.StaffData.Phone,
-			&order.StaffData.Active,
-			&order.StaffData.StoreId,
-			&order.StaffData.ManagerId,
-			
-			&order_items,
-		)
-		if err != nil {
-			return nil, err
-		}
-		order_items.AssignTo(&order.OrderItems)
+	err := row.Scan(
+		&order.OrderId,
+
+		&order.CustomerId,
+		&order.CustomerData.CustomerId,
+		&order.CustomerData.FirstName,
+		&order.CustomerData.LastName,
+		&order.CustomerData.Phone,
+		&order.CustomerData.Email,
+		&order.CustomerData.Street,
+		&order.CustomerData.City,
+		&order.CustomerData.State,
+		&order.CustomerData.ZipCode,
+
+		&order.OrderStatus,
+		&order.OrderDate,
+		&order.RequiredDate,
+		&order.ShippedDate,
+
+		&order.StoreId,
+
+		&order.StoreData.StoreId,
+		&order.StoreData.StoreName,
+		&order.StoreData.Phone,
+		&order.StoreData.Email,
+		&order.StoreData.Street,
+		&order.StoreData.City,
+		&order.StoreData.State,
+		&order.StoreData.ZipCode,
 
-		orders.Orders = append(orders.Orders, &order)
+		&order.StaffId,
+		&order.StaffData.StaffId,
+		&order.StaffData.FirstName,
+		&order.StaffData.LastName,
+		&order.StaffData.Email,
+		&order.StaffData.Phone,
+		&order.StaffData.Active,
+		&order.StaffData.StoreId,
+		&order.StaffData.ManagerId,
+
+		&order_items,
+	)
+	if err != nil {
+		return nil, err
 	}
+	order_items.AssignTo(&order.OrderItems)
 
-	orders.Count = len(orders.Orders)
-	return orders, nil
+	return &order, nil
 }
 
+// Update locks the order first, an order that still holds stock keeps its store because its
+// items are returned to the store the order points at.
 func (r *orderRepo) Update(ctx context.Context, req *models.UpdateOrder) (int64, error) {
-	var (
-		query  string
-		params map[string]interface{}
-	)
+	var rowsAffected int64
 
-	query = `
-		UPDATE 
-			orders
-		SET
-			customer_id = :customer_id,
-			order_status = :order_status,
-			required_date = :required_date,
-			shipped_date = :shipped_date,
-			store_id = :store_id,
-			staff_id = :staff_id
-		WHERE order_id = :order_id
-	`
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		storeId, status, err := lockOrder(ctx, tx, req.OrderId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
 
-	params = map[string]interface{}{
-		"order_id":      req.OrderId,
-		"customer_id":   req.CustomerId,
-		"order_status":  req.OrderStatus,
-		"required_date": req.RequiredDate,
-		"shipped_date":  helper.NewNullString(req.ShippedDate),
-		"store_id":      req.StoreId,
-		"staff_id":      req.StaffId,
-	}
+		if req.StoreId != storeId && models.OrderStatusHoldsStock(status) {
+			return fmt.Errorf("%w: order is %s, its store can not change", storage.ErrOrderClosed, models.OrderStatusName(status))
+		}
+
+		query := `
+			UPDATE 
+				orders
+			SET
+				customer_id = :customer_id,
+				required_date = :required_date,
+				store_id = :store_id,
+				staff_id = :staff_id
+			WHERE order_id = :order_id
+		`
+
+		params := map[string]interface{}{
+			"order_id":      req.OrderId,
+			"customer_id":   req.CustomerId,
+			"required_date": req.RequiredDate,
+			"store_id":      req.StoreId,
+			"staff_id":      req.StaffId,
+		}
 
-	query, args := helper.ReplaceQueryParams(query, params)
-	fmt.Println(query)
+		query, args := helper.ReplaceQueryParams(query, params)
 
-	res, err := r.db.Exec(ctx, query, args...)
+		res, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = res.RowsAffected()
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	return res.RowsAffected(), nil
+	return rowsAffected, nil
 }
 
+// Delete returns the items of an order that still holds stock before deleting it.
 func (r *orderRepo) Delete(ctx context.Context, req *models.OrderPrimaryKey) (int64, error) {
-	res, err := r.db.Exec(ctx, `DELETE FROM orders WHERE order_id = $1`, req.OrderId)
+	var rowsAffected int64
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		storeId, status, err := lockOrder(ctx, tx, req.OrderId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if models.OrderStatusHoldsStock(status) {
+			err = returnOrderItems(ctx, tx, req.OrderId, 0, storeId, models.StockMovementOrderDeleted)
+			if err != nil {
+				return err
+			}
+		}
+
+		res, err := tx.Exec(ctx, `DELETE FROM orders WHERE order_id = $1`, req.OrderId)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = res.RowsAffected()
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	return res.RowsAffected(), nil
+	return rowsAffected, nil
+}
+
+// lockOrder locks the order row for the rest of the transaction and returns its store and status.
+func lockOrder(ctx context.Context, tx pgx.Tx, orderId int) (storeId int, status int16, err error) {
+	err = tx.QueryRow(ctx,
+		`SELECT store_id, order_status FROM orders WHERE order_id = $1 FOR UPDATE`,
+		orderId,
+	).Scan(&storeId, &status)
+	return storeId, status, err
 }
 
 // Order Item
 
+// AddOrderItem takes the quantity out of the order's store stock and adds the item in one transaction.
 func (r *orderRepo) AddOrderItem(ctx context.Context, req *models.CreateOrderItem) error {
 
+	return r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		storeId, status, err := lockOrder(ctx, tx, req.OrderId)
+		if err != nil {
+			return err
+		}
+
+		if !models.OrderStatusHoldsStock(status) {
+			return fmt.Errorf("%w: order is %s", storage.ErrOrderClosed, models.OrderStatusName(status))
+		}
+
+		return insertOrderItem(ctx, tx, storeId, req)
+	})
+}
+
+// insertOrderItem locks the stock row, decrements it and inserts the item under the next item id.
+// The caller must hold the order row lock, item ids are numbered per order.
+func insertOrderItem(ctx context.Context, tx pgx.Tx, storeId int, req *models.CreateOrderItem) error {
+	var quantity int
+
+	if req.Quantity <= 0 {
+		return storage.ErrInvalidQuantity
+	}
+
+	err := tx.QueryRow(ctx,
+		`SELECT quantity FROM stocks WHERE store_id = $1 AND product_id = $2 FOR UPDATE`,
+		storeId,
+		req.ProductId,
+	).Scan(&quantity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: product %d is not stocked in store %d", storage.ErrNotEnoughStock, req.ProductId, storeId)
+	}
+	if err != nil {
+		return err
+	}
+
+	if quantity < req.Quantity {
+		return fmt.Errorf("%w: product %d has %d left", storage.ErrNotEnoughStock, req.ProductId, quantity)
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE stocks SET quantity = quantity - $1 WHERE store_id = $2 AND product_id = $3`,
+		req.Quantity,
+		storeId,
+		req.ProductId,
+	)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO order_items(
 			order_id, 
@@ -400,32 +657,193 @@ func (r *orderRepo) AddOrderItem(ctx context.Context, req *models.CreateOrderIte
 			$1, 
 			( SELECT COALESCE(MAX(item_id), 0) + 1 FROM order_items WHERE order_id = $1), 
 			$2, $3, $4, $5
-		)
+		) RETURNING item_id
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	var itemId int
+	err = tx.QueryRow(ctx, query,
 		req.OrderId,
 		req.ProductId,
 		req.Quantity,
 		req.ListPrice,
 		req.Discount,
-	)
+	).Scan(&itemId)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return insertStockMovement(ctx, tx, &models.StockMovement{
+		StoreId:   storeId,
+		ProductId: req.ProductId,
+		Quantity:  -req.Quantity,
+		Reason:    models.StockMovementOrderItem,
+		OrderId:   req.OrderId,
+		ItemId:    itemId,
+	})
 }
 
+// RemoveOrderItem returns the item to stock and deletes it. Only orders that still hold their
+// stock can lose items.
 func (r *orderRepo) RemoveOrderItem(ctx context.Context, req *models.OrderItemPrimaryKey) (int64, error) {
+	var rowsAffected int64
+
 	query := `
 		DELETE FROM order_items
 		WHERE order_id = $1 AND item_id = $2
 	`
 
-	res, err := r.db.Exec(ctx, query, req.OrderId, req.ItemId)
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		storeId, status, err := lockOrder(ctx, tx, req.OrderId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !models.OrderStatusHoldsStock(status) {
+			return fmt.Errorf("%w: order is %s", storage.ErrOrderClosed, models.OrderStatusName(status))
+		}
+
+		err = returnOrderItems(ctx, tx, req.OrderId, req.ItemId, storeId, models.StockMovementItemRemoved)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.Exec(ctx, query, req.OrderId, req.ItemId)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = res.RowsAffected()
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	return res.RowsAffected(), nil
+	return rowsAffected, nil
+}
+
+// Order Status
+
+func (r *orderRepo) ChangeStatus(ctx context.Context, req *models.ChangeOrderStatus) error {
+
+	return r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		storeId, current, err := lockOrder(ctx, tx, req.OrderId)
+		if err != nil {
+			return err
+		}
+
+		if !models.CanChangeOrderStatus(current, req.Status) {
+			return fmt.Errorf("%w: %s -> %s", storage.ErrInvalidStatusTransition,
+				models.OrderStatusName(current), models.OrderStatusName(req.Status))
+		}
+
+		query := `
+			UPDATE
+				orders
+			SET
+				order_status = $2,
+				shipped_date = CASE WHEN $2 = $3 THEN NOW()::DATE ELSE shipped_date END
+			WHERE order_id = $1
+		`
+
+		_, err = tx.Exec(ctx, query, req.OrderId, req.Status, models.OrderStatusShipped)
+		if err != nil {
+			return err
+		}
+
+		if models.OrderStatusHoldsStock(current) && !models.OrderStatusHoldsStock(req.Status) {
+			switch req.Status {
+			case models.OrderStatusCancelled:
+				err = returnOrderItems(ctx, tx, req.OrderId, 0, storeId, models.StockMovementOrderCancelled)
+			case models.OrderStatusRejected:
+				err = returnOrderItems(ctx, tx, req.OrderId, 0, storeId, models.StockMovementOrderRejected)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return insertOrderStatusHistory(ctx, tx, req.OrderId, current, req.Status, req.ChangedBy, req.Comment)
+	})
+}
+
+func (r *orderRepo) GetStatusHistory(ctx context.Context, req *models.OrderPrimaryKey) (*models.GetOrderStatusHistoryResponse, error) {
+	resp := &models.GetOrderStatusHistoryResponse{}
+	resp.History = []*models.OrderStatusHistory{}
+
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE order_id = $1)`, req.OrderId).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	query := `
+		SELECT
+			history_id,
+			order_id,
+			COALESCE(from_status, 0),
+			to_status,
+			COALESCE(changed_by, ''),
+			comment,
+			CAST(changed_at AS VARCHAR)
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at, history_id
+	`
+
+	rows, err := r.db.Query(ctx, query, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var history models.OrderStatusHistory
+
+		err = rows.Scan(
+			&history.HistoryId,
+			&history.OrderId,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.ChangedBy,
+			&history.Comment,
+			&history.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.History = append(resp.History, &history)
+	}
+
+	resp.Count = len(resp.History)
+	return resp, rows.Err()
+}
+
+func insertOrderStatusHistory(ctx context.Context, tx pgx.Tx, orderId int, from, to int16, changedBy, comment string) error {
+	query := `
+		INSERT INTO order_status_history(
+			order_id,
+			from_status,
+			to_status,
+			changed_by,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		orderId,
+		helper.NewNullInt(int64(from)),
+		to,
+		helper.NewNullString(changedBy),
+		comment,
+	)
+	return err
 }
@@ -22,9 +22,22 @@ type Store struct {
 	promocode storage.PromocodeRepoI
 	report    storage.ReportRepoI
 	user      storage.UserRepoI
+	refresh   storage.RefreshTokenRepoI
+	supplier  storage.SupplierRepoI
+	purchase  storage.PurchaseOrderRepoI
 }
 
 func NewConnectPostgresql(cfg *config.Config) (storage.StorageI, error) {
+	pgpool, err := NewPool(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(pgpool), nil
+}
+
+// NewPool connects to the database from the config.
+func NewPool(cfg *config.Config) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(fmt.Sprintf(
 		"host=%s user=%s dbname=%s password=%s port=%s sslmode=disable",
 		cfg.PostgresHost,
@@ -43,9 +56,15 @@ func NewConnectPostgresql(cfg *config.Config) (storage.StorageI, error) {
 	}
 
 	if err := pgpool.Ping(context.Background()); err != nil {
+		pgpool.Close()
 		return nil, err
 	}
 
+	return pgpool, nil
+}
+
+// NewStore builds the storage on an open pool, CloseDB closes the pool.
+func NewStore(pgpool *pgxpool.Pool) *Store {
 	return &Store{
 		db:        pgpool,
 		category:  NewCategoryRepo(pgpool),
@@ -59,7 +78,10 @@ func NewConnectPostgresql(cfg *config.Config) (storage.StorageI, error) {
 		promocode: NewPromocodeRepo(pgpool),
 		report:    NewReportRepo(pgpool),
 		user:      NewUserRepo(pgpool),
-	}, nil
+		refresh:   NewRefreshTokenRepo(pgpool),
+		supplier:  NewSupplierRepo(pgpool),
+		purchase:  NewPurchaseOrderRepo(pgpool),
+	}
 }
 
 func (s *Store) CloseDB() {
@@ -142,3 +164,24 @@ func (s *Store) User() storage.UserRepoI {
 	}
 	return s.user
 }
+
+func (s *Store) RefreshToken() storage.RefreshTokenRepoI {
+	if s.refresh == nil {
+		s.refresh = NewRefreshTokenRepo(s.db)
+	}
+	return s.refresh
+}
+
+func (s *Store) Supplier() storage.SupplierRepoI {
+	if s.supplier == nil {
+		s.supplier = NewSupplierRepo(s.db)
+	}
+	return s.supplier
+}
+
+func (s *Store) PurchaseOrder() storage.PurchaseOrderRepoI {
+	if s.purchase == nil {
+		s.purchase = NewPurchaseOrderRepo(s.db)
+	}
+	return s.purchase
+}
@@ -3,9 +3,11 @@ package postgresql
 import (
 	"app/api/models"
 	"app/pkg/helper"
+	"app/storage"
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -25,25 +27,19 @@ func (p *productRepo) Create(ctx context.Context, req *models.CreateProduct) (in
 		id    int
 	)
 
-	err := p.db.QueryRow(ctx, `SELECT MAX(product_id) + 1 FROM products`).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-
 	query = `
 		INSERT INTO products(
-			product_id,
 			product_name,
 			brand_id,
 			category_id,
 			model_year,
 			list_price
 		)
-		VALUES(:product_id, :product_name, :brand_id, :category_id, :model_year, :list_price)
+		VALUES(:product_name, :brand_id, :category_id, :model_year, :list_price)
+		RETURNING product_id
 	`
 
 	params := map[string]interface{}{
-		"product_id":   id,
 		"product_name": req.ProductName,
 		"brand_id":     req.BrandId,
 		"category_id":  req.CategoryId,
@@ -53,7 +49,7 @@ func (p *productRepo) Create(ctx context.Context, req *models.CreateProduct) (in
 
 	query, args := helper.ReplaceQueryParams(query, params)
 
-	_, err = p.db.Exec(ctx, query, args...)
+	err := p.db.QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -103,17 +99,86 @@ func (p *productRepo) GetById(ctx context.Context, req *models.ProductPrimaryKey
 	return &product, nil
 }
 
+var productSortColumns = map[string]string{
+	"product_id":    "product_id",
+	"product_name":  "product_name",
+	"brand_name":    "brand_name",
+	"category_name": "category_name",
+	"model_year":    "model_year",
+	"list_price":    "list_price",
+}
+
 func (p *productRepo) GetList(ctx context.Context, req *models.GetListProductRequest) (*models.GetListProductResponse, error) {
 	resp := models.GetListProductResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
+	q, err := productListQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	q.Paginate(req.Offset, req.Limit)
 
-	query = `
+	count, err := countRows(ctx, p.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	// keyset pages only follow the default order by id
+	if len(req.Sort) == 0 {
+		err = q.Keyset("product_id", req.After)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(req.After) > 0 {
+		return nil, fmt.Errorf("%w: after cannot be combined with sort", storage.ErrInvalidCursor)
+	}
+
+	query, args := q.Build()
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Products = append(resp.Products, product)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if q.HasMore(len(resp.Products)) {
+		resp.Products = resp.Products[:len(resp.Products)-1]
+		resp.NextCursor = NextCursor(resp.Products[len(resp.Products)-1].ProductId)
+	}
+
+	resp.Count = count
+
+	return &resp, nil
+}
+
+// Export hands every product matching the filters and sort of req to fn, ignoring pagination.
+func (p *productRepo) Export(ctx context.Context, req *models.GetListProductRequest, fn func(*models.Product) error) error {
+	q, err := productListQuery(req)
+	if err != nil {
+		return err
+	}
+
+	return exportRows(ctx, p.db, q, func(rows pgx.Rows) error {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return err
+		}
+		return fn(product)
+	})
+}
+
+func productListQuery(req *models.GetListProductRequest) (*ListQuery, error) {
+	q := NewListQuery(`
 		SELECT
 			product_id,
 			product_name, 
@@ -128,53 +193,66 @@ func (p *productRepo) GetList(ctx context.Context, req *models.GetListProductReq
 		FROM products
 		JOIN categories USING(category_id)
 		JOIN brands USING(brand_id)
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND product_name ILIKE '%' || '" + req.Search + "' || '%' "
+	q.Search(req.Search, "product_name")
+	if req.BrandId > 0 {
+		q.Where("brand_id = ?", req.BrandId)
 	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	if req.CategoryId > 0 {
+		q.Where("category_id = ?", req.CategoryId)
 	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	if req.ModelYearFrom > 0 {
+		q.Where("model_year >= ?", req.ModelYearFrom)
+	}
+	if req.ModelYearTo > 0 {
+		q.Where("model_year <= ?", req.ModelYearTo)
+	}
+	if req.PriceFrom > 0 {
+		q.Where("list_price >= ?", req.PriceFrom)
+	}
+	if req.PriceTo > 0 {
+		q.Where("list_price <= ?", req.PriceTo)
+	}
+	if req.InStockStoreId > 0 {
+		q.Where(`EXISTS (
+			SELECT 1 FROM stocks AS s
+			WHERE s.product_id = products.product_id AND s.store_id = ? AND s.quantity > 0
+		)`, req.InStockStoreId)
 	}
 
-	query += filter + offset + limit
-
-	rows, err := p.db.Query(ctx, query)
+	err := q.Sort(req.Sort, productSortColumns)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	// product_id last keeps pages stable when the sort fields tie
+	q.OrderBy("product_id")
 
-	for rows.Next() {
-		var product models.Product
-		product.BrandData = &models.Brand{}
-		product.CategoryData = &models.Category{}
-
-		err = rows.Scan(
-			&product.ProductId,
-			&product.ProductName,
-			&product.BrandId,
-			&product.BrandData.BrandId,
-			&product.BrandData.BrandName,
-			&product.CategoryId,
-			&product.CategoryData.CategoryId,
-			&product.CategoryData.CategoryName,
-			&product.ModelYear,
-			&product.ListPrice,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return q, nil
+}
 
-		resp.Products = append(resp.Products, &product)
-	}
+func scanProduct(row pgx.Row) (*models.Product, error) {
+	var product models.Product
+	product.BrandData = &models.Brand{}
+	product.CategoryData = &models.Category{}
 
-	resp.Count = len(resp.Products)
+	err := row.Scan(
+		&product.ProductId,
+		&product.ProductName,
+		&product.BrandId,
+		&product.BrandData.BrandId,
+		&product.BrandData.BrandName,
+		&product.CategoryId,
+		&product.CategoryData.CategoryId,
+		&product.CategoryData.CategoryName,
+		&product.ModelYear,
+		&product.ListPrice,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	return &resp, nil
+	return &product, nil
 }
 
 func (p *productRepo) Update(ctx context.Context, req *models.UpdateProduct) (int64, error) {
@@ -191,13 +269,13 @@ func (p *productRepo) Update(ctx context.Context, req *models.UpdateProduct) (in
 		WHERE product_id = $1
 	`
 
-	params := map[string]interface{} {
-		"product_id": req.ProductId,
+	params := map[string]interface{}{
+		"product_id":   req.ProductId,
 		"product_name": req.ProductName,
-		"brand_id": req.BrandId,
-		"category_id": req.CategoryId,
-		"model_year": req.ModelYear,
-		"list_price": req.ListPrice,
+		"brand_id":     req.BrandId,
+		"category_id":  req.CategoryId,
+		"model_year":   req.ModelYear,
+		"list_price":   req.ListPrice,
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
@@ -216,4 +294,26 @@ func (p *productRepo) Delete(ctx context.Context, req *models.ProductPrimaryKey)
 		return 0, err
 	}
 	return res.RowsAffected(), nil
-}
\ No newline at end of file
+}
+
+// Import copies the products in one transaction, a failing row rolls back all of them.
+func (p *productRepo) Import(ctx context.Context, req []*models.CreateProduct) (int64, error) {
+	var rowsAffected int64
+
+	err := p.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var err error
+		rowsAffected, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"products"},
+			[]string{"product_name", "brand_id", "category_id", "model_year", "list_price"},
+			pgx.CopyFromSlice(len(req), func(i int) ([]interface{}, error) {
+				return []interface{}{req[i].ProductName, req[i].BrandId, req[i].CategoryId, req[i].ModelYear, req[i].ListPrice}, nil
+			}),
+		)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
@@ -3,7 +3,6 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -24,29 +23,23 @@ func (p *promocodeRepo) Create(ctx context.Context, req *models.CreatePromocode)
 		id    int
 	)
 
-	err := p.db.QueryRow(ctx, `SELECT COALESCE(MAX(promocode_id), 0) + 1 FROM promocodes`).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-
 	query = `
 		INSERT INTO promocodes (
-			promocode_id,
 			promocode_name,
 			discount,
 			discount_type,
 			order_limit_price
 		)
-		VALUES($1, $2, $3, $4, $5)
+		VALUES($1, $2, $3, $4)
+		RETURNING promocode_id
 	`
 
-	_, err = p.db.Exec(ctx, query,
-		id,
+	err := p.db.QueryRow(ctx, query,
 		req.PromocodeName,
 		req.Discount,
 		req.DiscountType,
 		req.OrderLimitPrice,
-	)
+	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -87,14 +80,7 @@ func (p *promocodeRepo) GetById(ctx context.Context, req *models.PromocodePrimar
 func (p *promocodeRepo) GetList(ctx context.Context, req *models.GetListPromocodeRequest) (*models.GetListPromocodeResponse, error) {
 	promocodes := models.GetListPromocodeResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT
 			promocode_id,
 			promocode_name,
@@ -102,21 +88,19 @@ func (p *promocodeRepo) GetList(ctx context.Context, req *models.GetListPromocod
 			discount_type,
 			order_limit_price
 		FROM promocodes
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND promocode_name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
-	}
+	q.Search(req.Search, "promocode_name")
+	q.OrderBy("promocode_id")
+	q.Paginate(req.Offset, req.Limit)
 
-	query += filter + offset + limit
+	count, err := countRows(ctx, p.db, q)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := p.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +122,11 @@ func (p *promocodeRepo) GetList(ctx context.Context, req *models.GetListPromocod
 
 		promocodes.Promocodes = append(promocodes.Promocodes, &promocode)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-	promocodes.Count = len(promocodes.Promocodes)
+	promocodes.Count = count
 
 	return &promocodes, nil
 }
@@ -0,0 +1,462 @@
+package postgresql
+
+import (
+	"app/api/models"
+	"app/pkg/helper"
+	"app/storage"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type purchaseOrderRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPurchaseOrderRepo(db *pgxpool.Pool) *purchaseOrderRepo {
+	return &purchaseOrderRepo{
+		db: db,
+	}
+}
+
+// Create inserts the draft with all of its items in one transaction, item ids are numbered
+// in the order the items are given.
+func (r *purchaseOrderRepo) Create(ctx context.Context, req *models.CreatePurchaseOrder) (int, error) {
+	var id int
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO purchase_orders(
+				supplier_id,
+				status,
+				order_date,
+				expected_date
+			)
+			VALUES ($1, $2, NOW()::DATE, $3)
+			RETURNING purchase_order_id
+		`
+
+		err := tx.QueryRow(ctx, query,
+			req.SupplierId,
+			models.PurchaseOrderStatusDraft,
+			helper.NewNullString(req.ExpectedDate),
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for i, item := range req.Items {
+			if item.Quantity <= 0 {
+				return storage.ErrInvalidQuantity
+			}
+
+			_, err = tx.Exec(ctx, `
+				INSERT INTO purchase_order_items(
+					purchase_order_id,
+					item_id,
+					store_id,
+					product_id,
+					quantity,
+					unit_cost
+				)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, id, i+1, item.StoreId, item.ProductId, item.Quantity, item.UnitCost)
+			if err != nil {
+				return err
+			}
+		}
+
+		return insertPurchaseOrderStatusHistory(ctx, tx, id, 0, models.PurchaseOrderStatusDraft, req.CreatedBy, "")
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+const purchaseOrderQuery = `
+	WITH purchase_order_item_data AS (
+		SELECT
+			poi.purchase_order_id AS purchase_order_id,
+			JSONB_AGG (
+				JSONB_BUILD_OBJECT (
+					'purchase_order_id', poi.purchase_order_id,
+					'item_id', poi.item_id,
+					'store_id', poi.store_id,
+					'product_id', poi.product_id,
+					'quantity', poi.quantity,
+					'received_quantity', poi.received_quantity,
+					'unit_cost', poi.unit_cost
+				) ORDER BY poi.item_id
+			) AS items
+
+		FROM purchase_order_items AS poi
+		GROUP BY poi.purchase_order_id
+	)
+	SELECT
+		po.purchase_order_id,
+		po.supplier_id,
+
+		s.supplier_id,
+		s.supplier_name,
+		COALESCE(s.contact_name, ''),
+		COALESCE(s.phone, ''),
+		s.email,
+		COALESCE(s.street, ''),
+		COALESCE(s.city, ''),
+		COALESCE(s.state, ''),
+		COALESCE(s.zip_code, ''),
+
+		po.status,
+		CAST(po.order_date::timestamp AS VARCHAR),
+		COALESCE(CAST(po.expected_date::timestamp AS VARCHAR), ''),
+
+		COALESCE(poi.items, '[]')
+
+	FROM purchase_orders AS po
+	JOIN suppliers AS s ON s.supplier_id = po.supplier_id
+	LEFT JOIN purchase_order_item_data AS poi ON poi.purchase_order_id = po.purchase_order_id
+`
+
+func scanPurchaseOrder(row pgx.Row) (*models.PurchaseOrder, error) {
+	var purchaseOrder models.PurchaseOrder
+	purchaseOrder.SupplierData = &models.Supplier{}
+	var items pgtype.JSONB
+
+	err := row.Scan(
+		&purchaseOrder.PurchaseOrderId,
+		&purchaseOrder.SupplierId,
+
+		&purchaseOrder.SupplierData.SupplierId,
+		&purchaseOrder.SupplierData.SupplierName,
+		&purchaseOrder.SupplierData.ContactName,
+		&purchaseOrder.SupplierData.Phone,
+		&purchaseOrder.SupplierData.Email,
+		&purchaseOrder.SupplierData.Street,
+		&purchaseOrder.SupplierData.City,
+		&purchaseOrder.SupplierData.State,
+		&purchaseOrder.SupplierData.ZipCode,
+
+		&purchaseOrder.Status,
+		&purchaseOrder.OrderDate,
+		&purchaseOrder.ExpectedDate,
+
+		&items,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = items.AssignTo(&purchaseOrder.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &purchaseOrder, nil
+}
+
+func (r *purchaseOrderRepo) GetById(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (*models.PurchaseOrder, error) {
+	query := purchaseOrderQuery + ` WHERE po.purchase_order_id = $1`
+
+	return scanPurchaseOrder(r.db.QueryRow(ctx, query, req.PurchaseOrderId))
+}
+
+func (r *purchaseOrderRepo) GetList(ctx context.Context, req *models.GetListPurchaseOrderRequest) (*models.GetListPurchaseOrderResponse, error) {
+	purchaseOrders := &models.GetListPurchaseOrderResponse{}
+	purchaseOrders.PurchaseOrders = []*models.PurchaseOrder{}
+
+	q := NewListQuery(purchaseOrderQuery)
+
+	if req.SupplierId > 0 {
+		q.Where("po.supplier_id = ?", req.SupplierId)
+	}
+	if req.StoreId > 0 {
+		q.Where("EXISTS (SELECT 1 FROM purchase_order_items AS si WHERE si.purchase_order_id = po.purchase_order_id AND si.store_id = ?)", req.StoreId)
+	}
+	if req.Status > 0 {
+		q.Where("po.status = ?", req.Status)
+	}
+	q.OrderBy("po.purchase_order_id")
+	q.Paginate(req.Offset, req.Limit)
+
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		purchaseOrder, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		purchaseOrders.PurchaseOrders = append(purchaseOrders.PurchaseOrders, purchaseOrder)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	purchaseOrders.Count = count
+	return purchaseOrders, nil
+}
+
+// Delete removes the purchase order with its items and history. Goods already received stay
+// in stock and in the stock ledger.
+func (r *purchaseOrderRepo) Delete(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (int64, error) {
+	res, err := r.db.Exec(ctx, `DELETE FROM purchase_orders WHERE purchase_order_id = $1`, req.PurchaseOrderId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// lockPurchaseOrder locks the purchase order row for the rest of the transaction and returns its status.
+func lockPurchaseOrder(ctx context.Context, tx pgx.Tx, purchaseOrderId int) (status int16, err error) {
+	err = tx.QueryRow(ctx,
+		`SELECT status FROM purchase_orders WHERE purchase_order_id = $1 FOR UPDATE`,
+		purchaseOrderId,
+	).Scan(&status)
+	return status, err
+}
+
+func (r *purchaseOrderRepo) ChangeStatus(ctx context.Context, req *models.ChangePurchaseOrderStatus) error {
+
+	return r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		current, err := lockPurchaseOrder(ctx, tx, req.PurchaseOrderId)
+		if err != nil {
+			return err
+		}
+
+		if !models.CanChangePurchaseOrderStatus(current, req.Status) {
+			return fmt.Errorf("%w: %s -> %s", storage.ErrInvalidStatusTransition,
+				models.PurchaseOrderStatusName(current), models.PurchaseOrderStatusName(req.Status))
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE purchase_orders SET status = $2 WHERE purchase_order_id = $1`, req.PurchaseOrderId, req.Status)
+		if err != nil {
+			return err
+		}
+
+		return insertPurchaseOrderStatusHistory(ctx, tx, req.PurchaseOrderId, current, req.Status, req.ChangedBy, req.Comment)
+	})
+}
+
+// receiptLine is one line of a goods receipt with the item it is booked on.
+type receiptLine struct {
+	item     *models.PurchaseOrderItem
+	quantity int
+}
+
+// Receive books one goods receipt in a transaction: every received quantity is added to its item
+// and to the stock of the item's store, and the purchase order moves to partially received or,
+// once nothing is outstanding, to received.
+func (r *purchaseOrderRepo) Receive(ctx context.Context, req *models.ReceivePurchaseOrder) error {
+
+	return r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		current, err := lockPurchaseOrder(ctx, tx, req.PurchaseOrderId)
+		if err != nil {
+			return err
+		}
+
+		if !models.PurchaseOrderCanReceive(current) {
+			return fmt.Errorf("%w: purchase order is %s", storage.ErrOrderClosed, models.PurchaseOrderStatusName(current))
+		}
+
+		// check the whole receipt before touching stock, the same item may be on it more than once
+		lines := make([]*receiptLine, 0, len(req.Items))
+		items := map[int]*models.PurchaseOrderItem{}
+		for _, receipt := range req.Items {
+			if receipt.Quantity <= 0 {
+				return storage.ErrInvalidQuantity
+			}
+
+			item, ok := items[receipt.ItemId]
+			if !ok {
+				item = &models.PurchaseOrderItem{PurchaseOrderId: req.PurchaseOrderId, ItemId: receipt.ItemId}
+				err = tx.QueryRow(ctx, `
+					SELECT
+						store_id,
+						product_id,
+						quantity,
+						received_quantity
+					FROM purchase_order_items
+					WHERE purchase_order_id = $1 AND item_id = $2
+				`, req.PurchaseOrderId, receipt.ItemId).Scan(
+					&item.StoreId,
+					&item.ProductId,
+					&item.Quantity,
+					&item.ReceivedQuantity,
+				)
+				if err != nil {
+					return err
+				}
+				items[receipt.ItemId] = item
+			}
+
+			if item.ReceivedQuantity+receipt.Quantity > item.Quantity {
+				return fmt.Errorf("%w: item %d has %d of %d received", storage.ErrOverReceived,
+					receipt.ItemId, item.ReceivedQuantity, item.Quantity)
+			}
+			item.ReceivedQuantity += receipt.Quantity
+
+			lines = append(lines, &receiptLine{item: item, quantity: receipt.Quantity})
+		}
+
+		// lock stock rows by store and product, the order Place and the order item returns use
+		// within a store, so receipts and orders cannot deadlock each other
+		sort.SliceStable(lines, func(i, j int) bool {
+			a, b := lines[i].item, lines[j].item
+			if a.StoreId != b.StoreId {
+				return a.StoreId < b.StoreId
+			}
+			return a.ProductId < b.ProductId
+		})
+
+		for _, line := range lines {
+			_, err = tx.Exec(ctx, `
+				UPDATE purchase_order_items
+				SET received_quantity = received_quantity + $3
+				WHERE purchase_order_id = $1 AND item_id = $2
+			`, req.PurchaseOrderId, line.item.ItemId, line.quantity)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, `
+				INSERT INTO stocks(store_id, product_id, quantity)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (store_id, product_id) DO UPDATE SET quantity = stocks.quantity + EXCLUDED.quantity
+			`, line.item.StoreId, line.item.ProductId, line.quantity)
+			if err != nil {
+				return err
+			}
+
+			err = insertStockMovement(ctx, tx, &models.StockMovement{
+				StoreId:         line.item.StoreId,
+				ProductId:       line.item.ProductId,
+				Quantity:        line.quantity,
+				Reason:          models.StockMovementPurchaseReceived,
+				ItemId:          line.item.ItemId,
+				PurchaseOrderId: req.PurchaseOrderId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var outstanding bool
+		err = tx.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM purchase_order_items WHERE purchase_order_id = $1 AND received_quantity < quantity)`,
+			req.PurchaseOrderId,
+		).Scan(&outstanding)
+		if err != nil {
+			return err
+		}
+
+		next := models.PurchaseOrderStatusReceived
+		if outstanding {
+			next = models.PurchaseOrderStatusPartiallyReceived
+		}
+		if next == current {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE purchase_orders SET status = $2 WHERE purchase_order_id = $1`, req.PurchaseOrderId, next)
+		if err != nil {
+			return err
+		}
+
+		return insertPurchaseOrderStatusHistory(ctx, tx, req.PurchaseOrderId, current, next, req.ReceivedBy, req.Comment)
+	})
+}
+
+func (r *purchaseOrderRepo) GetStatusHistory(ctx context.Context, req *models.PurchaseOrderPrimaryKey) (*models.GetPurchaseOrderStatusHistoryResponse, error) {
+	resp := &models.GetPurchaseOrderStatusHistoryResponse{}
+	resp.History = []*models.PurchaseOrderStatusHistory{}
+
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM purchase_orders WHERE purchase_order_id = $1)`, req.PurchaseOrderId).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, pgx.ErrNoRows
+	}
+
+	query := `
+		SELECT
+			history_id,
+			purchase_order_id,
+			COALESCE(from_status, 0),
+			to_status,
+			COALESCE(changed_by, ''),
+			comment,
+			CAST(changed_at AS VARCHAR)
+		FROM purchase_order_status_history
+		WHERE purchase_order_id = $1
+		ORDER BY changed_at, history_id
+	`
+
+	rows, err := r.db.Query(ctx, query, req.PurchaseOrderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var history models.PurchaseOrderStatusHistory
+
+		err = rows.Scan(
+			&history.HistoryId,
+			&history.PurchaseOrderId,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.ChangedBy,
+			&history.Comment,
+			&history.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.History = append(resp.History, &history)
+	}
+
+	resp.Count = len(resp.History)
+	return resp, rows.Err()
+}
+
+func insertPurchaseOrderStatusHistory(ctx context.Context, tx pgx.Tx, purchaseOrderId int, from, to int16, changedBy, comment string) error {
+	query := `
+		INSERT INTO purchase_order_status_history(
+			purchase_order_id,
+			from_status,
+			to_status,
+			changed_by,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		purchaseOrderId,
+		helper.NewNullInt(int64(from)),
+		to,
+		helper.NewNullString(changedBy),
+		comment,
+	)
+	return err
+}
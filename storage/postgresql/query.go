@@ -0,0 +1,258 @@
+package postgresql
+
+import (
+	"app/pkg/helper"
+	"app/storage"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const defaultListLimit = 10
+
+// ListQuery composes the WHERE, GROUP BY, ORDER BY and pagination of a list query around a
+// base SELECT. Every value ends up as a bound parameter, conditions use ? as placeholder and
+// are renumbered to $n in the order they are added. Column names and ORDER BY expressions are
+// written into the SQL as given, so they must never come from the request.
+type ListQuery struct {
+	base    string
+	where   []string
+	groupBy string
+	orderBy []string
+	args    []interface{}
+	offset  int
+	limit   int
+	keyset  bool
+}
+
+// NewListQuery starts a list query. The base query may reference args as $1..$n, conditions
+// added later are numbered after them.
+func NewListQuery(base string, args ...interface{}) *ListQuery {
+	return &ListQuery{
+		base:  base,
+		args:  args,
+		limit: defaultListLimit,
+	}
+}
+
+// Where adds a condition joined with AND, e.g. Where("o.store_id = ?", storeId).
+func (q *ListQuery) Where(cond string, args ...interface{}) *ListQuery {
+	var (
+		b strings.Builder
+		n int
+	)
+
+	for _, r := range cond {
+		if r == '?' && n < len(args) {
+			q.args = append(q.args, args[n])
+			n++
+			b.WriteString(fmt.Sprintf("$%d", len(q.args)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	q.where = append(q.where, b.String())
+	return q
+}
+
+// Search matches value as a case insensitive substring of any of the columns. LIKE wildcards
+// in value are escaped so they match literally. An empty value adds nothing.
+func (q *ListQuery) Search(value string, columns ...string) *ListQuery {
+	if len(value) == 0 || len(columns) == 0 {
+		return q
+	}
+
+	pattern := "%" + EscapeLike(value) + "%"
+
+	conds := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		conds = append(conds, column+" ILIKE ?")
+		args = append(args, pattern)
+	}
+
+	return q.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
+
+func (q *ListQuery) GroupBy(expr string) *ListQuery {
+	q.groupBy = expr
+	return q
+}
+
+func (q *ListQuery) OrderBy(exprs ...string) *ListQuery {
+	q.orderBy = append(q.orderBy, exprs...)
+	return q
+}
+
+// Sort orders by a client supplied list like "-list_price,product_name". Only the keys of
+// columns are accepted, each mapped to the SQL expression to order by; a leading - sorts
+// descending. An empty sort adds nothing.
+func (q *ListQuery) Sort(sort string, columns map[string]string) error {
+	if len(strings.TrimSpace(sort)) == 0 {
+		return nil
+	}
+
+	exprs := []string{}
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+
+		direction := " ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = " DESC"
+			field = field[1:]
+		}
+
+		column, ok := columns[field]
+		if !ok {
+			return fmt.Errorf("%w: %q", storage.ErrInvalidSort, field)
+		}
+
+		exprs = append(exprs, column+direction)
+	}
+
+	q.OrderBy(exprs...)
+	return nil
+}
+
+// Paginate sets OFFSET and LIMIT, a limit below 1 falls back to the default page size.
+func (q *ListQuery) Paginate(offset, limit int) *ListQuery {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	q.offset = offset
+	q.limit = limit
+	return q
+}
+
+// keysetCursor is the position after the last row of a page ordered by id.
+type keysetCursor struct {
+	Id int `json:"id"`
+}
+
+// Keyset pages by idColumn instead of OFFSET: the page starts after the row the cursor points
+// at, and Build asks for one row more than the page so HasMore can tell if another page exists.
+// The query must be ordered by idColumn only. Call it after BuildCount, the cursor is not a filter.
+func (q *ListQuery) Keyset(idColumn, after string) error {
+	q.keyset = true
+
+	if len(after) == 0 {
+		return nil
+	}
+
+	var cursor keysetCursor
+	err := helper.DecodeCursor(after, &cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %v", storage.ErrInvalidCursor, err)
+	}
+
+	q.Where(idColumn+" > ?", cursor.Id)
+	q.offset = 0
+	return nil
+}
+
+// HasMore reports whether n scanned rows include the extra row Keyset asked for, in which case
+// the caller drops the last row and hands out NextCursor of the new last one.
+func (q *ListQuery) HasMore(n int) bool {
+	return q.keyset && n > q.limit
+}
+
+// NextCursor returns the cursor for the page after the row with the given id.
+func NextCursor(id int) string {
+	cursor, _ := helper.EncodeCursor(keysetCursor{Id: id})
+	return cursor
+}
+
+// Build returns the paginated query and its arguments.
+func (q *ListQuery) Build() (string, []interface{}) {
+	query := q.filtered()
+
+	if len(q.orderBy) > 0 {
+		query += " ORDER BY " + strings.Join(q.orderBy, ", ")
+	}
+
+	limit := q.limit
+	if q.keyset {
+		limit++
+	}
+
+	args := append(q.args[:len(q.args):len(q.args)], q.offset, limit)
+	query += fmt.Sprintf(" OFFSET $%d LIMIT $%d", len(args)-1, len(args))
+
+	return query, args
+}
+
+// BuildAll returns the query without pagination, for exports that read every matching row.
+func (q *ListQuery) BuildAll() (string, []interface{}) {
+	query := q.filtered()
+
+	if len(q.orderBy) > 0 {
+		query += " ORDER BY " + strings.Join(q.orderBy, ", ")
+	}
+
+	return query, q.args
+}
+
+// BuildCount returns a query counting every row the filters match, ignoring pagination.
+func (q *ListQuery) BuildCount() (string, []interface{}) {
+	return "SELECT COUNT(*) FROM (" + q.filtered() + ") AS list_query", q.args
+}
+
+func (q *ListQuery) filtered() string {
+	query := q.base
+
+	if len(q.where) > 0 {
+		query += " WHERE " + strings.Join(q.where, " AND ")
+	}
+	if len(q.groupBy) > 0 {
+		query += " GROUP BY " + q.groupBy
+	}
+
+	return query
+}
+
+func countRows(ctx context.Context, db *pgxpool.Pool, q *ListQuery) (int, error) {
+	var count int
+
+	query, args := q.BuildCount()
+	err := db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// exportRows runs the query without pagination and scans the rows one by one as they arrive
+// from the connection, so an export never holds more than one row in memory.
+func exportRows(ctx context.Context, db *pgxpool.Pool, q *ListQuery, scan func(rows pgx.Rows) error) error {
+	query, args := q.BuildAll()
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards in s, using the default backslash escape character.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
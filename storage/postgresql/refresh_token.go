@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type refreshTokenRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRefreshTokenRepo(db *pgxpool.Pool) *refreshTokenRepo {
+	return &refreshTokenRepo{
+		db: db,
+	}
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, req *models.CreateRefreshToken) (string, error) {
+	query := `
+		INSERT INTO refresh_tokens(token_hash, user_id, expires_at)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+	`
+
+	_, err := r.db.Exec(ctx, query, req.TokenHash, req.UserId, req.ExpiresIn)
+	if err != nil {
+		return "", err
+	}
+
+	return req.TokenHash, nil
+}
+
+func (r *refreshTokenRepo) GetById(ctx context.Context, req *models.RefreshTokenPrimaryKey) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+
+	query := `
+		SELECT
+			rt.token_hash,
+			rt.user_id,
+			CAST(rt.expires_at AS VARCHAR),
+			COALESCE(CAST(rt.revoked_at AS VARCHAR), ''),
+			CAST(rt.created_at AS VARCHAR)
+		FROM refresh_tokens AS rt
+		WHERE rt.token_hash = $1
+	`
+
+	err := r.db.QueryRow(ctx, query, req.TokenHash).Scan(
+		&token.TokenHash,
+		&token.UserId,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Revoke marks an active token as revoked. Zero rows affected means the token
+// was already revoked or has expired, so it must not be honoured.
+func (r *refreshTokenRepo) Revoke(ctx context.Context, req *models.RefreshTokenPrimaryKey) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	res, err := r.db.Exec(ctx, query, req.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (r *refreshTokenRepo) RevokeByUser(ctx context.Context, req *models.UserPrimaryKey) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	res, err := r.db.Exec(ctx, query, req.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
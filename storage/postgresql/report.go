@@ -2,10 +2,11 @@ package postgresql
 
 import (
 	"app/api/models"
+	"app/storage"
 	"context"
-	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -25,7 +26,7 @@ func (r *reportRepo) SendProduct(ctx context.Context, req *models.SendProduct) e
 	)
 
 	if req.Quantity <= 0 {
-		return errors.New("Invalid quantity")
+		return storage.ErrInvalidQuantity
 	}
 
 	err := r.db.QueryRow(ctx,
@@ -38,7 +39,7 @@ func (r *reportRepo) SendProduct(ctx context.Context, req *models.SendProduct) e
 	}
 
 	if senderStock < req.Quantity {
-		return errors.New("Sender doesn't have enough of this product")
+		return storage.ErrNotEnoughStock
 	}
 
 	_, err = r.db.Exec(ctx,
@@ -67,14 +68,51 @@ func (r *reportRepo) SendProduct(ctx context.Context, req *models.SendProduct) e
 func (r *reportRepo) StaffReport(ctx context.Context, req *models.StaffListRequest) (*models.StaffListResponse, error) {
 	staffs := &models.StaffListResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
+	q := staffReportQuery(req)
+	q.Paginate(req.Offset, req.Limit)
 
-	query = `
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		staffReport, err := scanStaffReport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		staffs.StaffReport = append(staffs.StaffReport, staffReport)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	staffs.Count = count
+
+	return staffs, nil
+}
+
+// ExportStaffReport hands every line of the staff report matching req to fn, ignoring pagination.
+func (r *reportRepo) ExportStaffReport(ctx context.Context, req *models.StaffListRequest, fn func(*models.StaffReport) error) error {
+	return exportRows(ctx, r.db, staffReportQuery(req), func(rows pgx.Rows) error {
+		staffReport, err := scanStaffReport(rows)
+		if err != nil {
+			return err
+		}
+		return fn(staffReport)
+	})
+}
+
+func staffReportQuery(req *models.StaffListRequest) *ListQuery {
+	q := NewListQuery(`
 		SELECT 
     		first_name || ' ' || last_name,
     		category_name ,
@@ -89,48 +127,34 @@ func (r *reportRepo) StaffReport(ctx context.Context, req *models.StaffListReque
 		JOIN stores ON orders.store_id = stores.store_id
 		JOIN products ON order_items.product_id = products.product_id
 		JOIN categories ON categories.category_id = products.category_id
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND (first_name || ' ' || last_name) ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	q.Search(req.Search, "first_name || ' ' || last_name")
+	if req.StoreId > 0 {
+		q.Where("orders.store_id = ?", req.StoreId)
 	}
+	q.OrderBy("orders.order_id", "order_items.item_id")
 
-	query += filter + offset + limit
+	return q
+}
 
-	rows, err := r.db.Query(ctx, query)
+func scanStaffReport(row pgx.Row) (*models.StaffReport, error) {
+	var staffReport models.StaffReport
+
+	err := row.Scan(
+		&staffReport.StaffName,
+		&staffReport.CategoryName,
+		&staffReport.ProductName,
+		&staffReport.Quantity,
+		&staffReport.TotalSum,
+		&staffReport.StoreName,
+		&staffReport.OrderDate,
+	)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var staffReport models.StaffReport
-
-		err = rows.Scan(
-			&staffReport.StaffName,
-			&staffReport.CategoryName,
-			&staffReport.ProductName,
-			&staffReport.Quantity,
-			&staffReport.TotalSum,
-			&staffReport.StoreName,
-			&staffReport.OrderDate,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		staffs.StaffReport = append(staffs.StaffReport, &staffReport)
-	}
-
-	staffs.Count = len(staffs.StaffReport)
-
-	return staffs, nil
+	return &staffReport, nil
 }
 
 func (r *reportRepo) OrderTotalSum(ctx context.Context, req *models.OrderTotalSum) (string, error) {
@@ -145,12 +169,12 @@ func (r *reportRepo) OrderTotalSum(ctx context.Context, req *models.OrderTotalSu
 	`
 
 	err := r.db.QueryRow(ctx, query, req.OrderId).Scan(&totalSum)
-	if totalSum == 0.0 {
-		return "", errors.New("There is no order with this id")
-	}
 	if err != nil {
 		return "", err
 	}
+	if totalSum == 0.0 {
+		return "", pgx.ErrNoRows
+	}
 
 	query = `
 		SELECT
@@ -182,44 +206,84 @@ func (r *reportRepo) OrderTotalSum(ctx context.Context, req *models.OrderTotalSu
 	return fmt.Sprintf("%.2f", totalSum), nil
 }
 
-func (r *orderRepo) CheckStock(ctx context.Context, req *models.CreateOrderItem) error {
-	var quantity, store_id int
-
-	if req.Quantity <=0 {
-		return errors.New("Invalid quantity")
-	}
-
-	query := `
-		SELECT 
-    		COALESCE(quantity, 0),
-			COALESCE(store_id, 0)
-		FROM stocks
-		WHERE product_id = $1 AND store_id = (
-    		SELECT
-        		store_id
-    		FROM orders
-   	 		WHERE order_id =$2
+// StockReconciliation compares what every order holds with what the stock ledger says was taken
+// for it. Rejected and cancelled orders are expected to have given all of their stock back.
+func (r *reportRepo) StockReconciliation(ctx context.Context, req *models.StockReconciliationRequest) (*models.StockReconciliationResponse, error) {
+	resp := &models.StockReconciliationResponse{}
+	resp.Drifts = []*models.StockReconciliation{}
+
+	q := NewListQuery(`
+		WITH items AS (
+			SELECT
+				o.order_id,
+				o.store_id,
+				oi.product_id,
+				SUM(oi.quantity) AS quantity
+			FROM orders AS o
+			JOIN order_items AS oi ON oi.order_id = o.order_id
+			WHERE o.order_status NOT IN ($1, $2)
+			GROUP BY o.order_id, o.store_id, oi.product_id
+		),
+		moves AS (
+			SELECT
+				sm.order_id,
+				sm.store_id,
+				sm.product_id,
+				-SUM(sm.quantity) AS quantity
+			FROM stock_movements AS sm
+			JOIN orders AS o ON o.order_id = sm.order_id
+			GROUP BY sm.order_id, sm.store_id, sm.product_id
 		)
-	`
+		SELECT
+			COALESCE(i.order_id, m.order_id),
+			COALESCE(i.store_id, m.store_id),
+			COALESCE(i.product_id, m.product_id),
+			o.order_status,
+			COALESCE(i.quantity, 0),
+			COALESCE(m.quantity, 0)
+		FROM items AS i
+		FULL JOIN moves AS m ON m.order_id = i.order_id AND m.store_id = i.store_id AND m.product_id = i.product_id
+		JOIN orders AS o ON o.order_id = COALESCE(i.order_id, m.order_id)
+	`, models.OrderStatusRejected, models.OrderStatusCancelled)
+
+	q.Where("COALESCE(i.quantity, 0) <> COALESCE(m.quantity, 0)")
+	if req.StoreId > 0 {
+		q.Where("COALESCE(i.store_id, m.store_id) = ?", req.StoreId)
+	}
+	q.OrderBy("1", "3")
+	q.Paginate(req.Offset, req.Limit)
 
-	err := r.db.QueryRow(ctx, query, req.ProductId, req.OrderId).Scan(&quantity, &store_id)
+	count, err := countRows(ctx, r.db, q)
 	if err != nil {
-		return errors.New("Product is not found")
+		return nil, err
 	}
 
-	if quantity < req.Quantity {
-		return errors.New("There is not enough of this product")
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	_, err = r.db.Exec(ctx,
-		`UPDATE stocks SET quantity = quantity - $1 WHERE store_id = $2 AND product_id = $3`,
-		req.Quantity,
-		store_id,
-		req.ProductId,
-	)
-	if err != nil {
-		return err
+	for rows.Next() {
+		var drift models.StockReconciliation
+
+		err = rows.Scan(
+			&drift.OrderId,
+			&drift.StoreId,
+			&drift.ProductId,
+			&drift.OrderStatus,
+			&drift.ItemQuantity,
+			&drift.MovedQuantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		drift.Drift = drift.ItemQuantity - drift.MovedQuantity
+
+		resp.Drifts = append(resp.Drifts, &drift)
 	}
 
-	return nil
+	resp.Count = count
+	return resp, rows.Err()
 }
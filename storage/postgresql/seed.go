@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"app/api/models"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// identityColumns are the generated ids a script with explicit ids leaves behind.
+var identityColumns = [][2]string{
+	{"brands", "brand_id"},
+	{"categories", "category_id"},
+	{"products", "product_id"},
+	{"staffs", "staff_id"},
+	{"orders", "order_id"},
+	{"promocodes", "promocode_id"},
+	{"customers", "customer_id"},
+	{"stores", "store_id"},
+}
+
+// RunScript runs a SQL script such as the BikeStores seed data in one transaction. The script
+// inserts explicit ids, so the id sequences are moved past them afterwards, and it inserts
+// order items without stock movements, so the ledger is backfilled the way migration 09 does.
+func RunScript(ctx context.Context, db *pgxpool.Pool, script string) error {
+	return db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, script)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO stock_movements (store_id, product_id, quantity, reason, order_id, item_id)
+			SELECT o.store_id, oi.product_id, -oi.quantity, $1, oi.order_id, oi.item_id
+			FROM order_items AS oi
+			JOIN orders AS o ON o.order_id = oi.order_id
+			WHERE o.order_status NOT IN ($2, $3) AND NOT EXISTS (
+				SELECT 1 FROM stock_movements AS sm
+				WHERE sm.order_id = oi.order_id AND sm.item_id = oi.item_id
+			)`,
+			models.StockMovementOrderItem,
+			models.OrderStatusRejected,
+			models.OrderStatusCancelled,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, column := range identityColumns {
+			_, err = tx.Exec(ctx,
+				`SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(`+column[1]+`), 0) + 1, false) FROM `+column[0],
+				column[0],
+				column[1],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
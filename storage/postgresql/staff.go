@@ -4,7 +4,6 @@ import (
 	"app/api/models"
 	"app/pkg/helper"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -27,7 +26,6 @@ func (r *staffRepo) Create(ctx context.Context, req *models.CreateStaff) (int, e
 
 	query = `
 		INSERT INTO staffs(
-			staff_id,
 			first_name,
 			last_name,
 			email,
@@ -36,10 +34,8 @@ func (r *staffRepo) Create(ctx context.Context, req *models.CreateStaff) (int, e
 			store_id,
 			manager_id
 		)
-		VALUES (
-			(SELECT MAX(staff_id) + 1 FROM staffs),
-			$1, $2, $3, $4, $5, $6, $7
-		) RETURNING staff_id
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING staff_id
 	`
 
 	err := r.db.QueryRow(ctx, query,
@@ -49,7 +45,7 @@ func (r *staffRepo) Create(ctx context.Context, req *models.CreateStaff) (int, e
 		req.Phone,
 		req.Active,
 		req.StoreId,
-		req.ManagerId,
+		helper.NewNullInt(int64(req.ManagerId)),
 	).Scan(&id)
 	if err != nil {
 		return 0, err
@@ -68,13 +64,13 @@ func (r *staffRepo) GetById(ctx context.Context, req *models.StaffPrimaryKey) (*
 			first_name,
 			last_name,
 			staffs.email,
-			staffs.phone,
+			COALESCE(staffs.phone, ''),
 			active,
 			store_id,
 
 			stores.store_id,
 			store_name,
-			stores.phone,
+			COALESCE(stores.phone, ''),
 			stores.email,
 			stores.street,
 			stores.city,
@@ -117,26 +113,19 @@ func (r *staffRepo) GetById(ctx context.Context, req *models.StaffPrimaryKey) (*
 func (r *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest) (*models.GetListStaffResponse, error) {
 	staffs := &models.GetListStaffResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT
 			staff_id,
 			first_name,
 			last_name,
 			staffs.email,
-			staffs.phone,
+			COALESCE(staffs.phone, ''),
 			active,
 			store_id,
 
 			stores.store_id,
 			store_name,
-			stores.phone,
+			COALESCE(stores.phone, ''),
 			stores.email,
 			stores.street,
 			stores.city,
@@ -146,23 +135,19 @@ func (r *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest
 			COALESCE(manager_id, 0)
 		FROM staffs
 		JOIN stores USING(store_id)
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
+	q.Search(req.Search, "first_name || ' ' || last_name")
+	q.OrderBy("staff_id")
+	q.Paginate(req.Offset, req.Limit)
 
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
 	}
 
-	query += filter + offset + limit
-
-	rows, err := r.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +183,10 @@ func (r *staffRepo) GetList(ctx context.Context, req *models.GetListStaffRequest
 
 		staffs.Staffs = append(staffs.Staffs, &staff)
 	}
-	staffs.Count = len(staffs.Staffs)
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	staffs.Count = count
 
 	return staffs, nil
 }
@@ -3,10 +3,10 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -94,13 +94,7 @@ func (r *stockRepo) GetList(ctx context.Context, req *models.GetListStockRequest
 	stocks := &models.GetListStockResponse{}
 	stocks.Stocks = []*models.GetStock{}
 
-	var (
-		query  string
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT
 			s.store_id,
 			SUM(s.quantity),
@@ -119,18 +113,27 @@ func (r *stockRepo) GetList(ctx context.Context, req *models.GetListStockRequest
 
 		FROM stocks AS s
 		LEFT JOIN products AS p ON p.product_id = s.product_id
-		GROUP BY s.store_id
-	`
+	`)
 
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	if req.StoreId > 0 {
+		q.Where("s.store_id = ?", req.StoreId)
 	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	q.GroupBy("s.store_id")
+	q.OrderBy("s.store_id")
+	q.Paginate(req.Offset, req.Limit)
+
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.Keyset("s.store_id", req.After)
+	if err != nil {
+		return nil, err
 	}
-	query += offset + limit
 
-	rows, err := r.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +155,77 @@ func (r *stockRepo) GetList(ctx context.Context, req *models.GetListStockRequest
 
 		stocks.Stocks = append(stocks.Stocks, &stock)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if q.HasMore(len(stocks.Stocks)) {
+		stocks.Stocks = stocks.Stocks[:len(stocks.Stocks)-1]
+		stocks.NextCursor = NextCursor(stocks.Stocks[len(stocks.Stocks)-1].StoreId)
+	}
 
-	stocks.Count = len(stocks.Stocks)
+	stocks.Count = count
 	return stocks, nil
 }
 
+// Export hands every store and product pair of req.StoreId, or of all stores, to fn ordered by
+// store and product, ignoring pagination.
+func (r *stockRepo) Export(ctx context.Context, req *models.GetListStockRequest, fn func(*models.Stock) error) error {
+	q := NewListQuery(`
+		SELECT
+			s.store_id,
+			s.product_id,
+			p.product_id,
+			p.product_name,
+			p.brand_id,
+			b.brand_id,
+			b.brand_name,
+			p.category_id,
+			c.category_id,
+			c.category_name,
+			p.model_year,
+			p.list_price,
+			s.quantity
+		FROM stocks AS s
+		JOIN products AS p ON p.product_id = s.product_id
+		JOIN brands AS b ON b.brand_id = p.brand_id
+		JOIN categories AS c ON c.category_id = p.category_id
+	`)
+
+	if req.StoreId > 0 {
+		q.Where("s.store_id = ?", req.StoreId)
+	}
+	q.OrderBy("s.store_id", "s.product_id")
+
+	return exportRows(ctx, r.db, q, func(rows pgx.Rows) error {
+		stock := models.Stock{ProductData: &models.Product{
+			BrandData:    &models.Brand{},
+			CategoryData: &models.Category{},
+		}}
+
+		err := rows.Scan(
+			&stock.StoreId,
+			&stock.ProductId,
+			&stock.ProductData.ProductId,
+			&stock.ProductData.ProductName,
+			&stock.ProductData.BrandId,
+			&stock.ProductData.BrandData.BrandId,
+			&stock.ProductData.BrandData.BrandName,
+			&stock.ProductData.CategoryId,
+			&stock.ProductData.CategoryData.CategoryId,
+			&stock.ProductData.CategoryData.CategoryName,
+			&stock.ProductData.ModelYear,
+			&stock.ProductData.ListPrice,
+			&stock.Quantity,
+		)
+		if err != nil {
+			return err
+		}
+
+		return fn(&stock)
+	})
+}
+
 func (r *stockRepo) Update(ctx context.Context, req *models.UpdateStock) (int64, error) {
 	query := `
 		UPDATE
@@ -185,15 +254,14 @@ func (r *stockRepo) Delete(ctx context.Context, req *models.StockPrimaryKey) (in
 
 	if req.ProductId > 0 {
 		res, err = r.db.Exec(ctx,
-			`DELETE FROM stocks WHERE stock_id = $1 and product_id = $2`,
+			`DELETE FROM stocks WHERE store_id = $1 and product_id = $2`,
 			req.StoreId,
 			req.ProductId,
 		)
 	} else {
 		res, err = r.db.Exec(ctx,
-			`DELETE FROM stocks WHERE stock_id = $1`,
+			`DELETE FROM stocks WHERE store_id = $1`,
 			req.StoreId,
-			req.ProductId,
 		)
 	}
 
@@ -203,3 +271,44 @@ func (r *stockRepo) Delete(ctx context.Context, req *models.StockPrimaryKey) (in
 
 	return res.RowsAffected(), nil
 }
+
+// Import sets the quantity of every store and product pair in one transaction. COPY cannot
+// update, so the rows go through a temporary table and are upserted from there.
+func (r *stockRepo) Import(ctx context.Context, req []*models.CreateStock) (int64, error) {
+	var rowsAffected int64
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `CREATE TEMPORARY TABLE stock_import (LIKE stocks) ON COMMIT DROP`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"stock_import"},
+			[]string{"store_id", "product_id", "quantity"},
+			pgx.CopyFromSlice(len(req), func(i int) ([]interface{}, error) {
+				return []interface{}{req[i].StoreId, req[i].ProductId, req[i].Quantity}, nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.Exec(ctx, `
+			INSERT INTO stocks(store_id, product_id, quantity)
+			SELECT store_id, product_id, quantity FROM stock_import
+			ON CONFLICT (store_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity
+		`)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected = res.RowsAffected()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"app/api/models"
+	"app/pkg/helper"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func insertStockMovement(ctx context.Context, tx pgx.Tx, req *models.StockMovement) error {
+	query := `
+		INSERT INTO stock_movements(
+			store_id,
+			product_id,
+			quantity,
+			reason,
+			order_id,
+			item_id,
+			purchase_order_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		req.StoreId,
+		req.ProductId,
+		req.Quantity,
+		req.Reason,
+		helper.NewNullInt(int64(req.OrderId)),
+		helper.NewNullInt(int64(req.ItemId)),
+		helper.NewNullInt(int64(req.PurchaseOrderId)),
+	)
+	return err
+}
+
+// returnOrderItems puts the items of an order back into its store's stock and records why.
+// itemId limits it to a single item, 0 returns every item of the order.
+func returnOrderItems(ctx context.Context, tx pgx.Tx, orderId, itemId, storeId int, reason string) error {
+	query := `
+		SELECT
+			item_id,
+			product_id,
+			quantity
+		FROM order_items
+		WHERE order_id = $1 AND ($2 = 0 OR item_id = $2)
+		ORDER BY product_id
+	`
+
+	rows, err := tx.Query(ctx, query, orderId, itemId)
+	if err != nil {
+		return err
+	}
+
+	var items []*models.OrderItem
+	for rows.Next() {
+		var item models.OrderItem
+
+		err = rows.Scan(
+			&item.ItemId,
+			&item.ProductId,
+			&item.Quantity,
+		)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		items = append(items, &item)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		// the stock row may have been deleted since the item was added
+		_, err = tx.Exec(ctx, `
+			INSERT INTO stocks(store_id, product_id, quantity)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (store_id, product_id) DO UPDATE SET quantity = stocks.quantity + EXCLUDED.quantity
+		`, storeId, item.ProductId, item.Quantity)
+		if err != nil {
+			return err
+		}
+
+		err = insertStockMovement(ctx, tx, &models.StockMovement{
+			StoreId:   storeId,
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			Reason:    reason,
+			OrderId:   orderId,
+			ItemId:    item.ItemId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
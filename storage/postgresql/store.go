@@ -3,7 +3,6 @@ package postgresql
 import (
 	"app/api/models"
 	"context"
-	"fmt"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
@@ -24,14 +23,8 @@ func (r *storeRepo) Create(ctx context.Context, req *models.CreateStore) (int, e
 		id    int
 	)
 
-	err := r.db.QueryRow(ctx, `SELECT MAX(store_id) + 1 FROM stores`).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-
 	query = `
 		INSERT INTO stores (
-			store_id,
 			store_name,
 			phone,
 			email,
@@ -40,11 +33,11 @@ func (r *storeRepo) Create(ctx context.Context, req *models.CreateStore) (int, e
 			state,
 			zip_code
 		)
-		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+		RETURNING store_id
 	`
 
-	_, err = r.db.Exec(ctx, query,
-		id,
+	err := r.db.QueryRow(ctx, query,
 		req.StoreName,
 		req.Phone,
 		req.Email,
@@ -52,7 +45,7 @@ func (r *storeRepo) Create(ctx context.Context, req *models.CreateStore) (int, e
 		req.City,
 		req.State,
 		req.ZipCode,
-	)
+	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -77,7 +70,7 @@ func (c *storeRepo) GetById(ctx context.Context, req *models.StorePrimaryKey) (*
 		WHERE store_id = $1
 	`
 
-	err := c.db.QueryRow(ctx, query, 
+	err := c.db.QueryRow(ctx, query,
 		req.StoreId,
 	).Scan(
 		&store.StoreId,
@@ -99,14 +92,7 @@ func (c *storeRepo) GetById(ctx context.Context, req *models.StorePrimaryKey) (*
 func (c *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest) (*models.GetListStoreResponse, error) {
 	stores := models.GetListStoreResponse{}
 
-	var (
-		query  string
-		filter = " WHERE TRUE "
-		offset = " OFFSET 0"
-		limit  = " LIMIT 10"
-	)
-
-	query = `
+	q := NewListQuery(`
 		SELECT 
 			store_id,
 			store_name,
@@ -117,21 +103,19 @@ func (c *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest
 			state,
 			zip_code
 		FROM stores
-	`
+	`)
 
-	if len(req.Search) > 0 {
-		filter += " AND store_name ILIKE '%' || '" + req.Search + "' || '%' "
-	}
-	if req.Offset > 0 {
-		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
-	}
-	if req.Limit > 0 {
-		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
-	}
+	q.Search(req.Search, "store_name")
+	q.OrderBy("store_id")
+	q.Paginate(req.Offset, req.Limit)
 
-	query += filter + offset + limit
+	count, err := countRows(ctx, c.db, q)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := c.db.Query(ctx, query)
+	query, args := q.Build()
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +140,11 @@ func (c *storeRepo) GetList(ctx context.Context, req *models.GetListStoreRequest
 
 		stores.Stores = append(stores.Stores, &store)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-	stores.Count = len(stores.Stores)
+	stores.Count = count
 
 	return &stores, nil
 }
@@ -177,8 +164,8 @@ func (c *storeRepo) Update(ctx context.Context, req *models.UpdateStore) (int64,
 		WHERE store_id = $8
 	`
 
-	res, err := c.db.Exec(ctx, query, 
-		req.StoreName, 
+	res, err := c.db.Exec(ctx, query,
+		req.StoreName,
 		req.Phone,
 		req.Email,
 		req.Street,
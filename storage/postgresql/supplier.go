@@ -0,0 +1,176 @@
+package postgresql
+
+import (
+	"app/api/models"
+	"app/pkg/helper"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type supplierRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSupplierRepo(db *pgxpool.Pool) *supplierRepo {
+	return &supplierRepo{
+		db: db,
+	}
+}
+
+func (r *supplierRepo) Create(ctx context.Context, req *models.CreateSupplier) (int, error) {
+	var id int
+
+	query := `
+		INSERT INTO suppliers (
+			supplier_name,
+			contact_name,
+			phone,
+			email,
+			street,
+			city,
+			state,
+			zip_code
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING supplier_id
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		req.SupplierName,
+		helper.NewNullString(req.ContactName),
+		helper.NewNullString(req.Phone),
+		req.Email,
+		helper.NewNullString(req.Street),
+		helper.NewNullString(req.City),
+		helper.NewNullString(req.State),
+		helper.NewNullString(req.ZipCode),
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+const supplierColumns = `
+	supplier_id,
+	supplier_name,
+	COALESCE(contact_name, ''),
+	COALESCE(phone, ''),
+	email,
+	COALESCE(street, ''),
+	COALESCE(city, ''),
+	COALESCE(state, ''),
+	COALESCE(zip_code, '')
+`
+
+func scanSupplier(row pgx.Row) (*models.Supplier, error) {
+	var supplier models.Supplier
+
+	err := row.Scan(
+		&supplier.SupplierId,
+		&supplier.SupplierName,
+		&supplier.ContactName,
+		&supplier.Phone,
+		&supplier.Email,
+		&supplier.Street,
+		&supplier.City,
+		&supplier.State,
+		&supplier.ZipCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (r *supplierRepo) GetById(ctx context.Context, req *models.SupplierPrimaryKey) (*models.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE supplier_id = $1`
+
+	return scanSupplier(r.db.QueryRow(ctx, query, req.SupplierId))
+}
+
+func (r *supplierRepo) GetList(ctx context.Context, req *models.GetListSupplierRequest) (*models.GetListSupplierResponse, error) {
+	suppliers := &models.GetListSupplierResponse{}
+	suppliers.Suppliers = []*models.Supplier{}
+
+	q := NewListQuery(`SELECT ` + supplierColumns + ` FROM suppliers`)
+
+	q.Search(req.Search, "supplier_name", "contact_name", "email")
+	q.OrderBy("supplier_id")
+	q.Paginate(req.Offset, req.Limit)
+
+	count, err := countRows(ctx, r.db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := q.Build()
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		suppliers.Suppliers = append(suppliers.Suppliers, supplier)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	suppliers.Count = count
+	return suppliers, nil
+}
+
+func (r *supplierRepo) Update(ctx context.Context, req *models.UpdateSupplier) (int64, error) {
+	query := `
+		UPDATE
+			suppliers
+		SET
+			supplier_name = $1,
+			contact_name = $2,
+			phone = $3,
+			email = $4,
+			street = $5,
+			city = $6,
+			state = $7,
+			zip_code = $8
+		WHERE supplier_id = $9
+	`
+
+	res, err := r.db.Exec(ctx, query,
+		req.SupplierName,
+		helper.NewNullString(req.ContactName),
+		helper.NewNullString(req.Phone),
+		req.Email,
+		helper.NewNullString(req.Street),
+		helper.NewNullString(req.City),
+		helper.NewNullString(req.State),
+		helper.NewNullString(req.ZipCode),
+		req.SupplierId,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// Delete fails with a foreign key violation while purchase orders of the supplier exist.
+func (r *supplierRepo) Delete(ctx context.Context, req *models.SupplierPrimaryKey) (int64, error) {
+	res, err := r.db.Exec(ctx, `DELETE FROM suppliers WHERE supplier_id = $1`, req.SupplierId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"app/api/models"
+	"app/pkg/helper"
 	"context"
 
 	"github.com/google/uuid"
@@ -18,15 +19,22 @@ func NewUserRepo(db *pgxpool.Pool) *userRepo {
 	}
 }
 
+// Create stores the user as given, as read_only unless a role is set; callers are expected to
+// hash the password first.
 func (u *userRepo) Create(ctx context.Context, req *models.CreateUser) (string, error) {
 	id := uuid.New().String()
 
+	role := req.Role
+	if role == "" {
+		role = models.RoleReadOnly
+	}
+
 	query := `
-		INSERT INTO users(user_id, username, password)
-		VALUES($1, $2, $3)
+		INSERT INTO users(user_id, username, password, role)
+		VALUES($1, $2, $3, $4)
 	`
 
-	_, err := u.db.Exec(ctx, query, id, req.Username, req.Password)
+	_, err := u.db.Exec(ctx, query, id, req.Username, req.Password, role)
 	if err != nil {
 		return "", err
 	}
@@ -34,20 +42,87 @@ func (u *userRepo) Create(ctx context.Context, req *models.CreateUser) (string,
 	return id, nil
 }
 
-func (u *userRepo) GetById(ctx context.Context, req *models.LoginUser) (bool, error) {
-	ans := false
+// GetById looks the user up by user_id, or by username when no id is given.
+func (u *userRepo) GetById(ctx context.Context, req *models.UserPrimaryKey) (*models.User, error) {
+	var (
+		user  models.User
+		where = " WHERE u.user_id = $1"
+		arg   = req.UserId
+	)
+
+	if req.UserId == "" {
+		where = " WHERE u.username = $1"
+		arg = req.Username
+	}
 
 	query := `
-	select exists(select 1 from users where username=$1 and password=$2)
-	`
+		SELECT
+			u.user_id,
+			u.username,
+			u.password,
+			u.role,
+			COALESCE(u.staff_id, 0),
+			COALESCE(st.store_id, 0)
+		FROM users AS u
+		LEFT JOIN staffs AS st ON st.staff_id = u.staff_id
+	` + where
 
-	err := u.db.QueryRow(ctx, query, req.Username, req.Password).Scan(
-		&ans,
+	err := u.db.QueryRow(ctx, query, arg).Scan(
+		&user.UserId,
+		&user.Username,
+		&user.Password,
+		&user.Role,
+		&user.StaffId,
+		&user.StoreId,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (u *userRepo) UpdatePassword(ctx context.Context, req *models.UpdateUserPassword) (int64, error) {
+	query := `
+		UPDATE users
+		SET password = $1
+		WHERE user_id = $2
+	`
+
+	res, err := u.db.Exec(ctx, query, req.Password, req.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (u *userRepo) UpdateRole(ctx context.Context, req *models.UpdateUserRole) (int64, error) {
+	query := `
+		UPDATE users
+		SET role = $1
+		WHERE user_id = $2
+	`
+
+	res, err := u.db.Exec(ctx, query, req.Role, req.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (u *userRepo) UpdateStaff(ctx context.Context, req *models.UpdateUserStaff) (int64, error) {
+	query := `
+		UPDATE users
+		SET staff_id = $1
+		WHERE user_id = $2
+	`
 
+	res, err := u.db.Exec(ctx, query, helper.NewNullInt(int64(req.StaffId)), req.UserId)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
-	return ans, nil
+	return res.RowsAffected(), nil
 }
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+type entityCacheRepo struct {
+	cache     *redis.Client
+	namespace string
+}
+
+func NewEntityCacheRepo(redisDB *redis.Client, namespace string) *entityCacheRepo {
+	return &entityCacheRepo{
+		cache:     redisDB,
+		namespace: namespace + ":entity:",
+	}
+}
+
+func (c *entityCacheRepo) Get(key string, dst interface{}) (bool, error) {
+
+	body, err := c.cache.Get(c.namespace + key).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	err = json.Unmarshal(body, dst)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *entityCacheRepo) Set(key string, value interface{}, ttl time.Duration) error {
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	err = c.cache.Set(c.namespace+key, body, ttl).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Version is 0 until the entity is first written.
+func (c *entityCacheRepo) Version(entity string) (int64, error) {
+
+	version, err := c.cache.Get(c.namespace + "version:" + entity).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (c *entityCacheRepo) Bump(entity string) error {
+
+	err := c.cache.Incr(c.namespace + "version:" + entity).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,73 +2,120 @@ package redis
 
 import (
 	"app/api/models"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-redis/redis"
 )
 
 type productCacheRepo struct {
-	cache *redis.Client
+	cache     *redis.Client
+	namespace string
+	ttl       time.Duration
+
+	hits          int64
+	misses        int64
+	invalidations int64
 }
 
-func NewProductCacheRepo(redisDB *redis.Client) *productCacheRepo {
+func NewProductCacheRepo(redisDB *redis.Client, namespace string, ttl time.Duration) *productCacheRepo {
 	return &productCacheRepo{
-		cache: redisDB,
+		cache:     redisDB,
+		namespace: namespace + ":products:",
+		ttl:       ttl,
 	}
 }
 
-func (c *productCacheRepo) Create(name string, req *models.GetListProductResponse) error {
+// pageKey hashes the request so keys have a fixed length whatever the search text is.
+func (c *productCacheRepo) pageKey(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return c.namespace + "list:" + hex.EncodeToString(sum[:])
+}
 
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
+func (c *productCacheRepo) tagKey(tag string) string {
+	return c.namespace + "tag:" + tag
+}
+
+func (c *productCacheRepo) Get(name string) (*models.GetListProductResponse, bool, error) {
+
+	productData, err := c.cache.Get(c.pageKey(name)).Bytes()
+	if err == redis.Nil {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
 	}
 
-	err = c.cache.Set(name, body, 0).Err()
+	resp := models.GetListProductResponse{}
+	err = json.Unmarshal(productData, &resp)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
-	return nil
+	atomic.AddInt64(&c.hits, 1)
+	return &resp, true, nil
 }
 
-func (c *productCacheRepo) GetAll(name string) (*models.GetListProductResponse, error) {
-
-	resp := models.GetListProductResponse{}
+// Set stores the page and adds its key to the set of every tag. Tag sets live as long
+// as the newest page in them, stale members left behind by expired pages are harmless.
+func (c *productCacheRepo) Set(name string, req *models.GetListProductResponse, tags []string) error {
 
-	productData, err := c.cache.Get(name).Result()
+	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = json.Unmarshal([]byte(productData), &resp)
+	key := c.pageKey(name)
+	_, err = c.cache.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(key, body, c.ttl)
+		for _, tag := range tags {
+			pipe.SAdd(c.tagKey(tag), key)
+			if c.ttl > 0 {
+				pipe.Expire(c.tagKey(tag), c.ttl)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &resp, nil
+	return nil
 }
 
-func (c *productCacheRepo) Exists(name string) (bool, error) {
+// Invalidate drops every page carrying one of the tags.
+func (c *productCacheRepo) Invalidate(tags ...string) error {
 
-	exists, err := c.cache.Exists(name).Result()
-	if err != nil {
-		return false, err
-	}
+	for _, tag := range tags {
+		keys, err := c.cache.SMembers(c.tagKey(tag)).Result()
+		if err != nil {
+			return err
+		}
 
-	if exists <= 0 {
-		return false, nil
+		err = c.cache.Del(append(keys, c.tagKey(tag))...).Err()
+		if err != nil {
+			return err
+		}
 	}
 
-	return true, nil
+	atomic.AddInt64(&c.invalidations, 1)
+	return nil
 }
 
-func (c *productCacheRepo) Delete() error {
+func (c *productCacheRepo) Stats() models.CacheStats {
 
-	err := c.cache.Del("products").Err()
-	if err != nil {
-		return err
+	stats := models.CacheStats{
+		Hits:          atomic.LoadInt64(&c.hits),
+		Misses:        atomic.LoadInt64(&c.misses),
+		Invalidations: atomic.LoadInt64(&c.invalidations),
 	}
 
-	return nil
-}
\ No newline at end of file
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return stats
+}
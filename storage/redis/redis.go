@@ -2,17 +2,37 @@ package redis
 
 import (
 	"app/config"
+	"app/pkg/logger"
 	"app/storage"
+	"app/storage/memcache"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-redis/redis"
 )
 
+// CacheStore serves from Redis while it answers and from an in-memory cache while it
+// does not. A background ping switches between the two, so a Redis outage degrades
+// caching instead of failing requests and Redis is picked up again once it is back.
+// Token revocation checks are the exception: they fail while Redis is down, see
+// failoverTokenRepo.
 type CacheStore struct {
+	cfg              *config.Config
+	logger           logger.LoggerI
 	redisDB          *redis.Client
 	productCacheRepo storage.ProductCacheRepoI
+	tokenCacheRepo   storage.TokenCacheRepoI
+	entityCacheRepo  storage.EntityCacheRepoI
+
+	up     int32
+	mu     sync.RWMutex
+	memory storage.StorageCacheI
+	tokens *failoverTokenRepo
+	done   chan struct{}
 }
 
-func NewConnectRedis(cfg *config.Config) (storage.StorageCacheI, error) {
+func NewConnectRedis(cfg *config.Config, log logger.LoggerI) storage.StorageCacheI {
 
 	client := redis.NewClient(
 		&redis.Options{
@@ -22,24 +42,204 @@ func NewConnectRedis(cfg *config.Config) (storage.StorageCacheI, error) {
 		},
 	)
 
-	_, err := client.Ping().Result()
+	c := &CacheStore{
+		cfg:              cfg,
+		logger:           log,
+		redisDB:          client,
+		productCacheRepo: NewProductCacheRepo(client, cfg.CacheNamespace, cfg.ProductCacheTTL),
+		tokenCacheRepo:   NewTokenCacheRepo(client),
+		entityCacheRepo:  NewEntityCacheRepo(client, cfg.CacheNamespace),
+		memory:           memcache.NewCache(cfg),
+		done:             make(chan struct{}),
+	}
+	c.tokens = &failoverTokenRepo{store: c, memory: memcache.NewTokenCacheRepo(), pending: map[string]time.Time{}}
+
+	err := client.Ping().Err()
 	if err != nil {
-		return nil, err
+		log.Warn("redis unavailable, using in-memory cache until it is back", logger.Error(err))
+	} else {
+		c.up = 1
 	}
 
-	return &CacheStore{
-		redisDB: client,
-		productCacheRepo: NewProductCacheRepo(client),
-	}, nil
+	go c.watch(cfg.RedisHealthInterval)
+
+	return c
 }
 
 func (c *CacheStore) CloseDB() {
+	close(c.done)
 	c.redisDB.Close()
 }
 
+func (c *CacheStore) isUp() bool {
+	return atomic.LoadInt32(&c.up) == 1
+}
+
+func (c *CacheStore) fallback() storage.StorageCacheI {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.memory
+}
+
 func (c *CacheStore) Product() storage.ProductCacheRepoI {
-	if c.productCacheRepo == nil {
-		c.productCacheRepo = NewProductCacheRepo(c.redisDB)
+	if !c.isUp() {
+		return c.fallback().Product()
 	}
 	return c.productCacheRepo
-}
\ No newline at end of file
+}
+
+func (c *CacheStore) Token() storage.TokenCacheRepoI {
+	return c.tokens
+}
+
+func (c *CacheStore) Entity() storage.EntityCacheRepoI {
+	if !c.isUp() {
+		return c.fallback().Entity()
+	}
+	return c.entityCacheRepo
+}
+
+func (c *CacheStore) watch(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+// check flips between Redis and memory. Each side misses the writes made while the
+// other one was serving, so the side taking over starts empty.
+func (c *CacheStore) check() {
+	err := c.redisDB.Ping().Err()
+
+	switch {
+	case err != nil && c.isUp():
+		c.mu.Lock()
+		c.memory = memcache.NewCache(c.cfg)
+		c.mu.Unlock()
+		atomic.StoreInt32(&c.up, 0)
+		c.logger.Warn("redis unavailable, using in-memory cache until it is back", logger.Error(err))
+
+	case err == nil && !c.isUp():
+		err = c.flush()
+		if err != nil {
+			c.logger.Warn("redis is back but could not be flushed, retrying", logger.Error(err))
+			return
+		}
+		err = c.tokens.replay()
+		if err != nil {
+			c.logger.Warn("redis is back but the revoked tokens could not be written, retrying", logger.Error(err))
+			return
+		}
+		atomic.StoreInt32(&c.up, 1)
+		c.logger.Info("redis reconnected")
+
+	case err == nil:
+		err = c.tokens.replay()
+		if err != nil {
+			c.logger.Error("cache.token.replay", logger.Error(err))
+		}
+	}
+}
+
+// flush drops the cached reads. Revoked tokens are kept, they are still valid.
+func (c *CacheStore) flush() error {
+	for _, pattern := range []string{c.cfg.CacheNamespace + ":products:*", c.cfg.CacheNamespace + ":entity:*"} {
+		var keys []string
+
+		iter := c.redisDB.Scan(0, pattern, 100).Iterator()
+		for iter.Next() {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			err := c.redisDB.Del(keys...).Err()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// failoverTokenRepo keeps every revocation in memory as well, so a token revoked while
+// Redis was down stays revoked on this instance after Redis comes back. Revocations Redis
+// did not take are kept as pending and written to it before the store switches back to
+// Redis, so the other instances see them too.
+//
+// Lookups fail closed: while Redis is down or errors, a token this instance did not revoke
+// gets storage.ErrCacheUnavailable, since another instance may have revoked it.
+type failoverTokenRepo struct {
+	store  *CacheStore
+	memory storage.TokenCacheRepoI
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+}
+
+func (r *failoverTokenRepo) Revoke(tokenId string, ttl time.Duration) error {
+	err := r.memory.Revoke(tokenId, ttl)
+	if err != nil || ttl <= 0 {
+		return err
+	}
+
+	if r.store.isUp() {
+		err = r.store.tokenCacheRepo.Revoke(tokenId, ttl)
+		if err == nil {
+			return nil
+		}
+		r.store.logger.Error("cache.token.revoke", logger.Error(err))
+	}
+
+	r.mu.Lock()
+	r.pending[tokenId] = time.Now().Add(ttl)
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *failoverTokenRepo) IsRevoked(tokenId string) (bool, error) {
+	revoked, err := r.memory.IsRevoked(tokenId)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	if !r.store.isUp() {
+		return false, storage.ErrCacheUnavailable
+	}
+
+	return r.store.tokenCacheRepo.IsRevoked(tokenId)
+}
+
+// replay writes the pending revocations to Redis, the ones that expired meanwhile are dropped.
+func (r *failoverTokenRepo) replay() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for tokenId, expiresAt := range r.pending {
+		if ttl := expiresAt.Sub(now); ttl > 0 {
+			err := r.store.tokenCacheRepo.Revoke(tokenId, ttl)
+			if err != nil {
+				return err
+			}
+		}
+		delete(r.pending, tokenId)
+	}
+
+	return nil
+}
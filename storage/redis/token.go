@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const revokedTokenPrefix = "revoked_token:"
+
+type tokenCacheRepo struct {
+	cache *redis.Client
+}
+
+func NewTokenCacheRepo(redisDB *redis.Client) *tokenCacheRepo {
+	return &tokenCacheRepo{
+		cache: redisDB,
+	}
+}
+
+// Revoke adds the token id to the revocation set until the token would have expired anyway.
+func (c *tokenCacheRepo) Revoke(tokenId string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := c.cache.Set(revokedTokenPrefix+tokenId, 1, ttl).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *tokenCacheRepo) IsRevoked(tokenId string) (bool, error) {
+
+	exists, err := c.cache.Exists(revokedTokenPrefix + tokenId).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return exists > 0, nil
+}
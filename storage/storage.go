@@ -19,6 +19,9 @@ type StorageI interface {
 	Promocode() PromocodeRepoI
 	Report() ReportRepoI
 	User() UserRepoI
+	RefreshToken() RefreshTokenRepoI
+	Supplier() SupplierRepoI
+	PurchaseOrder() PurchaseOrderRepoI
 }
 
 type CategoryRepoI interface {
@@ -43,6 +46,8 @@ type ProductRepoI interface {
 	GetList(context.Context, *models.GetListProductRequest) (*models.GetListProductResponse, error)
 	Update(context.Context, *models.UpdateProduct) (int64, error)
 	Delete(context.Context, *models.ProductPrimaryKey) (int64, error)
+	Import(context.Context, []*models.CreateProduct) (int64, error)
+	Export(context.Context, *models.GetListProductRequest, func(*models.Product) error) error
 }
 
 type StockRepoI interface {
@@ -51,6 +56,8 @@ type StockRepoI interface {
 	GetList(context.Context, *models.GetListStockRequest) (*models.GetListStockResponse, error)
 	Update(context.Context, *models.UpdateStock) (int64, error)
 	Delete(context.Context, *models.StockPrimaryKey) (int64, error)
+	Import(context.Context, []*models.CreateStock) (int64, error)
+	Export(context.Context, *models.GetListStockRequest, func(*models.Stock) error) error
 }
 
 type StoreRepoI interface {
@@ -67,6 +74,7 @@ type CustomerRepoI interface {
 	GetList(context.Context, *models.GetListCustomerRequest) (*models.GetListCustomerResponse, error)
 	Update(context.Context, *models.UpdateCustomer) (int64, error)
 	Delete(context.Context, *models.CustomerPrimaryKey) (int64, error)
+	Import(context.Context, []*models.CreateCustomer) (int64, error)
 }
 
 type StaffRepoI interface {
@@ -81,11 +89,14 @@ type OrderRepoI interface {
 	Create(context.Context, *models.CreateOrder) (int, error)
 	GetById(context.Context, *models.OrderPrimaryKey) (*models.Order, error)
 	GetList(context.Context, *models.GetListOrderRequest) (*models.GetListOrderResponse, error)
+	Export(context.Context, *models.GetListOrderRequest, func(*models.Order) error) error
 	Update(context.Context, *models.UpdateOrder) (int64, error)
 	Delete(context.Context, *models.OrderPrimaryKey) (int64, error)
+	Place(context.Context, *models.PlaceOrder) (int, error)
 	AddOrderItem(context.Context, *models.CreateOrderItem) error
-	CheckStock(context.Context, *models.CreateOrderItem) error
 	RemoveOrderItem(context.Context, *models.OrderItemPrimaryKey) (int64, error)
+	ChangeStatus(context.Context, *models.ChangeOrderStatus) error
+	GetStatusHistory(context.Context, *models.OrderPrimaryKey) (*models.GetOrderStatusHistoryResponse, error)
 }
 
 type PromocodeRepoI interface {
@@ -98,10 +109,39 @@ type PromocodeRepoI interface {
 type ReportRepoI interface {
 	SendProduct(context.Context, *models.SendProduct) error
 	StaffReport(context.Context, *models.StaffListRequest) (*models.StaffListResponse, error)
+	ExportStaffReport(context.Context, *models.StaffListRequest, func(*models.StaffReport) error) error
 	OrderTotalSum(context.Context, *models.OrderTotalSum) (string, error)
+	StockReconciliation(context.Context, *models.StockReconciliationRequest) (*models.StockReconciliationResponse, error)
+}
+
+type SupplierRepoI interface {
+	Create(context.Context, *models.CreateSupplier) (int, error)
+	GetById(context.Context, *models.SupplierPrimaryKey) (*models.Supplier, error)
+	GetList(context.Context, *models.GetListSupplierRequest) (*models.GetListSupplierResponse, error)
+	Update(context.Context, *models.UpdateSupplier) (int64, error)
+	Delete(context.Context, *models.SupplierPrimaryKey) (int64, error)
+}
+
+type PurchaseOrderRepoI interface {
+	Create(context.Context, *models.CreatePurchaseOrder) (int, error)
+	GetById(context.Context, *models.PurchaseOrderPrimaryKey) (*models.PurchaseOrder, error)
+	GetList(context.Context, *models.GetListPurchaseOrderRequest) (*models.GetListPurchaseOrderResponse, error)
+	Delete(context.Context, *models.PurchaseOrderPrimaryKey) (int64, error)
+	ChangeStatus(context.Context, *models.ChangePurchaseOrderStatus) error
+	Receive(context.Context, *models.ReceivePurchaseOrder) error
+	GetStatusHistory(context.Context, *models.PurchaseOrderPrimaryKey) (*models.GetPurchaseOrderStatusHistoryResponse, error)
 }
 
 type UserRepoI interface {
 	Create(context.Context, *models.CreateUser) (string, error)
-	GetById(context.Context, *models.LoginUser) (bool, error)
-}
\ No newline at end of file
+	GetById(context.Context, *models.UserPrimaryKey) (*models.User, error)
+	UpdatePassword(context.Context, *models.UpdateUserPassword) (int64, error)
+	UpdateRole(context.Context, *models.UpdateUserRole) (int64, error)
+	UpdateStaff(context.Context, *models.UpdateUserStaff) (int64, error)
+}
+type RefreshTokenRepoI interface {
+	Create(context.Context, *models.CreateRefreshToken) (string, error)
+	GetById(context.Context, *models.RefreshTokenPrimaryKey) (*models.RefreshToken, error)
+	Revoke(context.Context, *models.RefreshTokenPrimaryKey) (int64, error)
+	RevokeByUser(context.Context, *models.UserPrimaryKey) (int64, error)
+}
@@ -0,0 +1,217 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testCategory(t *testing.T, store storage.StorageI) {
+	id, err := store.Category().Create(ctx, &models.CreateCategory{CategoryName: "Road Bikes"})
+	require.NoError(t, err)
+	_, err = store.Category().Create(ctx, &models.CreateCategory{CategoryName: "Mountain Bikes"})
+	require.NoError(t, err)
+
+	category, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	require.NoError(t, err)
+	assert.Equal(t, id, category.CategoryId)
+	assert.Equal(t, "Road Bikes", category.CategoryName)
+
+	list, err := store.Category().GetList(ctx, &models.GetListCategoryRequest{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	assert.Len(t, list.Categories, 1)
+	assert.Equal(t, id, list.Categories[0].CategoryId)
+
+	list, err = store.Category().GetList(ctx, &models.GetListCategoryRequest{Search: "mountain"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	rows, err := store.Category().Update(ctx, &models.UpdateCategory{CategoryId: id, CategoryName: "Cruisers"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	category, err = store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Cruisers", category.CategoryName)
+
+	rows, err = store.Category().Delete(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	assertNotFound(t, err)
+
+	rows, err = store.Category().Update(ctx, &models.UpdateCategory{CategoryId: id, CategoryName: "Cruisers"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = store.Category().Delete(ctx, &models.CategoryPrimaryKey{CategoryId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testBrand(t *testing.T, store storage.StorageI) {
+	id, err := store.Brand().Create(ctx, &models.CreateBrand{BrandName: "Trek"})
+	require.NoError(t, err)
+	_, err = store.Brand().Create(ctx, &models.CreateBrand{BrandName: "Electra"})
+	require.NoError(t, err)
+
+	brand, err := store.Brand().GetById(ctx, &models.BrandPrimaryKey{BrandId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Trek", brand.BrandName)
+
+	list, err := store.Brand().GetList(ctx, &models.GetListBrandRequest{Offset: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	require.Len(t, list.Brands, 1)
+	assert.Equal(t, "Electra", list.Brands[0].BrandName)
+
+	rows, err := store.Brand().Update(ctx, &models.UpdateBrand{BrandId: id, BrandName: "Trek Bikes"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = store.Brand().Delete(ctx, &models.BrandPrimaryKey{BrandId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Brand().GetById(ctx, &models.BrandPrimaryKey{BrandId: id})
+	assertNotFound(t, err)
+}
+
+func testProduct(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	product, err := store.Product().GetById(ctx, &models.ProductPrimaryKey{ProductId: s.productId})
+	require.NoError(t, err)
+	assert.Equal(t, "Domane", product.ProductName)
+	assert.Equal(t, 2018, product.ModelYear)
+	assert.Equal(t, float32(379.99), product.ListPrice)
+	require.NotNil(t, product.BrandData)
+	assert.Equal(t, "Trek", product.BrandData.BrandName)
+	require.NotNil(t, product.CategoryData)
+	assert.Equal(t, "Road Bikes", product.CategoryData.CategoryName)
+
+	_, err = store.Product().Create(ctx, &models.CreateProduct{ProductName: "Madone", BrandId: s.brandId + 100, CategoryId: s.categoryId})
+	assertPgCode(t, err, "23503")
+
+	rows, err := store.Product().Update(ctx, &models.UpdateProduct{ProductId: s.productId, ProductName: "Domane SL", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 449.99})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	product, err = store.Product().GetById(ctx, &models.ProductPrimaryKey{ProductId: s.productId})
+	require.NoError(t, err)
+	assert.Equal(t, "Domane SL", product.ProductName)
+	assert.Equal(t, float32(449.99), product.ListPrice)
+
+	_, err = store.Product().Update(ctx, &models.UpdateProduct{ProductId: s.productId, ProductName: "Domane", BrandId: s.brandId, CategoryId: s.categoryId + 100})
+	assertPgCode(t, err, "23503")
+
+	rows, err = store.Product().Update(ctx, &models.UpdateProduct{ProductId: s.productId + 100, ProductName: "Domane", BrandId: s.brandId, CategoryId: s.categoryId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// deleting the brand takes its products and their stock with it
+	rows, err = store.Brand().Delete(ctx, &models.BrandPrimaryKey{BrandId: s.brandId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Product().GetById(ctx, &models.ProductPrimaryKey{ProductId: s.productId})
+	assertNotFound(t, err)
+	assert.Equal(t, -1, stockOf(t, store, s.storeId, s.productId))
+
+	rows, err = store.Product().Delete(ctx, &models.ProductPrimaryKey{ProductId: s.productId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testProductList(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	electraId, err := store.Brand().Create(ctx, &models.CreateBrand{BrandName: "Electra"})
+	require.NoError(t, err)
+
+	products := []*models.CreateProduct{
+		{ProductName: "Townie", BrandId: electraId, CategoryId: s.categoryId, ModelYear: 2016, ListPrice: 549.99},
+		{ProductName: "Cruiser", BrandId: electraId, CategoryId: s.categoryId, ModelYear: 2017, ListPrice: 269.99},
+		{ProductName: "Madone", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 1999.99},
+	}
+	for _, product := range products {
+		_, err = store.Product().Create(ctx, product)
+		require.NoError(t, err)
+	}
+
+	names := func(resp *models.GetListProductResponse) []string {
+		var names []string
+		for _, product := range resp.Products {
+			names = append(names, product.ProductName)
+		}
+		return names
+	}
+
+	list, err := store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 4, list.Count)
+	assert.Equal(t, []string{"Domane", "Townie", "Cruiser", "Madone"}, names(list))
+	for _, product := range list.Products {
+		require.NotNil(t, product.BrandData)
+		require.NotNil(t, product.CategoryData)
+		assert.Equal(t, product.BrandId, product.BrandData.BrandId)
+		assert.Equal(t, "Road Bikes", product.CategoryData.CategoryName)
+	}
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, BrandId: electraId})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Townie", "Cruiser"}, names(list))
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, ModelYearFrom: 2017, PriceTo: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Domane", "Cruiser"}, names(list))
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, Search: "ma"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Domane", "Madone"}, names(list))
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, InStockStoreId: s.storeId})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Domane"}, names(list))
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, Sort: "-list_price"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Madone", "Townie", "Domane", "Cruiser"}, names(list))
+
+	list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, Sort: "brand_name,-model_year"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Cruiser", "Townie", "Madone", "Domane"}, names(list))
+
+	_, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, Sort: "password"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidSort))
+
+	// keyset pages walk the whole list once
+	var (
+		seen  []string
+		after string
+	)
+	for {
+		list, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 3, After: after})
+		require.NoError(t, err)
+		assert.Equal(t, 4, list.Count)
+		seen = append(seen, names(list)...)
+
+		if list.NextCursor == "" {
+			break
+		}
+		after = list.NextCursor
+	}
+	assert.Equal(t, []string{"Domane", "Townie", "Cruiser", "Madone"}, seen)
+
+	_, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, After: "not a cursor"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidCursor))
+
+	_, err = store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10, After: after, Sort: "list_price"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidCursor))
+}
@@ -0,0 +1,141 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+// concurrentRequests is how many requests the concurrency tests run at once.
+const concurrentRequests = 50
+
+// parallel runs fn n times at once and returns the ids it created, failing on an error or a
+// duplicate id.
+func parallel(t *testing.T, n int, fn func(i int) (int, error)) []int {
+	t.Helper()
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = make([]int, 0, n)
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			id, err := fn(i)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			ids = append(ids, id)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		assert.False(t, seen[id], "duplicate id %d", id)
+		seen[id] = true
+	}
+	assert.Len(t, ids, n)
+
+	return ids
+}
+
+func testConcurrentCreates(t *testing.T, store storage.StorageI) {
+	categoryIds := parallel(t, concurrentRequests, func(i int) (int, error) {
+		return store.Category().Create(ctx, &models.CreateCategory{CategoryName: fmt.Sprintf("Category %d", i)})
+	})
+	brandIds := parallel(t, concurrentRequests, func(i int) (int, error) {
+		return store.Brand().Create(ctx, &models.CreateBrand{BrandName: fmt.Sprintf("Brand %d", i)})
+	})
+	require.NotEmpty(t, categoryIds)
+	require.NotEmpty(t, brandIds)
+
+	parallel(t, concurrentRequests, func(i int) (int, error) {
+		return store.Product().Create(ctx, &models.CreateProduct{
+			ProductName: fmt.Sprintf("Product %d", i),
+			BrandId:     brandIds[i%len(brandIds)],
+			CategoryId:  categoryIds[i%len(categoryIds)],
+			ModelYear:   2020,
+			ListPrice:   99.99,
+		})
+	})
+	parallel(t, concurrentRequests, func(i int) (int, error) {
+		return store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Debra", LastName: "Burks", Email: fmt.Sprintf("customer%d@bikes.shop", i)})
+	})
+
+	list, err := store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, concurrentRequests, list.Count)
+}
+
+func testConcurrentPlace(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	madoneId, err := store.Product().Create(ctx, &models.CreateProduct{ProductName: "Madone", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 1999.99})
+	require.NoError(t, err)
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: s.storeId, ProductId: madoneId, Quantity: 20})
+	require.NoError(t, err)
+	_, err = store.Stock().Update(ctx, &models.UpdateStock{StoreId: s.storeId, ProductId: s.productId, Quantity: 20})
+	require.NoError(t, err)
+
+	// half of the orders list the products the other way round, the stock rows are still
+	// taken in one order so they can not deadlock
+	var (
+		mu       sync.Mutex
+		placed   int
+		shortage int
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < concurrentRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			place := s.placeOrder(1)
+			place.Items = append(place.Items, &models.PlaceOrderItem{ProductId: madoneId, Quantity: 1})
+			if i%2 == 1 {
+				place.Items[0], place.Items[1] = place.Items[1], place.Items[0]
+			}
+
+			_, err := store.Order().Place(ctx, place)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				placed++
+			case errors.Is(err, storage.ErrNotEnoughStock):
+				shortage++
+			default:
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 20, placed)
+	assert.Equal(t, concurrentRequests-20, shortage)
+	assert.Equal(t, 0, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 0, stockOf(t, store, s.storeId, madoneId))
+
+	list, err := store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 20, list.Count)
+
+	reconciliation, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testExport(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	otherId, err := store.Product().Create(ctx, &models.CreateProduct{ProductName: "Emonda", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2020, ListPrice: 2799.99})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = store.Order().Place(ctx, s.placeOrder(1))
+		require.NoError(t, err)
+	}
+
+	// exports ignore pagination and keep the sort of the list
+	var products []*models.Product
+	err = store.Product().Export(ctx, &models.GetListProductRequest{Limit: 1, Sort: "-model_year"}, func(product *models.Product) error {
+		products = append(products, product)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, otherId, products[0].ProductId)
+	assert.Equal(t, "Trek", products[0].BrandData.BrandName)
+	assert.Equal(t, "Road Bikes", products[0].CategoryData.CategoryName)
+
+	err = store.Product().Export(ctx, &models.GetListProductRequest{Sort: "unknown"}, func(*models.Product) error { return nil })
+	assert.True(t, errors.Is(err, storage.ErrInvalidSort))
+
+	// an error of fn stops the export and comes back as it is
+	stop := errors.New("stop")
+	calls := 0
+	err = store.Product().Export(ctx, &models.GetListProductRequest{}, func(*models.Product) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+
+	var stocks []*models.Stock
+	err = store.Stock().Export(ctx, &models.GetListStockRequest{StoreId: s.storeId}, func(stock *models.Stock) error {
+		stocks = append(stocks, stock)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, stocks, 1)
+	assert.Equal(t, s.productId, stocks[0].ProductId)
+	assert.Equal(t, 3, stocks[0].Quantity)
+	assert.Equal(t, "Domane", stocks[0].ProductData.ProductName)
+	assert.Equal(t, "Trek", stocks[0].ProductData.BrandData.BrandName)
+
+	var orders []*models.Order
+	err = store.Order().Export(ctx, &models.GetListOrderRequest{Limit: 1, StoreId: s.storeId}, func(order *models.Order) error {
+		orders = append(orders, order)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.True(t, orders[0].OrderId < orders[1].OrderId)
+	assert.Equal(t, "Burks", orders[0].CustomerData.LastName)
+	require.Len(t, orders[0].OrderItems, 1)
+
+	var lines []*models.StaffReport
+	err = store.Report().ExportStaffReport(ctx, &models.StaffListRequest{Limit: 1, Search: "fabiola"}, func(line *models.StaffReport) error {
+		lines = append(lines, line)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Fabiola Jackson", lines[0].StaffName)
+	assert.InDelta(t, 379.99, lines[0].TotalSum, 0.001)
+}
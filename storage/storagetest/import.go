@@ -0,0 +1,63 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testImport(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	rows, err := store.Product().Import(ctx, []*models.CreateProduct{
+		{ProductName: "Madone", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 4999.99},
+		{ProductName: "Emonda", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2020, ListPrice: 2799.99},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+
+	// one bad row fails the whole import
+	_, err = store.Product().Import(ctx, []*models.CreateProduct{
+		{ProductName: "Fuel", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 1},
+		{ProductName: "Slash", BrandId: s.brandId + 100, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 1},
+	})
+	assertPgCode(t, err, "23503")
+
+	products, err := store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, products.Count)
+
+	rows, err = store.Customer().Import(ctx, []*models.CreateCustomer{
+		{FirstName: "Kasha", LastName: "Todd", Email: "kasha.todd@yahoo.com"},
+		{FirstName: "Tameka", LastName: "Fisher", Email: "tameka.fisher@aol.com", ZipCode: 94087},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+
+	customers, err := store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, customers.Count)
+
+	otherId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+
+	// existing pairs get the new quantity, new pairs are added
+	rows, err = store.Stock().Import(ctx, []*models.CreateStock{
+		{StoreId: s.storeId, ProductId: s.productId, Quantity: 12},
+		{StoreId: otherId, ProductId: s.productId, Quantity: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	assert.Equal(t, 12, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 3, stockOf(t, store, otherId, s.productId))
+
+	_, err = store.Stock().Import(ctx, []*models.CreateStock{
+		{StoreId: s.storeId, ProductId: s.productId, Quantity: 1},
+		{StoreId: otherId, ProductId: s.productId + 100, Quantity: 1},
+	})
+	assertPgCode(t, err, "23503")
+	assert.Equal(t, 12, stockOf(t, store, s.storeId, s.productId))
+}
@@ -0,0 +1,320 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testOrder(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	id, err := store.Order().Create(ctx, &models.CreateOrder{CustomerId: s.customerId, RequiredDate: "2030-01-01", StoreId: s.storeId, StaffId: s.staffId})
+	require.NoError(t, err)
+
+	order, err := store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, order.OrderStatus)
+	assert.Equal(t, "2030-01-01 00:00:00", order.RequiredDate)
+	assert.Equal(t, "", order.ShippedDate)
+	assert.NotEmpty(t, order.OrderDate)
+	assert.Empty(t, order.OrderItems)
+	require.NotNil(t, order.CustomerData)
+	assert.Equal(t, "Debra", order.CustomerData.FirstName)
+	require.NotNil(t, order.StoreData)
+	assert.Equal(t, "Santa Cruz Bikes", order.StoreData.StoreName)
+	require.NotNil(t, order.StaffData)
+	assert.Equal(t, "Fabiola", order.StaffData.FirstName)
+
+	_, err = store.Order().Create(ctx, &models.CreateOrder{CustomerId: s.customerId, RequiredDate: "2030-01-01", StoreId: s.storeId, StaffId: s.staffId + 100})
+	assertPgCode(t, err, "23503")
+
+	_, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id + 100})
+	assertNotFound(t, err)
+
+	rows, err := store.Order().Update(ctx, &models.UpdateOrder{OrderId: id, CustomerId: s.customerId, RequiredDate: "2030-02-01", StoreId: s.storeId, StaffId: s.staffId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	order, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "2030-02-01 00:00:00", order.RequiredDate)
+
+	rows, err = store.Order().Update(ctx, &models.UpdateOrder{OrderId: id + 100, CustomerId: s.customerId, RequiredDate: "2030-02-01", StoreId: s.storeId, StaffId: s.staffId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = store.Order().Delete(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	assertNotFound(t, err)
+
+	rows, err = store.Order().Delete(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testPlaceOrder(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	madoneId, err := store.Product().Create(ctx, &models.CreateProduct{ProductName: "Madone", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2019, ListPrice: 1999.99})
+	require.NoError(t, err)
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: s.storeId, ProductId: madoneId, Quantity: 1})
+	require.NoError(t, err)
+
+	place := s.placeOrder(2)
+	place.Items = append(place.Items, &models.PlaceOrderItem{ProductId: madoneId, Quantity: 1, Discount: 0.1})
+
+	id, err := store.Order().Place(ctx, place)
+	require.NoError(t, err)
+
+	order, err := store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	require.Len(t, order.OrderItems, 2)
+	for i, item := range order.OrderItems {
+		assert.Equal(t, id, item.OrderId)
+		assert.Equal(t, i+1, item.ItemId)
+	}
+	assert.Equal(t, 379.99, order.OrderItems[0].ListPrice)
+	assert.Equal(t, 2, order.OrderItems[0].Quantity)
+	assert.Equal(t, 1999.99, order.OrderItems[1].ListPrice)
+	assert.Equal(t, 0.1, order.OrderItems[1].Discount)
+
+	assert.Equal(t, 3, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 0, stockOf(t, store, s.storeId, madoneId))
+
+	// a failing item rolls the whole order back
+	place = s.placeOrder(1)
+	place.Items = append(place.Items, &models.PlaceOrderItem{ProductId: madoneId, Quantity: 1})
+	_, err = store.Order().Place(ctx, place)
+	assert.True(t, errors.Is(err, storage.ErrNotEnoughStock), err)
+
+	place = s.placeOrder(1)
+	place.Items = append(place.Items, &models.PlaceOrderItem{ProductId: madoneId + 100, Quantity: 1})
+	_, err = store.Order().Place(ctx, place)
+	assertNotFound(t, err)
+
+	_, err = store.Order().Place(ctx, s.placeOrder(0))
+	assert.True(t, errors.Is(err, storage.ErrInvalidQuantity), err)
+
+	assert.Equal(t, 3, stockOf(t, store, s.storeId, s.productId))
+
+	list, err := store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	// deleting a pending order returns its stock
+	rows, err := store.Order().Delete(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, 5, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 1, stockOf(t, store, s.storeId, madoneId))
+}
+
+func testOrderItems(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	id, err := store.Order().Create(ctx, &models.CreateOrder{CustomerId: s.customerId, RequiredDate: "2030-01-01", StoreId: s.storeId, StaffId: s.staffId})
+	require.NoError(t, err)
+
+	for _, quantity := range []int{1, 2} {
+		err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id, ProductId: s.productId, Quantity: quantity, ListPrice: 379.99})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, stockOf(t, store, s.storeId, s.productId))
+
+	err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id, ProductId: s.productId, Quantity: 3, ListPrice: 379.99})
+	assert.True(t, errors.Is(err, storage.ErrNotEnoughStock), err)
+
+	err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id, ProductId: s.productId, Quantity: 0})
+	assert.True(t, errors.Is(err, storage.ErrInvalidQuantity), err)
+
+	err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id + 100, ProductId: s.productId, Quantity: 1})
+	assertNotFound(t, err)
+
+	order, err := store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	require.Len(t, order.OrderItems, 2)
+	assert.Equal(t, []int{1, 2}, []int{order.OrderItems[0].ItemId, order.OrderItems[1].ItemId})
+	assert.Equal(t, []int{1, 2}, []int{order.OrderItems[0].Quantity, order.OrderItems[1].Quantity})
+
+	rows, err := store.Order().RemoveOrderItem(ctx, &models.OrderItemPrimaryKey{OrderId: id, ItemId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, 3, stockOf(t, store, s.storeId, s.productId))
+
+	rows, err = store.Order().RemoveOrderItem(ctx, &models.OrderItemPrimaryKey{OrderId: id, ItemId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// item ids keep counting after the highest one
+	err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id, ProductId: s.productId, Quantity: 1, ListPrice: 379.99})
+	require.NoError(t, err)
+
+	order, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	require.Len(t, order.OrderItems, 2)
+	assert.Equal(t, 3, order.OrderItems[1].ItemId)
+
+	// shipped orders keep their items
+	for _, status := range []int16{models.OrderStatusProcessing, models.OrderStatusShipped} {
+		err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: id, Status: status})
+		require.NoError(t, err)
+	}
+
+	err = store.Order().AddOrderItem(ctx, &models.CreateOrderItem{OrderId: id, ProductId: s.productId, Quantity: 1})
+	assert.True(t, errors.Is(err, storage.ErrOrderClosed), err)
+
+	_, err = store.Order().RemoveOrderItem(ctx, &models.OrderItemPrimaryKey{OrderId: id, ItemId: 2})
+	assert.True(t, errors.Is(err, storage.ErrOrderClosed), err)
+
+	// deleting a shipped order leaves the stock consumed
+	rows, err = store.Order().Delete(ctx, &models.OrderPrimaryKey{OrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, 2, stockOf(t, store, s.storeId, s.productId))
+}
+
+func testOrderStatus(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	userId, err := store.User().Create(ctx, &models.CreateUser{Username: "fabiola", Password: "hash"})
+	require.NoError(t, err)
+
+	place := s.placeOrder(2)
+	place.CreatedBy = userId
+	shippedId, err := store.Order().Place(ctx, place)
+	require.NoError(t, err)
+	cancelledId, err := store.Order().Place(ctx, s.placeOrder(1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, stockOf(t, store, s.storeId, s.productId))
+
+	for _, status := range []int16{models.OrderStatusProcessing, models.OrderStatusShipped, models.OrderStatusCompleted} {
+		err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: shippedId, Status: status, ChangedBy: userId, Comment: models.OrderStatusName(status)})
+		require.NoError(t, err)
+	}
+
+	order, err := store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: shippedId})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCompleted, order.OrderStatus)
+	assert.NotEmpty(t, order.ShippedDate)
+
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: shippedId, Status: models.OrderStatusCancelled})
+	assert.True(t, errors.Is(err, storage.ErrInvalidStatusTransition), err)
+
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: shippedId + 100, Status: models.OrderStatusProcessing})
+	assertNotFound(t, err)
+
+	history, err := store.Order().GetStatusHistory(ctx, &models.OrderPrimaryKey{OrderId: shippedId})
+	require.NoError(t, err)
+	require.Equal(t, 4, history.Count)
+	assert.Equal(t, int16(0), history.History[0].FromStatus)
+	assert.Equal(t, models.OrderStatusPending, history.History[0].ToStatus)
+	for i, row := range history.History[1:] {
+		assert.Equal(t, history.History[i].ToStatus, row.FromStatus)
+		assert.Equal(t, userId, row.ChangedBy)
+		assert.Equal(t, models.OrderStatusName(row.ToStatus), row.Comment)
+		assert.NotEmpty(t, row.ChangedAt)
+	}
+
+	_, err = store.Order().GetStatusHistory(ctx, &models.OrderPrimaryKey{OrderId: shippedId + 100})
+	assertNotFound(t, err)
+
+	// an order holding stock can not move to another store, its stock would be returned there
+	otherId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+	moved := &models.UpdateOrder{OrderId: cancelledId, CustomerId: s.customerId, RequiredDate: "2030-01-01", StoreId: otherId, StaffId: s.staffId}
+	_, err = store.Order().Update(ctx, moved)
+	assert.True(t, errors.Is(err, storage.ErrOrderClosed), err)
+
+	// cancelling gives the stock back, the shipped order keeps its stock
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: cancelledId, Status: models.OrderStatusCancelled})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stockOf(t, store, s.storeId, s.productId))
+
+	rows, err := store.Order().Update(ctx, moved)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	order, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: cancelledId})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, order.OrderStatus)
+	assert.Len(t, order.OrderItems, 1)
+}
+
+func testOrderList(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	kashaId, err := store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Kasha", LastName: "Todd", Email: "kasha.todd@yahoo.com"})
+	require.NoError(t, err)
+
+	ids := make([]int, 3)
+	for i, customerId := range []int{s.customerId, kashaId, s.customerId} {
+		ids[i], err = store.Order().Create(ctx, &models.CreateOrder{CustomerId: customerId, RequiredDate: []string{"2030-03-01", "2030-01-01", "2030-02-01"}[i], StoreId: s.storeId, StaffId: s.staffId})
+		require.NoError(t, err)
+	}
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: ids[2], Status: models.OrderStatusProcessing})
+	require.NoError(t, err)
+
+	orderIds := func(resp *models.GetListOrderResponse) []int {
+		var ids []int
+		for _, order := range resp.Orders {
+			ids = append(ids, order.OrderId)
+		}
+		return ids
+	}
+
+	list, err := store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.Count)
+	assert.Equal(t, ids, orderIds(list))
+	for _, order := range list.Orders {
+		require.NotNil(t, order.CustomerData)
+		require.NotNil(t, order.StoreData)
+		require.NotNil(t, order.StaffData)
+	}
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, CustomerId: s.customerId})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[0], ids[2]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, Search: "kasha"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, OrderStatus: models.OrderStatusProcessing})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[2]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, RequiredDateFrom: "2030-02-01"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[0], ids[2]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, Sort: "required_date"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1], ids[2], ids[0]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, Sort: "customer_name,-order_id"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[2], ids[0], ids[1]}, orderIds(list))
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.Count)
+	assert.Equal(t, ids[:2], orderIds(list))
+	require.NotEmpty(t, list.NextCursor)
+
+	list, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 2, After: list.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, ids[2:], orderIds(list))
+	assert.Empty(t, list.NextCursor)
+
+	_, err = store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10, Sort: "password"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidSort), err)
+}
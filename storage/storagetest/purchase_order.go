@@ -0,0 +1,259 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testSupplier(t *testing.T, store storage.StorageI) {
+	id, err := store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Trek Bicycle", ContactName: "Ann Baker", Phone: "(920) 478-2191", Email: "orders@trekbikes.com", City: "Waterloo", State: "WI", ZipCode: "53594"})
+	require.NoError(t, err)
+	otherId, err := store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Surly", Email: "sales@surlybikes.com"})
+	require.NoError(t, err)
+
+	_, err = store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Trek Europe", Email: "orders@trekbikes.com"})
+	assertPgCode(t, err, "23505")
+
+	supplier, err := store.Supplier().GetById(ctx, &models.SupplierPrimaryKey{SupplierId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Trek Bicycle", supplier.SupplierName)
+	assert.Equal(t, "Ann Baker", supplier.ContactName)
+	assert.Equal(t, "53594", supplier.ZipCode)
+
+	other, err := store.Supplier().GetById(ctx, &models.SupplierPrimaryKey{SupplierId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, "", other.ContactName)
+
+	_, err = store.Supplier().GetById(ctx, &models.SupplierPrimaryKey{SupplierId: otherId + 100})
+	assertNotFound(t, err)
+
+	for _, search := range []string{"trek", "ann b", "@TREKBIKES"} {
+		list, err := store.Supplier().GetList(ctx, &models.GetListSupplierRequest{Limit: 10, Search: search})
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Count, search)
+	}
+
+	rows, err := store.Supplier().Update(ctx, &models.UpdateSupplier{SupplierId: otherId, SupplierName: "Surly Bikes", Email: "orders@trekbikes.com"})
+	assertPgCode(t, err, "23505")
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = store.Supplier().Update(ctx, &models.UpdateSupplier{SupplierId: otherId, SupplierName: "Surly Bikes", Email: "sales@surlybikes.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = store.Supplier().Update(ctx, &models.UpdateSupplier{SupplierId: otherId + 100, SupplierName: "Surly Bikes", Email: "other@surlybikes.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// a supplier with purchase orders can not be deleted
+	s := seedShop(t, store)
+	_, err = store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: id, Items: []*models.CreatePurchaseOrderItem{{StoreId: s.storeId, ProductId: s.productId, Quantity: 1}}})
+	require.NoError(t, err)
+
+	_, err = store.Supplier().Delete(ctx, &models.SupplierPrimaryKey{SupplierId: id})
+	assertPgCode(t, err, "23503")
+
+	rows, err = store.Supplier().Delete(ctx, &models.SupplierPrimaryKey{SupplierId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = store.Supplier().Delete(ctx, &models.SupplierPrimaryKey{SupplierId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testPurchaseOrder(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	supplierId, err := store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Trek Bicycle", Email: "orders@trekbikes.com"})
+	require.NoError(t, err)
+
+	line := func(quantity int) *models.CreatePurchaseOrderItem {
+		return &models.CreatePurchaseOrderItem{StoreId: s.storeId, ProductId: s.productId, Quantity: quantity, UnitCost: 250.5}
+	}
+
+	id, err := store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, ExpectedDate: "2030-01-01", Items: []*models.CreatePurchaseOrderItem{line(10)}})
+	require.NoError(t, err)
+
+	purchaseOrder, err := store.PurchaseOrder().GetById(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusDraft, purchaseOrder.Status)
+	assert.NotEmpty(t, purchaseOrder.OrderDate)
+	assert.Equal(t, "2030-01-01 00:00:00", purchaseOrder.ExpectedDate)
+	require.NotNil(t, purchaseOrder.SupplierData)
+	assert.Equal(t, "Trek Bicycle", purchaseOrder.SupplierData.SupplierName)
+	require.Len(t, purchaseOrder.Items, 1)
+	assert.Equal(t, &models.PurchaseOrderItem{PurchaseOrderId: id, ItemId: 1, StoreId: s.storeId, ProductId: s.productId, Quantity: 10, UnitCost: 250.5}, purchaseOrder.Items[0])
+
+	_, err = store.PurchaseOrder().GetById(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id + 100})
+	assertNotFound(t, err)
+
+	// a broken item rolls the whole purchase order back
+	_, err = store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId + 100, Items: []*models.CreatePurchaseOrderItem{line(1)}})
+	assertPgCode(t, err, "23503")
+	_, err = store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{line(1), {StoreId: s.storeId, ProductId: s.productId + 100, Quantity: 1}}})
+	assertPgCode(t, err, "23503")
+	_, err = store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{line(1), line(2)}})
+	assertPgCode(t, err, "23505")
+	_, err = store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{line(0)}})
+	assert.True(t, errors.Is(err, storage.ErrInvalidQuantity), err)
+
+	list, err := store.PurchaseOrder().GetList(ctx, &models.GetListPurchaseOrderRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: id, Status: models.PurchaseOrderStatusReceived})
+	assert.True(t, errors.Is(err, storage.ErrInvalidStatusTransition), err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: id + 100, Status: models.PurchaseOrderStatusOrdered})
+	assertNotFound(t, err)
+
+	cancelledId, err := store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{line(3)}})
+	require.NoError(t, err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: cancelledId, Status: models.PurchaseOrderStatusCancelled})
+	require.NoError(t, err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: cancelledId, Status: models.PurchaseOrderStatusOrdered})
+	assert.True(t, errors.Is(err, storage.ErrInvalidStatusTransition), err)
+
+	list, err = store.PurchaseOrder().GetList(ctx, &models.GetListPurchaseOrderRequest{Limit: 10, Status: models.PurchaseOrderStatusCancelled})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Count)
+	assert.Equal(t, cancelledId, list.PurchaseOrders[0].PurchaseOrderId)
+
+	list, err = store.PurchaseOrder().GetList(ctx, &models.GetListPurchaseOrderRequest{Limit: 10, SupplierId: supplierId + 100})
+	require.NoError(t, err)
+	assert.Equal(t, 0, list.Count)
+	assert.Empty(t, list.PurchaseOrders)
+
+	// deleting a purchase order does not touch the stock
+	rows, err := store.PurchaseOrder().Delete(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: cancelledId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	_, err = store.PurchaseOrder().GetStatusHistory(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: cancelledId})
+	assertNotFound(t, err)
+
+	rows, err = store.PurchaseOrder().Delete(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: cancelledId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+	assert.Equal(t, 5, stockOf(t, store, s.storeId, s.productId))
+
+	// deleting the product or the store takes the items of its purchase orders with it
+	otherId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+	cascadeId, err := store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{line(1), {StoreId: otherId, ProductId: s.productId, Quantity: 1}}})
+	require.NoError(t, err)
+
+	_, err = store.Store().Delete(ctx, &models.StorePrimaryKey{StoreId: otherId})
+	require.NoError(t, err)
+
+	purchaseOrder, err = store.PurchaseOrder().GetById(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: cascadeId})
+	require.NoError(t, err)
+	require.Len(t, purchaseOrder.Items, 1)
+	assert.Equal(t, s.storeId, purchaseOrder.Items[0].StoreId)
+}
+
+func testPurchaseOrderReceive(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	userId, err := store.User().Create(ctx, &models.CreateUser{Username: "fabiola", Password: "hash"})
+	require.NoError(t, err)
+	supplierId, err := store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Trek Bicycle", Email: "orders@trekbikes.com"})
+	require.NoError(t, err)
+	otherId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+
+	id, err := store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, CreatedBy: userId, Items: []*models.CreatePurchaseOrderItem{
+		{StoreId: s.storeId, ProductId: s.productId, Quantity: 10, UnitCost: 250},
+		{StoreId: otherId, ProductId: s.productId, Quantity: 4, UnitCost: 250},
+	}})
+	require.NoError(t, err)
+
+	receive := func(items ...*models.ReceivePurchaseOrderItem) error {
+		return store.PurchaseOrder().Receive(ctx, &models.ReceivePurchaseOrder{PurchaseOrderId: id, Items: items, ReceivedBy: userId, Comment: "delivery"})
+	}
+
+	// drafts are not ordered yet
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 1})
+	assert.True(t, errors.Is(err, storage.ErrOrderClosed), err)
+
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: id, Status: models.PurchaseOrderStatusOrdered, ChangedBy: userId})
+	require.NoError(t, err)
+
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 9, stockOf(t, store, s.storeId, s.productId))
+
+	purchaseOrder, err := store.PurchaseOrder().GetById(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusPartiallyReceived, purchaseOrder.Status)
+	assert.Equal(t, 4, purchaseOrder.Items[0].ReceivedQuantity)
+	assert.Equal(t, 0, purchaseOrder.Items[1].ReceivedQuantity)
+
+	// a cancelled partial receipt changes nothing
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 7})
+	assert.True(t, errors.Is(err, storage.ErrOverReceived), err)
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 3}, &models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 4})
+	assert.True(t, errors.Is(err, storage.ErrOverReceived), err)
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 1}, &models.ReceivePurchaseOrderItem{ItemId: 3, Quantity: 1})
+	assertNotFound(t, err)
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 1}, &models.ReceivePurchaseOrderItem{ItemId: 2, Quantity: 0})
+	assert.True(t, errors.Is(err, storage.ErrInvalidQuantity), err)
+	assert.Equal(t, 9, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, -1, stockOf(t, store, otherId, s.productId))
+
+	// receiving stock a store does not carry yet adds it
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 2, Quantity: 4}, &models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 15, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 4, stockOf(t, store, otherId, s.productId))
+
+	purchaseOrder, err = store.PurchaseOrder().GetById(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderStatusReceived, purchaseOrder.Status)
+
+	err = receive(&models.ReceivePurchaseOrderItem{ItemId: 1, Quantity: 1})
+	assert.True(t, errors.Is(err, storage.ErrOrderClosed), err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: id, Status: models.PurchaseOrderStatusCancelled})
+	assert.True(t, errors.Is(err, storage.ErrInvalidStatusTransition), err)
+
+	history, err := store.PurchaseOrder().GetStatusHistory(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	require.NoError(t, err)
+	require.Equal(t, 4, history.Count)
+	for i, status := range []int16{models.PurchaseOrderStatusDraft, models.PurchaseOrderStatusOrdered, models.PurchaseOrderStatusPartiallyReceived, models.PurchaseOrderStatusReceived} {
+		assert.Equal(t, status, history.History[i].ToStatus)
+		assert.Equal(t, userId, history.History[i].ChangedBy)
+	}
+	assert.Equal(t, int16(0), history.History[0].FromStatus)
+	assert.Equal(t, "delivery", history.History[3].Comment)
+
+	// goods receipts are no order movements
+	reconciliation, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+
+	list, err := store.PurchaseOrder().GetList(ctx, &models.GetListPurchaseOrderRequest{Limit: 10, StoreId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	// the rest of a partially received purchase order can be given up
+	shortId, err := store.PurchaseOrder().Create(ctx, &models.CreatePurchaseOrder{SupplierId: supplierId, Items: []*models.CreatePurchaseOrderItem{{StoreId: s.storeId, ProductId: s.productId, Quantity: 2}}})
+	require.NoError(t, err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: shortId, Status: models.PurchaseOrderStatusOrdered})
+	require.NoError(t, err)
+	err = store.PurchaseOrder().Receive(ctx, &models.ReceivePurchaseOrder{PurchaseOrderId: shortId, Items: []*models.ReceivePurchaseOrderItem{{ItemId: 1, Quantity: 1}}})
+	require.NoError(t, err)
+	err = store.PurchaseOrder().ChangeStatus(ctx, &models.ChangePurchaseOrderStatus{PurchaseOrderId: shortId, Status: models.PurchaseOrderStatusReceived})
+	require.NoError(t, err)
+	assert.Equal(t, 16, stockOf(t, store, s.storeId, s.productId))
+
+	// received goods stay in stock when the purchase order is deleted
+	rows, err := store.PurchaseOrder().Delete(ctx, &models.PurchaseOrderPrimaryKey{PurchaseOrderId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, 16, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 4, stockOf(t, store, otherId, s.productId))
+}
@@ -0,0 +1,92 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testReport(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	receiverId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: receiverId, ProductId: s.productId, Quantity: 1})
+	require.NoError(t, err)
+
+	err = store.Report().SendProduct(ctx, &models.SendProduct{SenderId: s.storeId, ReceiverId: receiverId, ProductId: s.productId, Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stockOf(t, store, s.storeId, s.productId))
+	assert.Equal(t, 3, stockOf(t, store, receiverId, s.productId))
+
+	err = store.Report().SendProduct(ctx, &models.SendProduct{SenderId: s.storeId, ReceiverId: receiverId, ProductId: s.productId, Quantity: 4})
+	assert.True(t, errors.Is(err, storage.ErrNotEnoughStock), err)
+
+	err = store.Report().SendProduct(ctx, &models.SendProduct{SenderId: s.storeId, ReceiverId: receiverId, ProductId: s.productId})
+	assert.True(t, errors.Is(err, storage.ErrInvalidQuantity), err)
+
+	err = store.Report().SendProduct(ctx, &models.SendProduct{SenderId: s.storeId, ReceiverId: receiverId, ProductId: s.productId + 100, Quantity: 1})
+	assertNotFound(t, err)
+
+	place := s.placeOrder(2)
+	place.Items[0].Discount = 0.1
+	orderId, err := store.Order().Place(ctx, place)
+	require.NoError(t, err)
+
+	report, err := store.Report().StaffReport(ctx, &models.StaffListRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Count)
+	row := report.StaffReport[0]
+	assert.Equal(t, "Fabiola Jackson", row.StaffName)
+	assert.Equal(t, "Road Bikes", row.CategoryName)
+	assert.Equal(t, "Domane", row.ProductName)
+	assert.Equal(t, 2, row.Quantity)
+	assert.InDelta(t, 759.98, row.TotalSum, 0.001)
+	assert.Equal(t, "Santa Cruz Bikes", row.StoreName)
+	assert.Len(t, row.OrderDate, len("2006-01-02"))
+
+	report, err = store.Report().StaffReport(ctx, &models.StaffListRequest{Limit: 10, StoreId: receiverId})
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Count)
+
+	report, err = store.Report().StaffReport(ctx, &models.StaffListRequest{Limit: 10, Search: "fabiola"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Count)
+
+	_, err = store.Promocode().Create(ctx, &models.CreatePromocode{PromocodeName: "TENOFF", Discount: 10, DiscountType: 2})
+	require.NoError(t, err)
+	_, err = store.Promocode().Create(ctx, &models.CreatePromocode{PromocodeName: "BIG50", Discount: 50, DiscountType: 1, OrderLimitPrice: 1000})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		promocode string
+		total     string
+	}{
+		{"", "759.98"},
+		{"tenoff", "683.98"},
+		{"BIG50", "759.98"},
+	} {
+		total, err := store.Report().OrderTotalSum(ctx, &models.OrderTotalSum{OrderId: orderId, PromocodeName: test.promocode})
+		require.NoError(t, err)
+		assert.Equal(t, test.total, total, test.promocode)
+	}
+
+	_, err = store.Report().OrderTotalSum(ctx, &models.OrderTotalSum{OrderId: orderId + 100})
+	assertNotFound(t, err)
+
+	reconciliation, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+	assert.Empty(t, reconciliation.Drifts)
+
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: orderId, Status: models.OrderStatusCancelled})
+	require.NoError(t, err)
+
+	reconciliation, err = store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+}
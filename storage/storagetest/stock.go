@@ -0,0 +1,81 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testStock(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	otherId, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+
+	storeId, err := store.Stock().Create(ctx, &models.CreateStock{StoreId: otherId, ProductId: s.productId, Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, otherId, storeId)
+
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: otherId, ProductId: s.productId, Quantity: 2})
+	assertPgCode(t, err, "23505")
+
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: otherId, ProductId: s.productId + 100, Quantity: 2})
+	assertPgCode(t, err, "23503")
+
+	stock, err := store.Stock().GetById(ctx, &models.StockPrimaryKey{StoreId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, otherId, stock.StoreId)
+	assert.Equal(t, 2, stock.Quantity)
+	require.Len(t, stock.Products, 1)
+	assert.Equal(t, "Domane", stock.Products[0].ProductName)
+	assert.Equal(t, 2, stock.Products[0].Quantity)
+
+	_, err = store.Stock().GetById(ctx, &models.StockPrimaryKey{StoreId: otherId + 100})
+	assertNotFound(t, err)
+
+	list, err := store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+
+	list, err = store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Stocks, 1)
+	assert.Equal(t, s.storeId, list.Stocks[0].StoreId)
+	assert.NotEmpty(t, list.NextCursor)
+
+	list, err = store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 1, After: list.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, list.Stocks, 1)
+	assert.Equal(t, otherId, list.Stocks[0].StoreId)
+	assert.Empty(t, list.NextCursor)
+
+	list, err = store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 10, StoreId: otherId})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	_, err = store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 10, After: "not a cursor"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidCursor))
+
+	rows, err := store.Stock().Update(ctx, &models.UpdateStock{StoreId: otherId, ProductId: s.productId, Quantity: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, 7, stockOf(t, store, otherId, s.productId))
+
+	rows, err = store.Stock().Update(ctx, &models.UpdateStock{StoreId: otherId, ProductId: s.productId + 100, Quantity: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = store.Stock().Delete(ctx, &models.StockPrimaryKey{StoreId: otherId, ProductId: s.productId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, -1, stockOf(t, store, otherId, s.productId))
+
+	rows, err = store.Stock().Delete(ctx, &models.StockPrimaryKey{StoreId: s.storeId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, -1, stockOf(t, store, s.storeId, s.productId))
+}
@@ -0,0 +1,146 @@
+// Package storagetest is the contract every storage.StorageI implementation keeps. A backend runs
+// it from a test with a factory that hands out an empty storage for every test:
+//
+//	storagetest.Run(t, func(t *testing.T) storage.StorageI {
+//		return memory.NewStorage()
+//	})
+//
+// The tests only rely on ids returned by the storage, never on their values, so a backend may
+// keep its sequences between tests.
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+// Factory returns an empty storage. It is called once per test.
+type Factory func(t *testing.T) storage.StorageI
+
+var tests = []struct {
+	name string
+	run  func(t *testing.T, store storage.StorageI)
+}{
+	{"Category", testCategory},
+	{"Brand", testBrand},
+	{"Product", testProduct},
+	{"ProductList", testProductList},
+	{"Stock", testStock},
+	{"Import", testImport},
+	{"Export", testExport},
+	{"Store", testStore},
+	{"Customer", testCustomer},
+	{"Staff", testStaff},
+	{"Order", testOrder},
+	{"PlaceOrder", testPlaceOrder},
+	{"OrderItems", testOrderItems},
+	{"OrderStatus", testOrderStatus},
+	{"OrderList", testOrderList},
+	{"Supplier", testSupplier},
+	{"PurchaseOrder", testPurchaseOrder},
+	{"PurchaseOrderReceive", testPurchaseOrderReceive},
+	{"Promocode", testPromocode},
+	{"Report", testReport},
+	{"User", testUser},
+	{"RefreshToken", testRefreshToken},
+	{"ConcurrentCreates", testConcurrentCreates},
+	{"ConcurrentPlace", testConcurrentPlace},
+}
+
+// Run runs every contract test against storages made by factory.
+func Run(t *testing.T, factory Factory) {
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, factory(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// assertNotFound checks err is pgx.ErrNoRows, which handlers turn into a 404.
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	assert.True(t, errors.Is(err, pgx.ErrNoRows), "want pgx.ErrNoRows, got %v", err)
+}
+
+// assertPgCode checks err is a *pgconn.PgError with the given SQLSTATE code.
+func assertPgCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var pgErr *pgconn.PgError
+	if assert.True(t, errors.As(err, &pgErr), "want a *pgconn.PgError, got %v", err) {
+		assert.Equal(t, code, pgErr.Code)
+	}
+}
+
+// shop is one store with a staff member, a customer and a product with 5 in stock.
+type shop struct {
+	categoryId int
+	brandId    int
+	productId  int
+	storeId    int
+	staffId    int
+	customerId int
+}
+
+func seedShop(t *testing.T, store storage.StorageI) *shop {
+	t.Helper()
+
+	s := &shop{}
+
+	var err error
+	s.categoryId, err = store.Category().Create(ctx, &models.CreateCategory{CategoryName: "Road Bikes"})
+	require.NoError(t, err)
+	s.brandId, err = store.Brand().Create(ctx, &models.CreateBrand{BrandName: "Trek"})
+	require.NoError(t, err)
+	s.productId, err = store.Product().Create(ctx, &models.CreateProduct{ProductName: "Domane", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2018, ListPrice: 379.99})
+	require.NoError(t, err)
+	s.storeId, err = store.Store().Create(ctx, &models.CreateStore{StoreName: "Santa Cruz Bikes", Email: "santacruz@bikes.shop"})
+	require.NoError(t, err)
+	s.staffId, err = store.Staff().Create(ctx, &models.CreateStaff{FirstName: "Fabiola", LastName: "Jackson", Email: "fabiola.jackson@bikes.shop", Active: 1, StoreId: s.storeId})
+	require.NoError(t, err)
+	s.customerId, err = store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Debra", LastName: "Burks", Email: "debra.burks@yahoo.com"})
+	require.NoError(t, err)
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: s.storeId, ProductId: s.productId, Quantity: 5})
+	require.NoError(t, err)
+
+	return s
+}
+
+func (s *shop) placeOrder(quantity int) *models.PlaceOrder {
+	return &models.PlaceOrder{
+		CustomerId:   s.customerId,
+		RequiredDate: "2030-01-01",
+		StoreId:      s.storeId,
+		StaffId:      s.staffId,
+		Items:        []*models.PlaceOrderItem{{ProductId: s.productId, Quantity: quantity}},
+	}
+}
+
+// stockOf returns how many of the product the store has, -1 when it has no stock row.
+func stockOf(t *testing.T, store storage.StorageI, storeId, productId int) int {
+	t.Helper()
+
+	stock, err := store.Stock().GetById(ctx, &models.StockPrimaryKey{StoreId: storeId})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return -1
+	}
+	require.NoError(t, err)
+
+	for _, product := range stock.Products {
+		if product.ProductId == productId {
+			return product.Quantity
+		}
+	}
+	return -1
+}
@@ -0,0 +1,184 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"errors"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testStore(t *testing.T, store storage.StorageI) {
+	id, err := store.Store().Create(ctx, &models.CreateStore{StoreName: "Santa Cruz Bikes", Phone: "(831) 476-4321", Email: "santacruz@bikes.shop", Street: "3700 Portola Drive", City: "Santa Cruz", State: "CA", ZipCode: "95060"})
+	require.NoError(t, err)
+	_, err = store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+
+	got, err := store.Store().GetById(ctx, &models.StorePrimaryKey{StoreId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Santa Cruz Bikes", got.StoreName)
+	assert.Equal(t, "Santa Cruz", got.City)
+	assert.Equal(t, "95060", got.ZipCode)
+
+	list, err := store.Store().GetList(ctx, &models.GetListStoreRequest{Limit: 10, Search: "baldwin"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	rows, err := store.Store().Update(ctx, &models.UpdateStore{StoreId: id, StoreName: "Rowlett Bikes", Email: "rowlett@bikes.shop"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	got, err = store.Store().GetById(ctx, &models.StorePrimaryKey{StoreId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Rowlett Bikes", got.StoreName)
+
+	// deleting a store takes its staff, orders and stock with it
+	s := seedShop(t, store)
+	orderId, err := store.Order().Place(ctx, s.placeOrder(1))
+	require.NoError(t, err)
+
+	rows, err = store.Store().Delete(ctx, &models.StorePrimaryKey{StoreId: s.storeId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Staff().GetById(ctx, &models.StaffPrimaryKey{StaffId: s.staffId})
+	assertNotFound(t, err)
+	_, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: orderId})
+	assertNotFound(t, err)
+	_, err = store.Stock().GetById(ctx, &models.StockPrimaryKey{StoreId: s.storeId})
+	assertNotFound(t, err)
+
+	rows, err = store.Store().Delete(ctx, &models.StorePrimaryKey{StoreId: s.storeId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testCustomer(t *testing.T, store storage.StorageI) {
+	id, err := store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Debra", LastName: "Burks", Email: "debra.burks@yahoo.com", City: "Orchard Park", State: "NY", ZipCode: 14127})
+	require.NoError(t, err)
+	_, err = store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Kasha", LastName: "Todd", Email: "kasha.todd@yahoo.com"})
+	require.NoError(t, err)
+
+	customer, err := store.Customer().GetById(ctx, &models.CustomerPrimaryKey{CustomerId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Debra", customer.FirstName)
+	assert.Equal(t, float64(14127), customer.ZipCode)
+
+	for _, search := range []string{"debra burks", "BURKS", "debra.burks@"} {
+		list, err := store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: 10, Search: search})
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Count, search)
+	}
+
+	list, err := store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	require.NotEmpty(t, list.NextCursor)
+
+	list, err = store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: 1, After: list.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, list.Customers, 1)
+	assert.Equal(t, "Kasha", list.Customers[0].FirstName)
+
+	_, err = store.Customer().GetList(ctx, &models.GetListCustomerRequest{Limit: 1, After: "!"})
+	assert.True(t, errors.Is(err, storage.ErrInvalidCursor))
+
+	rows, err := store.Customer().Update(ctx, &models.UpdateCustomer{CustomerId: id, FirstName: "Debra", LastName: "Todd", Email: "debra.todd@yahoo.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	customer, err = store.Customer().GetById(ctx, &models.CustomerPrimaryKey{CustomerId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Todd", customer.LastName)
+
+	rows, err = store.Customer().Delete(ctx, &models.CustomerPrimaryKey{CustomerId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Customer().GetById(ctx, &models.CustomerPrimaryKey{CustomerId: id})
+	assertNotFound(t, err)
+}
+
+func testStaff(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	id, err := store.Staff().Create(ctx, &models.CreateStaff{FirstName: "Mireya", LastName: "Copeland", Email: "mireya.copeland@bikes.shop", Active: 1, StoreId: s.storeId, ManagerId: s.staffId})
+	require.NoError(t, err)
+
+	staff, err := store.Staff().GetById(ctx, &models.StaffPrimaryKey{StaffId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Mireya", staff.FirstName)
+	assert.Equal(t, s.staffId, staff.ManagerId)
+	require.NotNil(t, staff.StoreData)
+	assert.Equal(t, "Santa Cruz Bikes", staff.StoreData.StoreName)
+
+	_, err = store.Staff().Create(ctx, &models.CreateStaff{FirstName: "Mireya", Email: "mireya.copeland@bikes.shop", StoreId: s.storeId})
+	assertPgCode(t, err, "23505")
+
+	_, err = store.Staff().Create(ctx, &models.CreateStaff{FirstName: "Genna", Email: "genna@bikes.shop", StoreId: s.storeId + 100})
+	assertPgCode(t, err, "23503")
+
+	list, err := store.Staff().GetList(ctx, &models.GetListStaffRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Count)
+	for _, staff := range list.Staffs {
+		require.NotNil(t, staff.StoreData)
+		assert.Equal(t, s.storeId, staff.StoreData.StoreId)
+	}
+
+	list, err = store.Staff().GetList(ctx, &models.GetListStaffRequest{Limit: 10, Search: "mireya cope"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	rows, err := store.Staff().Update(ctx, &models.UpdateStaff{StaffId: id, FirstName: "Mireya", LastName: "Copeland", Email: "mireya@bikes.shop", Active: 0, StoreId: s.storeId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	staff, err = store.Staff().GetById(ctx, &models.StaffPrimaryKey{StaffId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "mireya@bikes.shop", staff.Email)
+	assert.Equal(t, 0, staff.ManagerId)
+
+	// a staff member with orders cannot be deleted
+	_, err = store.Order().Place(ctx, s.placeOrder(1))
+	require.NoError(t, err)
+	_, err = store.Staff().Delete(ctx, &models.StaffPrimaryKey{StaffId: s.staffId})
+	assertPgCode(t, err, "23503")
+
+	rows, err = store.Staff().Delete(ctx, &models.StaffPrimaryKey{StaffId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Staff().GetById(ctx, &models.StaffPrimaryKey{StaffId: id})
+	assertNotFound(t, err)
+}
+
+func testPromocode(t *testing.T, store storage.StorageI) {
+	id, err := store.Promocode().Create(ctx, &models.CreatePromocode{PromocodeName: "SPRING", Discount: 10, DiscountType: 2})
+	require.NoError(t, err)
+	_, err = store.Promocode().Create(ctx, &models.CreatePromocode{PromocodeName: "BIGSALE", Discount: 50, DiscountType: 1, OrderLimitPrice: 500})
+	require.NoError(t, err)
+
+	promocode, err := store.Promocode().GetById(ctx, &models.PromocodePrimaryKey{PromocodeId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "SPRING", promocode.PromocodeName)
+	assert.Equal(t, float64(10), promocode.Discount)
+	assert.Equal(t, 2, promocode.DiscountType)
+
+	list, err := store.Promocode().GetList(ctx, &models.GetListPromocodeRequest{Limit: 10, Search: "sale"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	rows, err := store.Promocode().Delete(ctx, &models.PromocodePrimaryKey{PromocodeId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Promocode().GetById(ctx, &models.PromocodePrimaryKey{PromocodeId: id})
+	assertNotFound(t, err)
+
+	rows, err = store.Promocode().Delete(ctx, &models.PromocodePrimaryKey{PromocodeId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
@@ -0,0 +1,135 @@
+package storagetest
+
+import (
+	"app/api/models"
+	"app/storage"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func testUser(t *testing.T, store storage.StorageI) {
+	s := seedShop(t, store)
+
+	id, err := store.User().Create(ctx, &models.CreateUser{Username: "fabiola", Password: "hash"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	_, err = store.User().Create(ctx, &models.CreateUser{Username: "fabiola", Password: "hash"})
+	assertPgCode(t, err, "23505")
+
+	adminId, err := store.User().Create(ctx, &models.CreateUser{Username: "penelope", Password: "hash", Role: models.RoleAdmin})
+	require.NoError(t, err)
+	admin, err := store.User().GetById(ctx, &models.UserPrimaryKey{UserId: adminId})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, admin.Role)
+
+	user, err := store.User().GetById(ctx, &models.UserPrimaryKey{UserId: id})
+	require.NoError(t, err)
+	assert.Equal(t, &models.User{UserId: id, Username: "fabiola", Password: "hash", Role: models.RoleReadOnly}, user)
+
+	user, err = store.User().GetById(ctx, &models.UserPrimaryKey{Username: "fabiola"})
+	require.NoError(t, err)
+	assert.Equal(t, id, user.UserId)
+
+	_, err = store.User().GetById(ctx, &models.UserPrimaryKey{Username: "mireya"})
+	assertNotFound(t, err)
+
+	rows, err := store.User().UpdatePassword(ctx, &models.UpdateUserPassword{UserId: id, Password: "rehashed"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = store.User().UpdateRole(ctx, &models.UpdateUserRole{UserId: id, Role: models.RoleStoreManager})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.User().UpdateRole(ctx, &models.UpdateUserRole{UserId: id, Role: "owner"})
+	assertPgCode(t, err, "23514")
+
+	rows, err = store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: id, StaffId: s.staffId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	user, err = store.User().GetById(ctx, &models.UserPrimaryKey{UserId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "rehashed", user.Password)
+	assert.Equal(t, models.RoleStoreManager, user.Role)
+	assert.Equal(t, s.staffId, user.StaffId)
+	assert.Equal(t, s.storeId, user.StoreId)
+
+	otherId, err := store.User().Create(ctx, &models.CreateUser{Username: "mireya", Password: "hash"})
+	require.NoError(t, err)
+
+	_, err = store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: otherId, StaffId: s.staffId})
+	assertPgCode(t, err, "23505")
+
+	_, err = store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: otherId, StaffId: s.staffId + 100})
+	assertPgCode(t, err, "23503")
+
+	rows, err = store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	user, err = store.User().GetById(ctx, &models.UserPrimaryKey{UserId: id})
+	require.NoError(t, err)
+	assert.Equal(t, 0, user.StaffId)
+	assert.Equal(t, 0, user.StoreId)
+
+	rows, err = store.User().UpdateRole(ctx, &models.UpdateUserRole{UserId: "00000000-0000-0000-0000-000000000000", Role: models.RoleAdmin})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func testRefreshToken(t *testing.T, store storage.StorageI) {
+	userId, err := store.User().Create(ctx, &models.CreateUser{Username: "fabiola", Password: "hash"})
+	require.NoError(t, err)
+
+	hash, err := store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "first", UserId: userId, ExpiresIn: 3600})
+	require.NoError(t, err)
+	assert.Equal(t, "first", hash)
+
+	_, err = store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "first", UserId: userId, ExpiresIn: 3600})
+	assertPgCode(t, err, "23505")
+
+	_, err = store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "orphan", UserId: "00000000-0000-0000-0000-000000000000", ExpiresIn: 3600})
+	assertPgCode(t, err, "23503")
+
+	_, err = store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "second", UserId: userId, ExpiresIn: 3600})
+	require.NoError(t, err)
+	_, err = store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "expired", UserId: userId, ExpiresIn: -1})
+	require.NoError(t, err)
+
+	token, err := store.RefreshToken().GetById(ctx, &models.RefreshTokenPrimaryKey{TokenHash: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, userId, token.UserId)
+	assert.NotEmpty(t, token.ExpiresAt)
+	assert.NotEmpty(t, token.CreatedAt)
+	assert.Empty(t, token.RevokedAt)
+
+	_, err = store.RefreshToken().GetById(ctx, &models.RefreshTokenPrimaryKey{TokenHash: "third"})
+	assertNotFound(t, err)
+
+	rows, err := store.RefreshToken().Revoke(ctx, &models.RefreshTokenPrimaryKey{TokenHash: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// a token is only revoked once, expired tokens are never honoured
+	for _, hash := range []string{"first", "expired", "third"} {
+		rows, err = store.RefreshToken().Revoke(ctx, &models.RefreshTokenPrimaryKey{TokenHash: hash})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), rows, hash)
+	}
+
+	token, err = store.RefreshToken().GetById(ctx, &models.RefreshTokenPrimaryKey{TokenHash: "first"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.RevokedAt)
+
+	rows, err = store.RefreshToken().RevokeByUser(ctx, &models.UserPrimaryKey{UserId: userId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+
+	rows, err = store.RefreshToken().RevokeByUser(ctx, &models.UserPrimaryKey{UserId: userId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
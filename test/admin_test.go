@@ -0,0 +1,138 @@
+package test
+
+import (
+	"app/api/models"
+	"app/pkg/admin"
+	"app/pkg/helper"
+	"app/storage/memory"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func TestAdminUsers(t *testing.T) {
+	ctx := context.Background()
+	cfg := testConfig()
+	store := memory.NewStorage()
+
+	id, err := admin.CreateAdmin(ctx, cfg, store, "root_admin", "password1")
+	require.NoError(t, err)
+
+	user, err := store.User().GetById(ctx, &models.UserPrimaryKey{UserId: id})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, user.Role)
+	match, _ := helper.CheckPassword(user.Password, "password1")
+	assert.True(t, match)
+
+	var pgErr *pgconn.PgError
+	_, err = admin.CreateAdmin(ctx, cfg, store, "root_admin", "password1")
+	if assert.True(t, errors.As(err, &pgErr), err) {
+		assert.Equal(t, "23505", pgErr.Code)
+	}
+
+	_, err = admin.CreateAdmin(ctx, cfg, store, "root", "password1")
+	assert.Equal(t, admin.ErrInvalidUsername, err)
+
+	_, err = admin.CreateAdmin(ctx, cfg, store, "other_admin", "short")
+	assert.Error(t, err)
+
+	_, err = store.RefreshToken().Create(ctx, &models.CreateRefreshToken{TokenHash: "session", UserId: id, ExpiresIn: 3600})
+	require.NoError(t, err)
+
+	err = admin.ResetPassword(ctx, cfg, store, "root_admin", "password2")
+	require.NoError(t, err)
+
+	user, err = store.User().GetById(ctx, &models.UserPrimaryKey{UserId: id})
+	require.NoError(t, err)
+	match, _ = helper.CheckPassword(user.Password, "password2")
+	assert.True(t, match)
+
+	token, err := store.RefreshToken().GetById(ctx, &models.RefreshTokenPrimaryKey{TokenHash: "session"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.RevokedAt)
+
+	assert.Error(t, admin.ResetPassword(ctx, cfg, store, "root_admin", "short"))
+	err = admin.ResetPassword(ctx, cfg, store, "nobody_here", "password2")
+	assert.True(t, errors.Is(err, pgx.ErrNoRows), err)
+}
+
+func TestAdminSeedAndPurge(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+
+	userId, err := admin.CreateAdmin(ctx, testConfig(), store, "root_admin", "password1")
+	require.NoError(t, err)
+
+	counts, err := admin.SeedFake(ctx, store, admin.FakeData{
+		Stores:        2,
+		StaffPerStore: 3,
+		Categories:    2,
+		Brands:        2,
+		Products:      10,
+		Customers:     20,
+		Orders:        30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, admin.Counts{
+		"categories": 2,
+		"brands":     2,
+		"products":   10,
+		"stores":     2,
+		"staffs":     6,
+		"stocks":     20,
+		"customers":  20,
+		"orders":     counts["orders"],
+	}, counts)
+	assert.True(t, counts["orders"] > 0)
+
+	orders, err := store.Order().GetList(ctx, &models.GetListOrderRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, counts["orders"], orders.Count)
+
+	reconciliation, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+
+	// a staff member bound to a user is unbound, the user stays
+	_, err = store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: userId, StaffId: orders.Orders[0].StaffId})
+	require.NoError(t, err)
+
+	counts, err = admin.Purge(ctx, store)
+	require.NoError(t, err)
+	assert.Equal(t, orders.Count, counts["orders"])
+	assert.Equal(t, 6, counts["staffs"])
+	assert.Equal(t, 2, counts["stores"])
+	assert.Equal(t, 20, counts["customers"])
+
+	for _, count := range []func() (int, error){
+		func() (int, error) {
+			list, err := store.Product().GetList(ctx, &models.GetListProductRequest{Limit: 10})
+			return list.Count, err
+		},
+		func() (int, error) {
+			list, err := store.Stock().GetList(ctx, &models.GetListStockRequest{Limit: 10})
+			return list.Count, err
+		},
+		func() (int, error) {
+			list, err := store.Brand().GetList(ctx, &models.GetListBrandRequest{Limit: 10})
+			return list.Count, err
+		},
+		func() (int, error) {
+			list, err := store.Staff().GetList(ctx, &models.GetListStaffRequest{Limit: 10})
+			return list.Count, err
+		},
+	} {
+		n, err := count()
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	}
+
+	user, err := store.User().GetById(ctx, &models.UserPrimaryKey{UserId: userId})
+	require.NoError(t, err)
+	assert.Equal(t, 0, user.StaffId)
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"app/api/handler"
+	"app/api/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestAuthHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	user := &models.CreateUser{Username: "fabiola", Password: "password1"}
+	refresh := &models.RefreshTokenRequest{}
+
+	s.run(t, []handlerCase{
+		{name: "register", method: http.MethodPost, path: "/v1/register", body: user, auth: anonymous, status: http.StatusCreated},
+		{name: "register taken username", method: http.MethodPost, path: "/v1/register", body: user, auth: anonymous, status: http.StatusConflict},
+		{name: "register invalid username", method: http.MethodPost, path: "/v1/register", body: &models.CreateUser{Username: "1ab", Password: "password1"}, auth: anonymous, status: http.StatusBadRequest},
+		{name: "register weak password", method: http.MethodPost, path: "/v1/register", body: &models.CreateUser{Username: "jackson", Password: "short"}, auth: anonymous, status: http.StatusBadRequest},
+		{name: "register with role", method: http.MethodPost, path: "/v1/register", body: map[string]string{"username": "genoveva", "password": "password1", "role": models.RoleAdmin}, auth: anonymous, status: http.StatusCreated},
+		{name: "login", method: http.MethodPost, path: "/v1/login", body: &models.LoginUser{Username: user.Username, Password: user.Password}, auth: anonymous, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var tokens models.TokenResponse
+				decode(t, data, &tokens)
+				assert.NotEmpty(t, tokens.AccessToken)
+				refresh.RefreshToken = tokens.RefreshToken
+			}},
+		{name: "login wrong password", method: http.MethodPost, path: "/v1/login", body: &models.LoginUser{Username: user.Username, Password: "password2"}, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "login unknown user", method: http.MethodPost, path: "/v1/login", body: &models.LoginUser{Username: "unknown", Password: "password1"}, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "refresh", method: http.MethodPost, path: "/v1/refresh", body: refresh, auth: anonymous, status: http.StatusOK},
+		{name: "refresh reused token", method: http.MethodPost, path: "/v1/refresh", body: refresh, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "refresh unknown token", method: http.MethodPost, path: "/v1/refresh", body: &models.RefreshTokenRequest{RefreshToken: "unknown"}, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "refresh without token", method: http.MethodPost, path: "/v1/refresh", body: &models.RefreshTokenRequest{}, auth: anonymous, status: http.StatusBadRequest},
+		{name: "invalid token", method: http.MethodGet, path: "/v1/brand", auth: header{Key: "Authorization", Value: "Bearer invalid"}, status: http.StatusUnauthorized},
+	})
+
+	// both failures give the same message, so it does not tell which usernames exist
+	for _, login := range []*models.LoginUser{
+		{Username: user.Username, Password: "password2"},
+		{Username: "unknown", Password: "password1"},
+	} {
+		var resp handler.Response
+		_, err := s.PerformRequest(http.MethodPost, "/v1/login", login, &resp, anonymous)
+		assert.NoError(t, err)
+		if assert.NotNil(t, resp.Error) {
+			assert.Equal(t, "username or password invalid", resp.Error.Message)
+		}
+	}
+
+	stored, err := s.store.User().GetById(context.Background(), &models.UserPrimaryKey{Username: "genoveva"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleReadOnly, stored.Role)
+
+	stored, err = s.store.User().GetById(context.Background(), &models.UserPrimaryKey{Username: user.Username})
+	assert.NoError(t, err)
+	role := fmt.Sprintf("/v1/user/%s/role", stored.UserId)
+	staff := fmt.Sprintf("/v1/user/%s/staff", stored.UserId)
+
+	readOnly := s.login(t, models.RoleReadOnly, 0)
+
+	s.run(t, []handlerCase{
+		{name: "update role", method: http.MethodPut, path: role, body: &models.UpdateUserRole{Role: models.RoleCashier}, status: http.StatusOK},
+		{name: "update role invalid role", method: http.MethodPut, path: role, body: &models.UpdateUserRole{Role: "owner"}, status: http.StatusBadRequest},
+		{name: "update role not found", method: http.MethodPut, path: "/v1/user/unknown/role", body: &models.UpdateUserRole{Role: models.RoleCashier}, status: http.StatusNotFound},
+		{name: "update role as read only", method: http.MethodPut, path: role, body: &models.UpdateUserRole{Role: models.RoleAdmin}, auth: readOnly, status: http.StatusForbidden},
+		{name: "update staff", method: http.MethodPut, path: staff, body: &models.UpdateUserStaff{StaffId: fixture.staffId}, status: http.StatusOK},
+		{name: "update staff unknown staff", method: http.MethodPut, path: staff, body: &models.UpdateUserStaff{StaffId: 99}, status: http.StatusUnprocessableEntity},
+		{name: "update staff not found", method: http.MethodPut, path: "/v1/user/unknown/staff", body: &models.UpdateUserStaff{StaffId: fixture.staffId}, status: http.StatusNotFound},
+		{name: "update staff invalid body", method: http.MethodPut, path: staff, body: "staff", status: http.StatusBadRequest},
+	})
+}
+
+func TestLogoutRevokesTokens(t *testing.T) {
+	s := newMemoryServer()
+	auth := s.login(t, models.RoleReadOnly, 0)
+
+	s.run(t, []handlerCase{
+		{name: "before logout", method: http.MethodGet, path: "/v1/brand", auth: auth, status: http.StatusOK},
+		{name: "logout", method: http.MethodPost, path: "/v1/logout", auth: auth, status: http.StatusOK},
+		{name: "after logout", method: http.MethodGet, path: "/v1/brand", auth: auth, status: http.StatusUnauthorized},
+		{name: "logout without token", method: http.MethodPost, path: "/v1/logout", auth: anonymous, status: http.StatusUnauthorized},
+	})
+
+	// the refresh token of a logged out session is revoked as well
+	tokens := &models.TokenResponse{}
+	_, err := s.PerformRequest(http.MethodPost, "/v1/login", &models.LoginUser{Username: "read_only_1", Password: "password1"}, &handler.Response{Data: tokens})
+	assert.NoError(t, err)
+
+	bearer := header{Key: "Authorization", Value: "Bearer " + tokens.AccessToken}
+	s.run(t, []handlerCase{
+		{name: "logout with refresh token", method: http.MethodPost, path: "/v1/logout", body: &models.LogoutUser{RefreshToken: tokens.RefreshToken}, auth: bearer, status: http.StatusOK},
+		{name: "refresh after logout", method: http.MethodPost, path: "/v1/refresh", body: &models.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}, auth: anonymous, status: http.StatusUnauthorized},
+	})
+}
@@ -1,7 +1,9 @@
 package test
 
 import (
+	"app/api/handler"
 	"app/api/models"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -42,7 +44,7 @@ func createBrand(t *testing.T) int {
 		BrandName: faker.FirstName(),
 	}
 
-	resp, err := PerformRequest(http.MethodPost, "/brand", &request, &response)
+	resp, err := PerformRequest(http.MethodPost, "/v1/brand", &request, &handler.Response{Data: response}, authHeader(t))
 
 	assert.NoError(t, err)
 
@@ -68,7 +70,7 @@ func updateBrand(t *testing.T, id string) int {
 		BrandName: faker.FirstName(),
 	}
 
-	resp, err := PerformRequest(http.MethodPut, "/brand/"+id, request, response)
+	resp, err := PerformRequest(http.MethodPut, "/v1/brand/"+id, request, &handler.Response{Data: response}, authHeader(t))
 
 	assert.NoError(t, err)
 
@@ -87,9 +89,10 @@ func deleteBrand(t *testing.T, id int) string {
 
 	resp, _ := PerformRequest(
 		http.MethodDelete,
-		fmt.Sprintf("/brand/%s", strconv.Itoa(id)),
+		fmt.Sprintf("/v1/brand/%s", strconv.Itoa(id)),
 		nil,
 		nil,
+		authHeader(t),
 	)
 
 	assert.NotNil(t, resp)
@@ -100,3 +103,33 @@ func deleteBrand(t *testing.T, id int) string {
 
 	return ""
 }
+
+func TestBrandHandlers(t *testing.T) {
+	s := newMemoryServer()
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/brand", body: &models.CreateBrand{BrandName: "Trek"}, status: http.StatusCreated},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/brand", body: "Trek", status: http.StatusBadRequest},
+		{name: "create without token", method: http.MethodPost, path: "/v1/brand", body: &models.CreateBrand{BrandName: "Trek"}, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "get", method: http.MethodGet, path: "/v1/brand/1", status: http.StatusOK},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/brand/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/brand/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/brand", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListBrandResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid offset", method: http.MethodGet, path: "/v1/brand?offset=-", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/v1/brand/1", body: &models.UpdateBrand{BrandName: "Electra"}, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var brand models.Brand
+				decode(t, data, &brand)
+				assert.Equal(t, "Electra", brand.BrandName)
+			}},
+		{name: "update invalid id", method: http.MethodPut, path: "/v1/brand/one", body: &models.UpdateBrand{BrandName: "Electra"}, status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/brand/99", body: &models.UpdateBrand{BrandName: "Electra"}, status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, path: "/v1/brand/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/brand/1", status: http.StatusNotFound},
+	})
+}
@@ -0,0 +1,214 @@
+package test
+
+import (
+	"app/api/models"
+	"app/config"
+	"app/pkg/logger"
+	"app/storage"
+	"app/storage/cached"
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/assert"
+)
+
+type mapEntityCache struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	versions map[string]int64
+}
+
+func newMapEntityCache() *mapEntityCache {
+	return &mapEntityCache{values: map[string][]byte{}, versions: map[string]int64{}}
+}
+
+func (c *mapEntityCache) Get(key string, dst interface{}) (bool, error) {
+	c.mu.Lock()
+	body, ok := c.values[key]
+	c.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(body, dst)
+}
+
+func (c *mapEntityCache) Set(key string, value interface{}, ttl time.Duration) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.values[key] = body
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *mapEntityCache) Version(entity string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.versions[entity], nil
+}
+
+func (c *mapEntityCache) Bump(entity string) error {
+	c.mu.Lock()
+	c.versions[entity]++
+	c.mu.Unlock()
+	return nil
+}
+
+// entityCache is a storage.StorageCacheI that only has the entity cache.
+type entityCache struct {
+	storage.StorageCacheI
+	entity storage.EntityCacheRepoI
+}
+
+func (c *entityCache) Entity() storage.EntityCacheRepoI { return c.entity }
+
+// failoverEntityCache switches its entity cache like the Redis CacheStore does on a ping.
+type failoverEntityCache struct {
+	storage.StorageCacheI
+	up     int32
+	redis  *mapEntityCache
+	memory *mapEntityCache
+}
+
+func (c *failoverEntityCache) Entity() storage.EntityCacheRepoI {
+	if atomic.LoadInt32(&c.up) == 1 {
+		return c.redis
+	}
+	return c.memory
+}
+
+// countingCategoryRepo is a slow category table that counts its reads.
+type countingCategoryRepo struct {
+	storage.CategoryRepoI
+	reads int64
+	name  string
+}
+
+func (r *countingCategoryRepo) GetById(ctx context.Context, req *models.CategoryPrimaryKey) (*models.Category, error) {
+	atomic.AddInt64(&r.reads, 1)
+	time.Sleep(20 * time.Millisecond)
+	return &models.Category{CategoryId: req.CategoryId, CategoryName: r.name}, nil
+}
+
+func (r *countingCategoryRepo) Update(ctx context.Context, req *models.UpdateCategory) (int64, error) {
+	r.name = req.CategoryName
+	return 1, nil
+}
+
+type fakeStorage struct {
+	storage.StorageI
+	category storage.CategoryRepoI
+}
+
+func (s *fakeStorage) Category() storage.CategoryRepoI   { return s.category }
+func (s *fakeStorage) Brand() storage.BrandRepoI         { return nil }
+func (s *fakeStorage) Store() storage.StoreRepoI         { return nil }
+func (s *fakeStorage) Customer() storage.CustomerRepoI   { return nil }
+func (s *fakeStorage) Staff() storage.StaffRepoI         { return nil }
+func (s *fakeStorage) Promocode() storage.PromocodeRepoI { return nil }
+
+func newCachedCategories(repo storage.CategoryRepoI) storage.StorageI {
+	cfg := config.Config{CategoryCacheTTL: time.Minute}
+	return cached.NewStorage(&fakeStorage{category: repo}, &entityCache{entity: newMapEntityCache()}, &cfg, logger.NewLogger("test", logger.LevelError))
+}
+
+func TestCachedReadThroughSingleFlight(t *testing.T) {
+	repo := &countingCategoryRepo{name: "Road Bikes"}
+	store := newCachedCategories(repo)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			category, err := store.Category().GetById(context.Background(), &models.CategoryPrimaryKey{CategoryId: 1})
+			assert.NoError(t, err)
+			assert.Equal(t, "Road Bikes", category.CategoryName)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&repo.reads))
+}
+
+func TestCachedInvalidatesOnWrite(t *testing.T) {
+	repo := &countingCategoryRepo{name: "Road Bikes"}
+	store := newCachedCategories(repo)
+	ctx := context.Background()
+
+	_, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+
+	_, err = store.Category().Update(ctx, &models.UpdateCategory{CategoryId: 1, CategoryName: "Gravel Bikes"})
+	assert.NoError(t, err)
+
+	category, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Gravel Bikes", category.CategoryName)
+	assert.Equal(t, int64(2), repo.reads)
+}
+
+func TestCachedFollowsEntityCacheFailover(t *testing.T) {
+	repo := &countingCategoryRepo{name: "Road Bikes"}
+	cache := &failoverEntityCache{up: 1, redis: newMapEntityCache(), memory: newMapEntityCache()}
+	cfg := config.Config{CategoryCacheTTL: time.Minute}
+	store := cached.NewStorage(&fakeStorage{category: repo}, cache, &cfg, logger.NewLogger("test", logger.LevelError))
+	ctx := context.Background()
+
+	_, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Len(t, cache.redis.values, 1)
+
+	// redis goes down: reads and writes move to the memory cache
+	atomic.StoreInt32(&cache.up, 0)
+
+	_, err = store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), repo.reads)
+	assert.Len(t, cache.memory.values, 1)
+
+	_, err = store.Category().Update(ctx, &models.UpdateCategory{CategoryId: 1, CategoryName: "Gravel Bikes"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cache.memory.versions["category"])
+	assert.Equal(t, int64(0), cache.redis.versions["category"])
+
+	category, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Gravel Bikes", category.CategoryName)
+	assert.Equal(t, int64(3), repo.reads)
+
+	// redis is back: reads are served from it again. The CacheStore flushes Redis before
+	// it switches back, this fake does not, so the entry cached before the outage shows.
+	atomic.StoreInt32(&cache.up, 1)
+
+	category, err = store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Road Bikes", category.CategoryName)
+	assert.Equal(t, int64(3), repo.reads)
+}
+
+func TestCachedBumpAll(t *testing.T) {
+	repo := &countingCategoryRepo{name: "Road Bikes"}
+	cache := &entityCache{entity: newMapEntityCache()}
+	cfg := config.Config{CategoryCacheTTL: time.Minute}
+	store := cached.NewStorage(&fakeStorage{category: repo}, cache, &cfg, logger.NewLogger("test", logger.LevelError))
+	ctx := context.Background()
+
+	_, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+
+	// a write that went around the cache layer
+	repo.name = "Gravel Bikes"
+	assert.NoError(t, cached.BumpAll(cache))
+
+	category, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Gravel Bikes", category.CategoryName)
+	assert.Equal(t, int64(2), repo.reads)
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestCategoryHandlers(t *testing.T) {
+	s := newMemoryServer()
+	readOnly := s.login(t, models.RoleReadOnly, 0)
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/category", body: &models.CreateCategory{CategoryName: "Road Bikes"}, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var category models.Category
+				decode(t, data, &category)
+				assert.Equal(t, 1, category.CategoryId)
+				assert.Equal(t, "Road Bikes", category.CategoryName)
+			}},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/category", body: "Road Bikes", status: http.StatusBadRequest},
+		{name: "create without token", method: http.MethodPost, path: "/v1/category", body: &models.CreateCategory{CategoryName: "Road Bikes"}, auth: anonymous, status: http.StatusUnauthorized},
+		{name: "create as read only", method: http.MethodPost, path: "/v1/category", body: &models.CreateCategory{CategoryName: "Road Bikes"}, auth: readOnly, status: http.StatusForbidden},
+		{name: "get", method: http.MethodGet, path: "/v1/category/1", auth: readOnly, status: http.StatusOK},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/category/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/category/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/category?search=road", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListCategoryResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid limit", method: http.MethodGet, path: "/v1/category?limit=ten", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/v1/category/1", body: &models.UpdateCategory{CategoryName: "Mountain Bikes"}, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var category models.Category
+				decode(t, data, &category)
+				assert.Equal(t, "Mountain Bikes", category.CategoryName)
+			}},
+		{name: "update invalid body", method: http.MethodPut, path: "/v1/category/1", body: "Mountain Bikes", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/category/99", body: &models.UpdateCategory{CategoryName: "Mountain Bikes"}, status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, path: "/v1/category/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/category/1", status: http.StatusNotFound},
+		{name: "get deleted", method: http.MethodGet, path: "/v1/category/1", status: http.StatusNotFound},
+	})
+}
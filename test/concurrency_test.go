@@ -0,0 +1,119 @@
+package test
+
+import (
+	"app/api/handler"
+	"app/api/models"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/test-go/testify/assert"
+)
+
+const concurrentCreates = 200
+
+// createConcurrently fires n creates at once and checks every one of them got its own id.
+func createConcurrently(t *testing.T, path string, n int, request func(i int) interface{}, id func(data interface{}) int, data func() interface{}) []int {
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = make([]int, 0, n)
+	)
+
+	auth := authHeader(t)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			created := data()
+			resp, err := PerformRequest(http.MethodPost, path, request(i), &handler.Response{Data: created}, auth)
+			assert.NoError(t, err)
+			assert.NotNil(t, resp)
+			if resp == nil {
+				return
+			}
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			mu.Lock()
+			ids = append(ids, id(created))
+			mu.Unlock()
+		}(i)
+	}
+
+	wg.Wait()
+
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		assert.False(t, seen[id], "duplicate id %d from %s", id, path)
+		seen[id] = true
+	}
+	assert.Len(t, ids, n)
+
+	return ids
+}
+
+func deleteAll(t *testing.T, path string, ids []int) {
+	auth := authHeader(t)
+
+	for _, id := range ids {
+		resp, _ := PerformRequest(http.MethodDelete, fmt.Sprintf("%s/%d", path, id), nil, nil, auth)
+		if resp != nil {
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+	}
+}
+
+func TestConcurrentCreates(t *testing.T) {
+	brandIds := createConcurrently(t, "/v1/brand", concurrentCreates,
+		func(i int) interface{} { return &models.CreateBrand{BrandName: faker.FirstName()} },
+		func(data interface{}) int { return data.(*models.Brand).BrandId },
+		func() interface{} { return &models.Brand{} },
+	)
+	defer deleteAll(t, "/v1/brand", brandIds)
+
+	categoryIds := createConcurrently(t, "/v1/category", concurrentCreates,
+		func(i int) interface{} { return &models.CreateCategory{CategoryName: faker.Word()} },
+		func(data interface{}) int { return data.(*models.Category).CategoryId },
+		func() interface{} { return &models.Category{} },
+	)
+	defer deleteAll(t, "/v1/category", categoryIds)
+
+	if len(brandIds) == 0 || len(categoryIds) == 0 {
+		t.FailNow()
+	}
+
+	productIds := createConcurrently(t, "/v1/product", concurrentCreates,
+		func(i int) interface{} {
+			return &models.CreateProduct{
+				ProductName: faker.Word(),
+				BrandId:     brandIds[i%len(brandIds)],
+				CategoryId:  categoryIds[i%len(categoryIds)],
+				ModelYear:   2020,
+				ListPrice:   99.99,
+			}
+		},
+		func(data interface{}) int { return data.(*models.Product).ProductId },
+		func() interface{} { return &models.Product{} },
+	)
+	defer deleteAll(t, "/v1/product", productIds)
+
+	storeIds := createConcurrently(t, "/v1/store", concurrentCreates,
+		func(i int) interface{} { return &models.CreateStore{StoreName: faker.Word(), Email: faker.Email()} },
+		func(data interface{}) int { return data.(*models.Store).StoreId },
+		func() interface{} { return &models.Store{} },
+	)
+	defer deleteAll(t, "/v1/store", storeIds)
+
+	customerIds := createConcurrently(t, "/v1/customer", concurrentCreates,
+		func(i int) interface{} {
+			return &models.CreateCustomer{FirstName: faker.FirstName(), LastName: faker.LastName(), Email: faker.Email()}
+		},
+		func(data interface{}) int { return data.(*models.Customer).CustomerId },
+		func() interface{} { return &models.Customer{} },
+	)
+	defer deleteAll(t, "/v1/customer", customerIds)
+}
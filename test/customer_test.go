@@ -0,0 +1,44 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestCustomerHandlers(t *testing.T) {
+	s := newMemoryServer()
+	cashier := s.login(t, models.RoleCashier, 0)
+
+	create := &models.CreateCustomer{FirstName: "Debra", LastName: "Burks", Email: "debra.burks@yahoo.com", City: "Orchard Park", State: "NY", ZipCode: 14127}
+	update := &models.UpdateCustomer{FirstName: "Kasha", LastName: "Todd", Email: "kasha.todd@yahoo.com", City: "Campbell", State: "CA", ZipCode: 95008}
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/customer", body: create, auth: cashier, status: http.StatusCreated},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/customer", body: "Debra", status: http.StatusBadRequest},
+		{name: "get", method: http.MethodGet, path: "/v1/customer/1", status: http.StatusOK},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/customer/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/customer/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/customer?search=burks", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListCustomerResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid cursor", method: http.MethodGet, path: "/v1/customer?after=!", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/v1/customer/1", body: update, auth: cashier, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var customer models.Customer
+				decode(t, data, &customer)
+				assert.Equal(t, "Kasha", customer.FirstName)
+			}},
+		{name: "update invalid body", method: http.MethodPut, path: "/v1/customer/1", body: "Kasha", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/customer/99", body: update, status: http.StatusNotFound},
+		{name: "delete as cashier", method: http.MethodDelete, path: "/v1/customer/1", auth: cashier, status: http.StatusForbidden},
+		{name: "delete", method: http.MethodDelete, path: "/v1/customer/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/customer/1", status: http.StatusNotFound},
+	})
+}
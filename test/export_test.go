@@ -0,0 +1,153 @@
+package test
+
+import (
+	"app/api/models"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+// export sends a GET with the Accept header and returns the response.
+func (s *testServer) export(t *testing.T, path, accept string, auth header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.Header.Set(auth.Key, auth.Value)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func readCSV(t *testing.T, resp *httptest.ResponseRecorder) [][]string {
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Header().Get("Content-Disposition"), "attachment")
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExportCSV(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+	ctx := context.Background()
+
+	otherId, err := s.store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	require.NoError(t, err)
+	_, err = s.store.Stock().Create(ctx, &models.CreateStock{StoreId: otherId, ProductId: fixture.productId, Quantity: 9})
+	require.NoError(t, err)
+	for i := 0; i < 12; i++ {
+		_, err = s.store.Product().Create(ctx, &models.CreateProduct{ProductName: fmt.Sprintf("Fuel EX %d", i), BrandId: fixture.brandId, CategoryId: fixture.categoryId, ModelYear: 2019, ListPrice: 2999.99})
+		require.NoError(t, err)
+	}
+	_, err = s.store.Order().Place(ctx, fixture.placeOrder(2))
+	require.NoError(t, err)
+
+	admin := s.authHeader(t)
+	manager := s.login(t, models.RoleStoreManager, fixture.storeId)
+
+	t.Run("products", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/product?format=csv&limit=2", "", admin))
+
+		// every row, the page size only applies to JSON
+		require.Len(t, records, 14)
+		assert.Equal(t, []string{"product_id", "product_name", "brand_id", "brand_name", "category_id", "category_name", "model_year", "list_price"}, records[0])
+		assert.Equal(t, []string{fmt.Sprint(fixture.productId), "Domane", fmt.Sprint(fixture.brandId), "Trek", fmt.Sprint(fixture.categoryId), "Road Bikes", "2018", "379.99"}, records[1])
+	})
+
+	t.Run("products filtered and sorted", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/product?search=fuel&sort=-product_name", "text/csv", admin))
+		require.Len(t, records, 13)
+		assert.Equal(t, "Fuel EX 9", records[1][1])
+	})
+
+	t.Run("stock", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/stock", "text/csv, application/json;q=0.5", admin))
+		assert.Equal(t, [][]string{
+			{"store_id", "product_id", "product_name", "brand_name", "category_name", "model_year", "list_price", "quantity"},
+			{fmt.Sprint(fixture.storeId), fmt.Sprint(fixture.productId), "Domane", "Trek", "Road Bikes", "2018", "379.99", "3"},
+			{fmt.Sprint(otherId), fmt.Sprint(fixture.productId), "Domane", "Trek", "Road Bikes", "2018", "379.99", "9"},
+		}, records)
+	})
+
+	t.Run("stock scoped", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/stock?format=csv", "", manager))
+		require.Len(t, records, 2)
+		assert.Equal(t, fmt.Sprint(fixture.storeId), records[1][0])
+	})
+
+	t.Run("orders", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/order?format=csv", "", admin))
+		require.Len(t, records, 2)
+		assert.Equal(t, "order_id", records[0][0])
+
+		order := map[string]string{}
+		for i, column := range records[0] {
+			order[column] = records[1][i]
+		}
+		assert.Equal(t, "pending", order["order_status"])
+		assert.Equal(t, "Debra Burks", order["customer_name"])
+		assert.Equal(t, "Santa Cruz Bikes", order["store_name"])
+		assert.Equal(t, "Fabiola Jackson", order["staff_name"])
+		assert.Equal(t, "2", order["quantity"])
+		assert.Equal(t, "759.98", order["total"])
+	})
+
+	t.Run("orders empty", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/order?format=csv&order_status=4", "", admin))
+		require.Len(t, records, 1)
+		assert.Equal(t, "order_id", records[0][0])
+	})
+
+	t.Run("staff report", func(t *testing.T) {
+		records := readCSV(t, s.export(t, "/v1/report/staff_report", "text/csv", manager))
+		assert.Equal(t, [][]string{
+			{"staff_name", "store_name", "order_date", "category_name", "product_name", "quantity", "total_sum"},
+			{"Fabiola Jackson", "Santa Cruz Bikes", records[1][2], "Road Bikes", "Domane", "2", "759.98"},
+		}, records)
+	})
+
+	t.Run("formulas are escaped", func(t *testing.T) {
+		for _, name := range []string{"=calc()", "+calc()", "-calc()", "@calc()"} {
+			_, err := s.store.Product().Create(ctx, &models.CreateProduct{ProductName: name, BrandId: fixture.brandId, CategoryId: fixture.categoryId, ModelYear: 2019, ListPrice: 2999.99})
+			require.NoError(t, err)
+		}
+
+		records := readCSV(t, s.export(t, "/v1/product?format=csv&search=calc&sort=product_name", "", admin))
+		require.Len(t, records, 5)
+
+		names := []string{}
+		for _, record := range records[1:] {
+			names = append(names, record[1])
+		}
+		assert.Equal(t, []string{"'+calc()", "'-calc()", "'=calc()", "'@calc()"}, names)
+		assert.Equal(t, "2999.99", records[1][7])
+	})
+
+	t.Run("json stays the default", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json"} {
+			resp := s.export(t, "/v1/product", accept, admin)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.True(t, strings.HasPrefix(resp.Header().Get("Content-Type"), "application/json"), accept)
+		}
+	})
+
+	t.Run("errors are json", func(t *testing.T) {
+		resp := s.export(t, "/v1/product?format=xlsx", "", admin)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+		resp = s.export(t, "/v1/order?format=csv&sort=-unknown", "", admin)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.True(t, strings.HasPrefix(resp.Header().Get("Content-Type"), "application/json"))
+	})
+}
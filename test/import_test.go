@@ -0,0 +1,126 @@
+package test
+
+import (
+	"app/api/models"
+	"app/pkg/importer"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestImportHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	other := &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"}
+	otherId, err := s.store.Store().Create(context.Background(), other)
+	assert.NoError(t, err)
+
+	manager := s.login(t, models.RoleStoreManager, fixture.storeId)
+	cashier := s.login(t, models.RoleCashier, fixture.storeId)
+
+	products := rawBody{"text/csv", fmt.Sprintf("\ufeffproduct_name,brand_id,category_id,model_year,list_price\n"+
+		"Madone,%d,%d,2019,4999.99\n"+
+		"\"Emonda, SL 6\",%d,%d,2020,2799.99\n", fixture.brandId, fixture.categoryId, fixture.brandId, fixture.categoryId)}
+	badProducts := rawBody{"text/csv", fmt.Sprintf("product_name,brand_id,category_id,model_year,list_price\n"+
+		",%d,%d,2019,4999.99\n"+
+		"Madone,99,%d,1800,4999.99\n"+
+		"Madone,%d,%d,2019,x\n"+
+		"Madone,%d\n", fixture.brandId, fixture.categoryId, fixture.categoryId, fixture.brandId, fixture.categoryId, fixture.brandId)}
+
+	customers := rawBody{"application/x-ndjson", `{"first_name":"Kasha","last_name":"Todd","email":"kasha.todd@yahoo.com","city":"Campbell"}` + "\n\n" +
+		`{"first_name":"Tameka","last_name":"Fisher","email":"tameka.fisher@aol.com","zip_code":94087}` + "\n"}
+	badCustomers := rawBody{"application/x-ndjson", `{"first_name":"Kasha","last_name":"Todd","email":"kasha.todd"}` + "\n" +
+		`{"first_name":"Tameka","last_name":"Fisher","email":"tameka.fisher@aol.com","zip_code":"94087"}` + "\n" +
+		`{"first_name":"Daryl","last_name":"Spence","email":"daryl.spence@aol.com","age":40}` + "\n"}
+
+	stocks := rawBody{"text/csv", fmt.Sprintf("store_id,product_id,quantity\n%d,%d,12\n%d,%d,3\n", fixture.storeId, fixture.productId, otherId, fixture.productId)}
+	ownStock := rawBody{"text/csv", fmt.Sprintf("store_id,product_id,quantity\n%d,%d,8\n", fixture.storeId, fixture.productId)}
+	duplicateStocks := rawBody{"text/csv", fmt.Sprintf("store_id,product_id,quantity\n%d,%d,12\n%d,%d,-1\n", fixture.storeId, fixture.productId, fixture.storeId, fixture.productId)}
+
+	report := func(total int, imported int64, rowErrors int) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			var report models.ImportReport
+			decode(t, data, &report)
+			assert.Equal(t, total, report.Total)
+			assert.Equal(t, imported, report.Imported)
+			assert.Equal(t, rowErrors, len(report.Errors), string(data))
+		}
+	}
+	quantity := func(storeId, want int) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			stock, err := s.store.Stock().GetById(context.Background(), &models.StockPrimaryKey{StoreId: storeId})
+			assert.NoError(t, err)
+			assert.Equal(t, want, stock.Products[0].Quantity)
+		}
+	}
+
+	s.run(t, []handlerCase{
+		{name: "products dry run", method: http.MethodPost, path: "/v1/product/import?dry_run=true", body: products, status: http.StatusOK, check: report(2, 0, 0)},
+		{name: "products", method: http.MethodPost, path: "/v1/product/import", body: products, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				report(2, 2, 0)(t, data)
+
+				list, err := s.store.Product().GetList(context.Background(), &models.GetListProductRequest{Limit: 10})
+				assert.NoError(t, err)
+				assert.Equal(t, 3, list.Count)
+			}},
+		{name: "products with errors", method: http.MethodPost, path: "/v1/product/import", body: badProducts, status: http.StatusUnprocessableEntity,
+			check: func(t *testing.T, data json.RawMessage) {
+				var report models.ImportReport
+				decode(t, data, &report)
+				assert.Equal(t, int64(0), report.Imported)
+
+				fields := map[string]int{}
+				for _, rowErr := range report.Errors {
+					fields[rowErr.Field] = rowErr.Line
+				}
+				assert.Equal(t, map[string]int{"product_name": 2, "brand_id": 3, "model_year": 3, "list_price": 4, "": 5}, fields)
+			}},
+		{name: "products unknown column", method: http.MethodPost, path: "/v1/product/import", body: rawBody{"text/csv", "name,price\nMadone,1\n"}, status: http.StatusBadRequest},
+		{name: "products no rows", method: http.MethodPost, path: "/v1/product/import", body: rawBody{"text/csv", "product_name\n"}, status: http.StatusBadRequest},
+		{name: "products unknown format", method: http.MethodPost, path: "/v1/product/import", body: rawBody{"text/plain", "Madone"}, status: http.StatusBadRequest},
+		{name: "products invalid dry run", method: http.MethodPost, path: "/v1/product/import?dry_run=maybe", body: products, status: http.StatusBadRequest},
+		{name: "products as cashier", method: http.MethodPost, path: "/v1/product/import", body: products, auth: cashier, status: http.StatusForbidden},
+		{name: "customers", method: http.MethodPost, path: "/v1/customer/import", body: customers, status: http.StatusCreated, check: report(2, 2, 0)},
+		{name: "customers format from query", method: http.MethodPost, path: "/v1/customer/import?format=ndjson&dry_run=1",
+			body: rawBody{"text/plain", customers.body}, status: http.StatusOK, check: report(2, 0, 0)},
+		{name: "customers with errors", method: http.MethodPost, path: "/v1/customer/import", body: badCustomers, status: http.StatusUnprocessableEntity, check: report(3, 0, 3)},
+		{name: "stocks", method: http.MethodPost, path: "/v1/stock/import", body: stocks, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				report(2, 2, 0)(t, data)
+				quantity(fixture.storeId, 12)(t, data)
+				quantity(otherId, 3)(t, data)
+			}},
+		{name: "stocks duplicate pair", method: http.MethodPost, path: "/v1/stock/import", body: duplicateStocks, status: http.StatusUnprocessableEntity, check: report(2, 0, 2)},
+		{name: "stocks other store", method: http.MethodPost, path: "/v1/stock/import", body: stocks, auth: manager, status: http.StatusUnprocessableEntity, check: report(2, 0, 1)},
+		{name: "stocks own store", method: http.MethodPost, path: "/v1/stock/import", body: ownStock, auth: manager, status: http.StatusCreated, check: quantity(fixture.storeId, 8)},
+	})
+}
+
+func TestImportMaxRows(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	file := "store_id,product_id,quantity\n" + strings.Repeat(fmt.Sprintf("%d,%d,1\n", fixture.storeId, fixture.productId), 3)
+
+	_, err := importer.Stocks(context.Background(), s.store, strings.NewReader(file), importer.Options{Format: importer.FormatCSV, MaxRows: 2})
+	assert.True(t, errors.Is(err, importer.ErrInvalidFile))
+	assert.Contains(t, err.Error(), "more than 2 rows")
+
+	for name, format := range map[string]string{
+		"text/csv; charset=utf-8": importer.FormatCSV,
+		"application/x-ndjson":    importer.FormatNDJSON,
+		"stock.JSONL":             importer.FormatNDJSON,
+		"products.csv":            importer.FormatCSV,
+		"application/json":        "",
+	} {
+		assert.Equal(t, format, importer.FormatOf(name), name)
+	}
+}
@@ -1,12 +1,30 @@
 package test
 
 import (
+	"app/api"
+	"app/api/handler"
+	"app/api/models"
+	"app/config"
+	"app/pkg/helper"
+	"app/pkg/logger"
+	"app/storage"
+	"app/storage/cached"
+	"app/storage/memcache"
+	"app/storage/memory"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/test-go/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type header struct {
@@ -14,46 +32,261 @@ type header struct {
 	Value string
 }
 
-var (
-	localhost = "http://localhost:8001"
-	testHost  = ""
-)
+// anonymous sends no bearer token.
+var anonymous = header{Key: "Authorization"}
 
-func PerformRequest(method, path string, req, res interface{}, headers ...header) (*http.Response, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		fmt.Println("Hel")
-		return nil, err
+// testServer is the gin engine built by api.NewApi in-process, so requests never leave the test
+// binary. Storage and cache are pluggable, newMemoryServer runs without any external service.
+type testServer struct {
+	cfg    *config.Config
+	router *gin.Engine
+	store  storage.StorageI
+	cache  storage.StorageCacheI
+
+	users     int
+	adminOnce sync.Once
+	admin     header
+}
+
+// server is used by PerformRequest and authHeader.
+var server = newMemoryServer()
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Environment:       config.TestMode,
+		CacheNamespace:    "test",
+		ProductCacheTTL:   time.Minute,
+		CategoryCacheTTL:  time.Minute,
+		BrandCacheTTL:     time.Minute,
+		StoreCacheTTL:     time.Minute,
+		CustomerCacheTTL:  time.Minute,
+		StaffCacheTTL:     time.Minute,
+		PromocodeCacheTTL: time.Minute,
+		DefaultLimit:      10,
+		SecretKey:         "test",
+		TokenIssuer:       "test",
+		AccessTokenTTL:    time.Minute,
+		RefreshTokenTTL:   time.Hour,
+		BcryptCost:        bcrypt.MinCost,
+		PasswordMinLength: 8,
 	}
+}
 
-	client := &http.Client{}
+func newTestServer(cfg *config.Config, store storage.StorageI, cache storage.StorageCacheI) *testServer {
+	gin.SetMode(gin.TestMode)
 
-	request, err := http.NewRequestWithContext(context.Background(), method, fmt.Sprintf("%s%s", localhost, path), bytes.NewBuffer(body))
-	if err != nil {
-		fmt.Println("Word")
-		return nil, err
+	r := gin.New()
+	api.NewApi(r, cfg, store, cache, logger.NewLogger("test", logger.LevelPanic))
+
+	return &testServer{
+		cfg:    cfg,
+		router: r,
+		store:  store,
+		cache:  cache,
+	}
+}
+
+// newMemoryServer wires the in-memory storage behind the read-through cache like cmd/main.go
+// does with Postgres.
+func newMemoryServer() *testServer {
+	cfg := testConfig()
+	cache := memcache.NewCache(cfg)
+	store := cached.NewStorage(memory.NewStorage(), cache, cfg, logger.NewLogger("test", logger.LevelPanic))
+
+	return newTestServer(cfg, store, cache)
+}
+
+func PerformRequest(method, path string, req, res interface{}, headers ...header) (*http.Response, error) {
+	return server.PerformRequest(method, path, req, res, headers...)
+}
+
+// PerformRequest sends req as the JSON body and decodes the response body into res. Responses
+// without a body, like 204, leave res untouched.
+func (s *testServer) PerformRequest(method, path string, req, res interface{}, headers ...header) (*http.Response, error) {
+	contentType := "application/json"
+
+	var body []byte
+	if raw, ok := req.(rawBody); ok {
+		contentType = raw.contentType
+		body = []byte(raw.body)
+	} else {
+		var err error
+		body, err = json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	request := httptest.NewRequest(method, path, bytes.NewBuffer(body))
 	for _, h := range headers {
 		request.Header.Add(h.Key, h.Value)
 	}
-
 	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", contentType)
 
-	resp, err := client.Do(request)
-	if err != nil {
-		fmt.Println("err")
-		return resp, err
-	}
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, request)
 
+	resp := recorder.Result()
 	defer resp.Body.Close()
-	resp_body, err := ioutil.ReadAll(resp.Body)
 
-	err = json.Unmarshal(resp_body, &res)
+	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Println("Errror:", err)
 		return nil, err
 	}
 
+	if len(respBody) > 0 && res != nil {
+		err = json.Unmarshal(respBody, res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return resp, nil
 }
+
+// rawBody is a request body that is sent as it is instead of as JSON, like an import file.
+type rawBody struct {
+	contentType string
+	body        string
+}
+
+func authHeader(t *testing.T) header {
+	return server.authHeader(t)
+}
+
+// authHeader logs in once as an admin and returns the bearer header for secured routes.
+func (s *testServer) authHeader(t *testing.T) header {
+	s.adminOnce.Do(func() {
+		s.admin = s.login(t, models.RoleAdmin, 0)
+	})
+
+	return s.admin
+}
+
+// login creates a user with the role, bound to a new staff member of storeId when it is set,
+// and returns its bearer header.
+func (s *testServer) login(t *testing.T, role string, storeId int) header {
+	ctx := context.Background()
+
+	s.users++
+	username := fmt.Sprintf("%s_%d", role, s.users)
+	password := "password1"
+
+	hash, err := helper.HashPassword(password, s.cfg.BcryptCost)
+	assert.NoError(t, err)
+
+	userId, err := s.store.User().Create(ctx, &models.CreateUser{Username: username, Password: hash})
+	assert.NoError(t, err)
+	_, err = s.store.User().UpdateRole(ctx, &models.UpdateUserRole{UserId: userId, Role: role})
+	assert.NoError(t, err)
+
+	if storeId > 0 {
+		staffId, err := s.store.Staff().Create(ctx, &models.CreateStaff{
+			FirstName: "Test",
+			LastName:  username,
+			Email:     username + "@bikes.shop",
+			Active:    1,
+			StoreId:   storeId,
+		})
+		assert.NoError(t, err)
+		_, err = s.store.User().UpdateStaff(ctx, &models.UpdateUserStaff{UserId: userId, StaffId: staffId})
+		assert.NoError(t, err)
+	}
+
+	tokens := &models.TokenResponse{}
+	resp, err := s.PerformRequest(http.MethodPost, "/v1/login", &models.LoginUser{
+		Username: username,
+		Password: password,
+	}, &handler.Response{Data: tokens})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("login %s: %v", username, err)
+	}
+
+	return header{Key: "Authorization", Value: "Bearer " + tokens.AccessToken}
+}
+
+// handlerCase is one request and the status it must get back. Requests are sent as admin unless
+// auth is set, check gets the raw data of the response.
+type handlerCase struct {
+	name   string
+	method string
+	path   string
+	body   interface{}
+	auth   header
+	status int
+	check  func(t *testing.T, data json.RawMessage)
+}
+
+// run sends the cases in order, so a case can rely on what the cases before it wrote.
+func (s *testServer) run(t *testing.T, cases []handlerCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := tc.auth
+			if auth.Key == "" {
+				auth = s.authHeader(t)
+			}
+
+			var data json.RawMessage
+			resp, err := s.PerformRequest(tc.method, tc.path, tc.body, &handler.Response{Data: &data}, auth)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.status, resp.StatusCode, string(data))
+
+			if tc.check != nil {
+				tc.check(t, data)
+			}
+		})
+	}
+}
+
+// decode unmarshals the data of a response into v.
+func decode(t *testing.T, data json.RawMessage, v interface{}) {
+	assert.NoError(t, json.Unmarshal(data, v))
+}
+
+// shop is the fixture most handler tests start from: one store with a staff member, a customer
+// and a product with 5 in stock.
+type shop struct {
+	categoryId int
+	brandId    int
+	productId  int
+	storeId    int
+	staffId    int
+	customerId int
+}
+
+func seedShop(t *testing.T, store storage.StorageI) *shop {
+	ctx := context.Background()
+	s := &shop{}
+
+	var err error
+	s.categoryId, err = store.Category().Create(ctx, &models.CreateCategory{CategoryName: "Road Bikes"})
+	assert.NoError(t, err)
+	s.brandId, err = store.Brand().Create(ctx, &models.CreateBrand{BrandName: "Trek"})
+	assert.NoError(t, err)
+	s.productId, err = store.Product().Create(ctx, &models.CreateProduct{ProductName: "Domane", BrandId: s.brandId, CategoryId: s.categoryId, ModelYear: 2018, ListPrice: 379.99})
+	assert.NoError(t, err)
+	s.storeId, err = store.Store().Create(ctx, &models.CreateStore{StoreName: "Santa Cruz Bikes", Email: "santacruz@bikes.shop"})
+	assert.NoError(t, err)
+	s.staffId, err = store.Staff().Create(ctx, &models.CreateStaff{FirstName: "Fabiola", LastName: "Jackson", Email: "fabiola.jackson@bikes.shop", Active: 1, StoreId: s.storeId})
+	assert.NoError(t, err)
+	s.customerId, err = store.Customer().Create(ctx, &models.CreateCustomer{FirstName: "Debra", LastName: "Burks", Email: "debra.burks@yahoo.com"})
+	assert.NoError(t, err)
+	_, err = store.Stock().Create(ctx, &models.CreateStock{StoreId: s.storeId, ProductId: s.productId, Quantity: 5})
+	assert.NoError(t, err)
+
+	return s
+}
+
+// placeOrder is an order of quantity products of the shop.
+func (s *shop) placeOrder(quantity int) *models.PlaceOrder {
+	return &models.PlaceOrder{
+		CustomerId:   s.customerId,
+		RequiredDate: "2030-01-01",
+		StoreId:      s.storeId,
+		StaffId:      s.staffId,
+		Items:        []*models.PlaceOrderItem{{ProductId: s.productId, Quantity: quantity}},
+	}
+}
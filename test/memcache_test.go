@@ -0,0 +1,80 @@
+package test
+
+import (
+	"app/api/models"
+	"app/config"
+	"app/pkg/logger"
+	"app/storage"
+	"app/storage/cached"
+	"app/storage/memcache"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestMemcacheProductInvalidateByTag(t *testing.T) {
+	cache := memcache.NewCache(&config.Config{ProductCacheTTL: time.Minute}).Product()
+
+	trek := &models.GetListProductResponse{Count: 1, Products: []*models.Product{{ProductId: 1, BrandId: 9}}}
+	err := cache.Set("trek", trek, []string{storage.ProductCacheTagAll, storage.ProductCacheBrandTag(9)})
+	assert.NoError(t, err)
+	err = cache.Set("other", &models.GetListProductResponse{}, []string{storage.ProductCacheTagAll})
+	assert.NoError(t, err)
+
+	err = cache.Invalidate(storage.ProductCacheBrandTag(9))
+	assert.NoError(t, err)
+
+	_, ok, err := cache.Get("trek")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = cache.Get("other")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+}
+
+func TestMemcacheTokenRevokeExpires(t *testing.T) {
+	tokens := memcache.NewCache(&config.Config{}).Token()
+
+	err := tokens.Revoke("token", 20*time.Millisecond)
+	assert.NoError(t, err)
+
+	revoked, _ := tokens.IsRevoked("token")
+	assert.True(t, revoked)
+
+	time.Sleep(30 * time.Millisecond)
+	revoked, _ = tokens.IsRevoked("token")
+	assert.False(t, revoked)
+}
+
+type brokenEntityCache struct{}
+
+func (brokenEntityCache) Get(string, interface{}) (bool, error) {
+	return false, errors.New("connection refused")
+}
+func (brokenEntityCache) Set(string, interface{}, time.Duration) error {
+	return errors.New("connection refused")
+}
+func (brokenEntityCache) Version(string) (int64, error) { return 0, nil }
+func (brokenEntityCache) Bump(string) error             { return errors.New("connection refused") }
+
+func TestCachedBypassesCacheErrors(t *testing.T) {
+	repo := &countingCategoryRepo{name: "Road Bikes"}
+	cfg := config.Config{CategoryCacheTTL: time.Minute}
+	store := cached.NewStorage(&fakeStorage{category: repo}, &entityCache{entity: brokenEntityCache{}}, &cfg, logger.NewLogger("test", logger.LevelError))
+	ctx := context.Background()
+
+	category, err := store.Category().GetById(ctx, &models.CategoryPrimaryKey{CategoryId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Road Bikes", category.CategoryName)
+
+	_, err = store.Category().Update(ctx, &models.UpdateCategory{CategoryId: 1, CategoryName: "Gravel Bikes"})
+	assert.NoError(t, err)
+}
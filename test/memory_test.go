@@ -0,0 +1,82 @@
+package test
+
+import (
+	"app/api/models"
+	"app/storage"
+	"app/storage/memory"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/test-go/testify/assert"
+)
+
+func TestMemoryPlaceOrderTakesStock(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	place := seedShop(t, store).placeOrder(3)
+
+	orderId, err := store.Order().Place(ctx, place)
+	assert.NoError(t, err)
+
+	order, err := store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: orderId})
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, order.OrderStatus)
+	assert.Equal(t, "2030-01-01 00:00:00", order.RequiredDate)
+	assert.Len(t, order.OrderItems, 1)
+	assert.Equal(t, 379.99, order.OrderItems[0].ListPrice)
+
+	// 2 left, the second order fails and leaves nothing behind
+	_, err = store.Order().Place(ctx, place)
+	assert.True(t, errors.Is(err, storage.ErrNotEnoughStock))
+
+	list, err := store.Order().GetList(ctx, &models.GetListOrderRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+
+	err = store.Order().ChangeStatus(ctx, &models.ChangeOrderStatus{OrderId: orderId, Status: models.OrderStatusCancelled})
+	assert.NoError(t, err)
+
+	stock, err := store.Stock().GetById(ctx, &models.StockPrimaryKey{StoreId: place.StoreId})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, stock.Products[0].Quantity)
+
+	drifts, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, drifts.Count)
+}
+
+func TestMemoryConstraints(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStorage()
+	place := seedShop(t, store).placeOrder(3)
+
+	_, err := store.Product().Create(ctx, &models.CreateProduct{ProductName: "Madone", BrandId: 99, CategoryId: 1})
+	var pgErr *pgconn.PgError
+	assert.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23503", pgErr.Code)
+
+	_, err = store.User().Create(ctx, &models.CreateUser{Username: "admin", Password: "hash"})
+	assert.NoError(t, err)
+	_, err = store.User().Create(ctx, &models.CreateUser{Username: "admin", Password: "hash"})
+	assert.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23505", pgErr.Code)
+
+	_, err = store.Order().Place(ctx, place)
+	assert.NoError(t, err)
+
+	// the staff member still has an order
+	_, err = store.Staff().Delete(ctx, &models.StaffPrimaryKey{StaffId: place.StaffId})
+	assert.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23503", pgErr.Code)
+
+	// deleting the customer cascades to the order
+	rows, err := store.Customer().Delete(ctx, &models.CustomerPrimaryKey{CustomerId: place.CustomerId})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = store.Order().GetById(ctx, &models.OrderPrimaryKey{OrderId: 1})
+	assert.Equal(t, pgx.ErrNoRows, err)
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"app/config"
+	"app/migrations"
+	"app/storage/postgresql"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	list, err := postgresql.LoadMigrations(migrations.Postgres())
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+
+	assert.Equal(t, 1, list[0].Version)
+	assert.Equal(t, "create_tables", list[0].Name)
+	for i := 1; i < len(list); i++ {
+		assert.True(t, list[i-1].Version < list[i].Version)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(body)}
+	}
+
+	list, err := postgresql.LoadMigrations(fstest.MapFS{
+		"10_second.up.sql":   file("CREATE TABLE b ();"),
+		"10_second.down.sql": file("DROP TABLE b;"),
+		"2_first.up.sql":     file("CREATE TABLE a ();"),
+		"2_first.down.sql":   file("DROP TABLE a;"),
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, &postgresql.Migration{Version: 2, Name: "first", Up: "CREATE TABLE a ();", Down: "DROP TABLE a;"}, list[0])
+	assert.Equal(t, 10, list[1].Version)
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"missing down":  {"01_a.up.sql": file("SELECT 1;")},
+		"missing up":    {"01_a.down.sql": file("SELECT 1;")},
+		"bad name":      {"create.sql": file("SELECT 1;")},
+		"zero version":  {"0_a.up.sql": file("SELECT 1;"), "0_a.down.sql": file("SELECT 1;")},
+		"renamed":       {"01_a.up.sql": file("SELECT 1;"), "01_b.down.sql": file("SELECT 1;")},
+		"empty up file": {"01_a.up.sql": file(""), "01_a.down.sql": file("SELECT 1;")},
+	} {
+		_, err := postgresql.LoadMigrations(fsys)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestPostgresMigrations reverts and reapplies every migration, so like the storage contract
+// it only runs when TEST_POSTGRES=true.
+func TestPostgresMigrations(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") != "true" {
+		t.Skip("set TEST_POSTGRES=true to run against postgres, it drops every table")
+	}
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	db, err := postgresql.NewPool(&cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	migrator, err := postgresql.NewMigrator(db, migrations.Postgres())
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Check(ctx))
+
+	done, err := migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, done, len(mustStatus(t, migrator)))
+
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+	assert.True(t, errors.Is(migrator.Check(ctx), postgresql.ErrSchemaBehind))
+
+	done, err = migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	for _, row := range mustStatus(t, migrator)[1:] {
+		assert.Empty(t, row.AppliedAt)
+	}
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+
+	version, err = migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), version)
+	assert.NoError(t, migrator.Check(ctx))
+}
+
+func mustStatus(t *testing.T, migrator *postgresql.Migrator) []*postgresql.MigrationStatus {
+	t.Helper()
+
+	status, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	return status
+}
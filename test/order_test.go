@@ -0,0 +1,113 @@
+package test
+
+import (
+	"app/api/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestOrderHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	otherId, err := s.store.Store().Create(context.Background(), &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	assert.NoError(t, err)
+	otherManager := s.login(t, models.RoleStoreManager, otherId)
+	cashier := s.login(t, models.RoleCashier, fixture.storeId)
+
+	create := &models.CreateOrder{CustomerId: fixture.customerId, RequiredDate: "2030-01-01", StoreId: fixture.storeId, StaffId: fixture.staffId}
+	update := &models.UpdateOrder{CustomerId: fixture.customerId, RequiredDate: "2030-02-01", StoreId: fixture.storeId, StaffId: fixture.staffId}
+
+	status := func(status int16) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			var order models.Order
+			decode(t, data, &order)
+			assert.Equal(t, status, order.OrderStatus)
+		}
+	}
+	stockLeft := func(quantity int) handlerCase {
+		return handlerCase{name: fmt.Sprintf("%d left in stock", quantity), method: http.MethodGet, path: fmt.Sprintf("/v1/stock/%d", fixture.storeId), status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var stock models.GetStock
+				decode(t, data, &stock)
+				assert.Equal(t, quantity, stock.Products[0].Quantity)
+			}}
+	}
+
+	s.run(t, []handlerCase{
+		{name: "place", method: http.MethodPost, path: "/v1/order/place", body: fixture.placeOrder(2), auth: cashier, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var order models.Order
+				decode(t, data, &order)
+				assert.Equal(t, 1, order.OrderId)
+				assert.Len(t, order.OrderItems, 1)
+			}},
+		stockLeft(3),
+		{name: "place more than stocked", method: http.MethodPost, path: "/v1/order/place", body: fixture.placeOrder(10), status: http.StatusUnprocessableEntity},
+		{name: "place without items", method: http.MethodPost, path: "/v1/order/place", body: &models.PlaceOrder{StoreId: fixture.storeId}, status: http.StatusBadRequest},
+		{name: "place invalid body", method: http.MethodPost, path: "/v1/order/place", body: "order", status: http.StatusBadRequest},
+		{name: "place in other store", method: http.MethodPost, path: "/v1/order/place", body: fixture.placeOrder(1), auth: otherManager, status: http.StatusForbidden},
+		{name: "create", method: http.MethodPost, path: "/v1/order", body: create, status: http.StatusCreated, check: status(models.OrderStatusPending)},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/order", body: "order", status: http.StatusBadRequest},
+		{name: "create unknown staff", method: http.MethodPost, path: "/v1/order", body: &models.CreateOrder{CustomerId: fixture.customerId, RequiredDate: "2030-01-01", StoreId: fixture.storeId, StaffId: 99}, status: http.StatusUnprocessableEntity},
+		{name: "get", method: http.MethodGet, path: "/v1/order/1", status: http.StatusOK, check: status(models.OrderStatusPending)},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/order/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/order/99", status: http.StatusNotFound},
+		{name: "get other store", method: http.MethodGet, path: "/v1/order/1", auth: otherManager, status: http.StatusForbidden},
+		{name: "list", method: http.MethodGet, path: "/v1/order?sort=-order_id", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListOrderResponse
+				decode(t, data, &list)
+				assert.Equal(t, 2, list.Count)
+				assert.Equal(t, 2, list.Orders[0].OrderId)
+			}},
+		{name: "list scoped", method: http.MethodGet, path: "/v1/order", auth: otherManager, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListOrderResponse
+				decode(t, data, &list)
+				assert.Equal(t, 0, list.Count)
+			}},
+		{name: "list invalid status", method: http.MethodGet, path: "/v1/order?order_status=9", status: http.StatusBadRequest},
+		{name: "list invalid date", method: http.MethodGet, path: "/v1/order?order_date_from=yesterday", status: http.StatusBadRequest},
+		{name: "add item", method: http.MethodPost, path: "/v1/order_item", body: &models.CreateOrderItem{OrderId: 2, ProductId: fixture.productId, Quantity: 1, ListPrice: 379.99}, status: http.StatusCreated},
+		stockLeft(2),
+		{name: "add item without quantity", method: http.MethodPost, path: "/v1/order_item", body: &models.CreateOrderItem{OrderId: 2, ProductId: fixture.productId}, status: http.StatusUnprocessableEntity},
+		{name: "add item more than stocked", method: http.MethodPost, path: "/v1/order_item", body: &models.CreateOrderItem{OrderId: 2, ProductId: fixture.productId, Quantity: 10}, status: http.StatusUnprocessableEntity},
+		{name: "add item unknown order", method: http.MethodPost, path: "/v1/order_item", body: &models.CreateOrderItem{OrderId: 99, ProductId: fixture.productId, Quantity: 1}, status: http.StatusNotFound},
+		{name: "add item invalid body", method: http.MethodPost, path: "/v1/order_item", body: "item", status: http.StatusBadRequest},
+		{name: "remove item", method: http.MethodDelete, path: "/v1/order_item/2?item_id=1", status: http.StatusNoContent},
+		stockLeft(3),
+		{name: "remove item not found", method: http.MethodDelete, path: "/v1/order_item/2?item_id=1", status: http.StatusNotFound},
+		{name: "remove item invalid item id", method: http.MethodDelete, path: "/v1/order_item/2", status: http.StatusBadRequest},
+		{name: "process", method: http.MethodPut, path: "/v1/order/1/process", body: &models.ChangeOrderStatus{Comment: "packing"}, auth: cashier, status: http.StatusOK, check: status(models.OrderStatusProcessing)},
+		{name: "ship", method: http.MethodPut, path: "/v1/order/1/ship", status: http.StatusOK, check: status(models.OrderStatusShipped)},
+		{name: "cancel shipped", method: http.MethodPut, path: "/v1/order/1/cancel", status: http.StatusConflict},
+		{name: "add item to shipped", method: http.MethodPost, path: "/v1/order_item", body: &models.CreateOrderItem{OrderId: 1, ProductId: fixture.productId, Quantity: 1}, status: http.StatusConflict},
+		{name: "complete", method: http.MethodPut, path: "/v1/order/1/complete", status: http.StatusOK, check: status(models.OrderStatusCompleted)},
+		{name: "process invalid id", method: http.MethodPut, path: "/v1/order/one/process", status: http.StatusBadRequest},
+		{name: "process not found", method: http.MethodPut, path: "/v1/order/99/process", status: http.StatusNotFound},
+		{name: "history", method: http.MethodGet, path: "/v1/order/1/history", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var history models.GetOrderStatusHistoryResponse
+				decode(t, data, &history)
+				assert.Equal(t, 4, history.Count)
+				assert.Equal(t, "packing", history.History[1].Comment)
+			}},
+		{name: "history not found", method: http.MethodGet, path: "/v1/order/99/history", status: http.StatusNotFound},
+		{name: "reject as cashier", method: http.MethodPut, path: "/v1/order/2/reject", auth: cashier, status: http.StatusForbidden},
+		{name: "update", method: http.MethodPut, path: "/v1/order/2", body: update, status: http.StatusOK},
+		{name: "update store of pending order", method: http.MethodPut, path: "/v1/order/2", body: &models.UpdateOrder{CustomerId: fixture.customerId, RequiredDate: "2030-02-01", StoreId: otherId, StaffId: fixture.staffId}, status: http.StatusConflict},
+		{name: "update invalid body", method: http.MethodPut, path: "/v1/order/2", body: "order", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/order/99", body: update, status: http.StatusNotFound},
+		{name: "reject", method: http.MethodPut, path: "/v1/order/2/reject", status: http.StatusOK, check: status(models.OrderStatusRejected)},
+		{name: "cancel rejected", method: http.MethodPut, path: "/v1/order/2/cancel", status: http.StatusConflict},
+		{name: "delete as cashier", method: http.MethodDelete, path: "/v1/order/2", auth: cashier, status: http.StatusForbidden},
+		{name: "delete", method: http.MethodDelete, path: "/v1/order/2", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/order/2", status: http.StatusNotFound},
+	})
+}
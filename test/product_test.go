@@ -0,0 +1,90 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestProductHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	create := &models.CreateProduct{ProductName: "Madone", BrandId: fixture.brandId, CategoryId: fixture.categoryId, ModelYear: 2019, ListPrice: 999.99}
+	update := &models.UpdateProduct{ProductName: "Madone SLR", BrandId: fixture.brandId, CategoryId: fixture.categoryId, ModelYear: 2020, ListPrice: 1199.99}
+
+	listCount := func(count int) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			var list models.GetListProductResponse
+			decode(t, data, &list)
+			assert.Equal(t, count, list.Count)
+		}
+	}
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/product", body: create, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var product models.Product
+				decode(t, data, &product)
+				assert.Equal(t, "Madone", product.ProductName)
+				assert.Equal(t, "Trek", product.BrandData.BrandName)
+			}},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/product", body: "Madone", status: http.StatusBadRequest},
+		{name: "create unknown brand", method: http.MethodPost, path: "/v1/product", body: &models.CreateProduct{ProductName: "Madone", BrandId: 99, CategoryId: fixture.categoryId}, status: http.StatusUnprocessableEntity},
+		{name: "get", method: http.MethodGet, path: "/v1/product/2", status: http.StatusCreated},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/product/two", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/product/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/product", status: http.StatusOK, check: listCount(2)},
+		{name: "list cached", method: http.MethodGet, path: "/v1/product", status: http.StatusOK, check: listCount(2)},
+		{name: "list in stock", method: http.MethodGet, path: fmt.Sprintf("/v1/product?in_stock_store_id=%d", fixture.storeId), status: http.StatusOK, check: listCount(1)},
+		{name: "list invalid price", method: http.MethodGet, path: "/v1/product?price_from=cheap", status: http.StatusBadRequest},
+		{name: "list inverted years", method: http.MethodGet, path: "/v1/product?model_year_from=2020&model_year_to=2019", status: http.StatusBadRequest},
+		{name: "list invalid sort", method: http.MethodGet, path: "/v1/product?sort=password", status: http.StatusBadRequest},
+		{name: "list sort with cursor", method: http.MethodGet, path: "/v1/product?sort=-list_price&after=abc", status: http.StatusBadRequest},
+		{name: "cache stats", method: http.MethodGet, path: "/v1/cache/stats", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var stats models.GetCacheStatsResponse
+				decode(t, data, &stats)
+				assert.Equal(t, int64(1), stats.Product.Hits)
+			}},
+		{name: "update", method: http.MethodPut, path: "/v1/product/2", body: update, status: http.StatusAccepted},
+		{name: "update invalidates list", method: http.MethodGet, path: "/v1/product?search=slr", status: http.StatusOK, check: listCount(1)},
+		{name: "update invalid body", method: http.MethodPut, path: "/v1/product/2", body: "Madone", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/product/99", body: update, status: http.StatusNotFound},
+		{name: "update unknown category", method: http.MethodPut, path: "/v1/product/2", body: &models.UpdateProduct{ProductName: "Madone", BrandId: fixture.brandId, CategoryId: 99}, status: http.StatusUnprocessableEntity},
+		{name: "delete", method: http.MethodDelete, path: "/v1/product/2", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/product/2", status: http.StatusNotFound},
+		{name: "list after delete", method: http.MethodGet, path: "/v1/product", status: http.StatusOK, check: listCount(1)},
+	})
+}
+
+func TestProductCacheFollowsStock(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	inStock := func(name string, count int) handlerCase {
+		return handlerCase{name: name, method: http.MethodGet, path: fmt.Sprintf("/v1/product?in_stock_store_id=%d", fixture.storeId), status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListProductResponse
+				decode(t, data, &list)
+				assert.Equal(t, count, list.Count)
+			}}
+	}
+	updatePath := fmt.Sprintf("/v1/stock?storeId=%d&productId=%d", fixture.storeId, fixture.productId)
+
+	s.run(t, []handlerCase{
+		inStock("in stock", 1),
+		{name: "sell out", method: http.MethodPut, path: updatePath, body: &models.UpdateStock{Quantity: 0}, status: http.StatusOK},
+		inStock("sold out", 0),
+		{name: "restock", method: http.MethodPut, path: updatePath, body: &models.UpdateStock{Quantity: 2}, status: http.StatusOK},
+		inStock("restocked", 1),
+		{name: "place", method: http.MethodPost, path: "/v1/order/place", body: fixture.placeOrder(2), status: http.StatusCreated},
+		inStock("ordered out", 0),
+		{name: "cancel", method: http.MethodPut, path: "/v1/order/1/cancel", status: http.StatusOK},
+		inStock("returned by cancel", 1),
+	})
+}
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestPromocodeHandlers(t *testing.T) {
+	s := newMemoryServer()
+
+	create := &models.CreatePromocode{PromocodeName: "SPRING", Discount: 10, DiscountType: 2}
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/promocode", body: create, status: http.StatusCreated},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/promocode", body: "SPRING", status: http.StatusBadRequest},
+		{name: "get", method: http.MethodGet, path: "/v1/promocode/1", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var promocode models.Promocode
+				decode(t, data, &promocode)
+				assert.Equal(t, "SPRING", promocode.PromocodeName)
+			}},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/promocode/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/promocode/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/promocode", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListPromocodeResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid limit", method: http.MethodGet, path: "/v1/promocode?limit=x", status: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: "/v1/promocode/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/promocode/1", status: http.StatusNotFound},
+	})
+}
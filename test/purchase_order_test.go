@@ -0,0 +1,162 @@
+package test
+
+import (
+	"app/api/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestSupplierHandlers(t *testing.T) {
+	s := newMemoryServer()
+	cashier := s.login(t, models.RoleCashier, 0)
+
+	create := &models.CreateSupplier{SupplierName: "Trek Wholesale", ContactName: "Ann Lee", Email: "orders@trek.example"}
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/supplier", body: create, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var supplier models.Supplier
+				decode(t, data, &supplier)
+				assert.Equal(t, 1, supplier.SupplierId)
+				assert.Equal(t, "Ann Lee", supplier.ContactName)
+			}},
+		{name: "create duplicate email", method: http.MethodPost, path: "/v1/supplier", body: create, status: http.StatusConflict},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/supplier", body: "supplier", status: http.StatusBadRequest},
+		{name: "create as cashier", method: http.MethodPost, path: "/v1/supplier", body: create, auth: cashier, status: http.StatusForbidden},
+		{name: "get", method: http.MethodGet, path: "/v1/supplier/1", auth: cashier, status: http.StatusOK},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/supplier/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/supplier/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/supplier?search=trek", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListSupplierResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "update", method: http.MethodPut, path: "/v1/supplier/1", body: &models.UpdateSupplier{SupplierName: "Trek Wholesale", Email: "sales@trek.example"}, status: http.StatusAccepted,
+			check: func(t *testing.T, data json.RawMessage) {
+				var supplier models.Supplier
+				decode(t, data, &supplier)
+				assert.Equal(t, "sales@trek.example", supplier.Email)
+			}},
+		{name: "update not found", method: http.MethodPut, path: "/v1/supplier/99", body: &models.UpdateSupplier{Email: "nobody@trek.example"}, status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, path: "/v1/supplier/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/supplier/1", status: http.StatusNotFound},
+	})
+}
+
+func TestPurchaseOrderHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+	ctx := context.Background()
+
+	supplierId, err := s.store.Supplier().Create(ctx, &models.CreateSupplier{SupplierName: "Trek Wholesale", Email: "orders@trek.example"})
+	assert.NoError(t, err)
+	otherId, err := s.store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	assert.NoError(t, err)
+	manager := s.login(t, models.RoleStoreManager, fixture.storeId)
+	otherManager := s.login(t, models.RoleStoreManager, otherId)
+	cashier := s.login(t, models.RoleCashier, fixture.storeId)
+
+	create := &models.CreatePurchaseOrder{
+		SupplierId:   supplierId,
+		ExpectedDate: "2030-01-01",
+		Items:        []*models.CreatePurchaseOrderItem{{StoreId: fixture.storeId, ProductId: fixture.productId, Quantity: 10, UnitCost: 250}},
+	}
+	ownStore := &models.CreatePurchaseOrder{
+		SupplierId: supplierId,
+		Items:      []*models.CreatePurchaseOrderItem{{ProductId: fixture.productId, Quantity: 10, UnitCost: 250}},
+	}
+	receive := func(itemId, quantity int) *models.ReceivePurchaseOrder {
+		return &models.ReceivePurchaseOrder{Items: []*models.ReceivePurchaseOrderItem{{ItemId: itemId, Quantity: quantity}}}
+	}
+
+	status := func(status int16) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			var order models.PurchaseOrder
+			decode(t, data, &order)
+			assert.Equal(t, status, order.Status)
+		}
+	}
+	stockLeft := func(quantity int) handlerCase {
+		return handlerCase{name: fmt.Sprintf("%d in stock", quantity), method: http.MethodGet, path: fmt.Sprintf("/v1/stock/%d", fixture.storeId), status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var stock models.GetStock
+				decode(t, data, &stock)
+				assert.Equal(t, quantity, stock.Products[0].Quantity)
+			}}
+	}
+
+	s.run(t, []handlerCase{
+		{name: "create for own store", method: http.MethodPost, path: "/v1/purchase_order", body: ownStore, auth: manager, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var order models.PurchaseOrder
+				decode(t, data, &order)
+				assert.Equal(t, 1, order.PurchaseOrderId)
+				assert.Equal(t, models.PurchaseOrderStatusDraft, order.Status)
+				if assert.Len(t, order.Items, 1) {
+					assert.Equal(t, fixture.storeId, order.Items[0].StoreId)
+				}
+			}},
+		{name: "create without items", method: http.MethodPost, path: "/v1/purchase_order", body: &models.CreatePurchaseOrder{SupplierId: supplierId}, status: http.StatusBadRequest},
+		{name: "create invalid date", method: http.MethodPost, path: "/v1/purchase_order", body: &models.CreatePurchaseOrder{SupplierId: supplierId, ExpectedDate: "soon", Items: create.Items}, status: http.StatusBadRequest},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/purchase_order", body: "order", status: http.StatusBadRequest},
+		{name: "create unknown supplier", method: http.MethodPost, path: "/v1/purchase_order", body: &models.CreatePurchaseOrder{SupplierId: 99, Items: create.Items}, auth: manager, status: http.StatusUnprocessableEntity},
+		{name: "create for other store", method: http.MethodPost, path: "/v1/purchase_order", body: create, auth: otherManager, status: http.StatusForbidden},
+		{name: "create as cashier", method: http.MethodPost, path: "/v1/purchase_order", body: create, auth: cashier, status: http.StatusForbidden},
+		{name: "receive draft", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(1, 4), status: http.StatusConflict},
+		{name: "submit", method: http.MethodPut, path: "/v1/purchase_order/1/submit", auth: manager, status: http.StatusOK, check: status(models.PurchaseOrderStatusOrdered)},
+		{name: "get", method: http.MethodGet, path: "/v1/purchase_order/1", auth: cashier, status: http.StatusOK, check: status(models.PurchaseOrderStatusOrdered)},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/purchase_order/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/purchase_order/99", status: http.StatusNotFound},
+		{name: "get other store", method: http.MethodGet, path: "/v1/purchase_order/1", auth: otherManager, status: http.StatusForbidden},
+		{name: "receive part", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(1, 4), auth: manager, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var order models.PurchaseOrder
+				decode(t, data, &order)
+				assert.Equal(t, models.PurchaseOrderStatusPartiallyReceived, order.Status)
+				assert.Equal(t, 4, order.Items[0].ReceivedQuantity)
+			}},
+		stockLeft(9),
+		{name: "receive more than ordered", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(1, 7), status: http.StatusUnprocessableEntity},
+		{name: "receive unknown item", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(99, 1), status: http.StatusNotFound},
+		{name: "receive without items", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: &models.ReceivePurchaseOrder{}, status: http.StatusBadRequest},
+		{name: "receive in other store", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(1, 1), auth: otherManager, status: http.StatusForbidden},
+		{name: "cancel partially received", method: http.MethodPut, path: "/v1/purchase_order/1/cancel", status: http.StatusConflict},
+		{name: "receive rest", method: http.MethodPost, path: "/v1/purchase_order/1/receive", body: receive(1, 6), status: http.StatusOK, check: status(models.PurchaseOrderStatusReceived)},
+		stockLeft(15),
+		{name: "close received", method: http.MethodPut, path: "/v1/purchase_order/1/close", status: http.StatusConflict},
+		{name: "history", method: http.MethodGet, path: "/v1/purchase_order/1/history", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var history models.GetPurchaseOrderStatusHistoryResponse
+				decode(t, data, &history)
+				assert.Equal(t, 4, history.Count)
+			}},
+		{name: "history not found", method: http.MethodGet, path: "/v1/purchase_order/99/history", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: fmt.Sprintf("/v1/purchase_order?supplier_id=%d", supplierId), status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListPurchaseOrderResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list scoped", method: http.MethodGet, path: "/v1/purchase_order", auth: otherManager, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListPurchaseOrderResponse
+				decode(t, data, &list)
+				assert.Equal(t, 0, list.Count)
+			}},
+		{name: "list invalid status", method: http.MethodGet, path: "/v1/purchase_order?status=9", status: http.StatusBadRequest},
+		{name: "delete supplier with orders", method: http.MethodDelete, path: fmt.Sprintf("/v1/supplier/%d", supplierId), status: http.StatusUnprocessableEntity},
+		{name: "create second", method: http.MethodPost, path: "/v1/purchase_order", body: create, status: http.StatusCreated},
+		{name: "cancel draft", method: http.MethodPut, path: "/v1/purchase_order/2/cancel", body: &models.ChangePurchaseOrderStatus{Comment: "ordered elsewhere"}, status: http.StatusOK, check: status(models.PurchaseOrderStatusCancelled)},
+		{name: "submit cancelled", method: http.MethodPut, path: "/v1/purchase_order/2/submit", status: http.StatusConflict},
+		{name: "delete as manager", method: http.MethodDelete, path: "/v1/purchase_order/2", auth: manager, status: http.StatusForbidden},
+		{name: "delete", method: http.MethodDelete, path: "/v1/purchase_order/2", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/purchase_order/2", status: http.StatusNotFound},
+		stockLeft(15),
+	})
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"app/storage"
+	"app/storage/postgresql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestListQuerySearchIsBound(t *testing.T) {
+	malicious := []string{
+		"' OR 1=1 --",
+		"'; DROP TABLE brands; --",
+		"%' UNION SELECT user_id, password FROM users --",
+		`\'; SELECT pg_sleep(10); --`,
+		"? OR TRUE",
+	}
+
+	for _, search := range malicious {
+		q := postgresql.NewListQuery(`SELECT brand_id, brand_name FROM brands`)
+		q.Search(search, "brand_name")
+
+		query, args := q.Build()
+
+		assert.NotContains(t, query, search)
+		assert.Equal(t, "SELECT brand_id, brand_name FROM brands WHERE (brand_name ILIKE $1) OFFSET $2 LIMIT $3", query)
+		assert.Equal(t, []interface{}{"%" + postgresql.EscapeLike(search) + "%", 0, 10}, args)
+	}
+}
+
+func TestListQueryEscapesLikeWildcards(t *testing.T) {
+	assert.Equal(t, `100\% \_off\\`, postgresql.EscapeLike(`100% _off\`))
+
+	q := postgresql.NewListQuery(`SELECT 1 FROM products`)
+	q.Search("%", "product_name")
+
+	_, args := q.Build()
+	assert.Equal(t, `%\%%`, args[0])
+}
+
+func TestListQueryNumbersPlaceholders(t *testing.T) {
+	q := postgresql.NewListQuery(`SELECT o.order_id FROM orders AS o WHERE o.order_status <> $1`, 3)
+	q.Where("o.store_id = ?", 2)
+	q.Search("anna", "c.first_name", "c.last_name")
+	q.Where("o.order_date BETWEEN ? AND ?", "2017-01-01", "2017-12-31")
+	q.OrderBy("o.order_id")
+	q.Paginate(20, 5)
+
+	query, args := q.Build()
+
+	assert.True(t, strings.HasSuffix(query,
+		" WHERE o.store_id = $2 AND (c.first_name ILIKE $3 OR c.last_name ILIKE $4)"+
+			" AND o.order_date BETWEEN $5 AND $6 ORDER BY o.order_id OFFSET $7 LIMIT $8"), query)
+	assert.Equal(t, []interface{}{3, 2, "%anna%", "%anna%", "2017-01-01", "2017-12-31", 20, 5}, args)
+
+	count, countArgs := q.BuildCount()
+	assert.NotContains(t, count, "LIMIT")
+	assert.Equal(t, args[:6], countArgs)
+
+	all, allArgs := q.BuildAll()
+	assert.True(t, strings.HasSuffix(all, " BETWEEN $5 AND $6 ORDER BY o.order_id"), all)
+	assert.Equal(t, args[:6], allArgs)
+}
+
+func TestListQueryPaginateDefaults(t *testing.T) {
+	q := postgresql.NewListQuery(`SELECT 1 FROM stores`)
+	q.Paginate(-5, 0)
+
+	query, args := q.Build()
+	assert.Equal(t, "SELECT 1 FROM stores OFFSET $1 LIMIT $2", query)
+	assert.Equal(t, []interface{}{0, 10}, args)
+}
+
+func TestListQuerySort(t *testing.T) {
+	columns := map[string]string{"list_price": "p.list_price", "product_name": "p.product_name"}
+
+	q := postgresql.NewListQuery(`SELECT 1 FROM products AS p`)
+	err := q.Sort("-list_price, product_name", columns)
+	assert.NoError(t, err)
+
+	query, _ := q.Build()
+	assert.Contains(t, query, " ORDER BY p.list_price DESC, p.product_name ASC OFFSET")
+
+	err = postgresql.NewListQuery(`SELECT 1 FROM products AS p`).Sort("list_price; DROP TABLE products", columns)
+	assert.True(t, errors.Is(err, storage.ErrInvalidSort))
+}
+
+func TestListQueryKeyset(t *testing.T) {
+	q := postgresql.NewListQuery(`SELECT customer_id FROM customers`)
+	q.OrderBy("customer_id")
+	q.Paginate(40, 20)
+
+	count, _ := q.BuildCount()
+
+	err := q.Keyset("customer_id", postgresql.NextCursor(57))
+	assert.NoError(t, err)
+
+	query, args := q.Build()
+	assert.Equal(t, "SELECT customer_id FROM customers WHERE customer_id > $1 ORDER BY customer_id OFFSET $2 LIMIT $3", query)
+	assert.Equal(t, []interface{}{57, 0, 21}, args)
+	assert.NotContains(t, count, "customer_id >")
+
+	assert.True(t, q.HasMore(21))
+	assert.False(t, q.HasMore(20))
+
+	err = postgresql.NewListQuery(`SELECT 1 FROM customers`).Keyset("customer_id", "not a cursor")
+	assert.True(t, errors.Is(err, storage.ErrInvalidCursor))
+}
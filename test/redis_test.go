@@ -0,0 +1,31 @@
+package test
+
+import (
+	"app/config"
+	"app/pkg/logger"
+	"app/storage"
+	"app/storage/redis"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestRedisDownFailsTokenLookupsClosed(t *testing.T) {
+	// nothing listens on port 1, the store starts on its in-memory fallback
+	cfg := &config.Config{RedisHost: "127.0.0.1", RedisPort: ":1", CacheNamespace: "test"}
+	cache := redis.NewConnectRedis(cfg, logger.NewLogger("test", logger.LevelPanic))
+	defer cache.CloseDB()
+
+	// another instance may have revoked it, this one can not tell
+	revoked, err := cache.Token().IsRevoked("other")
+	assert.True(t, errors.Is(err, storage.ErrCacheUnavailable), err)
+	assert.False(t, revoked)
+
+	// revoking still works and this instance knows about it
+	assert.NoError(t, cache.Token().Revoke("mine", time.Minute))
+	revoked, err = cache.Token().IsRevoked("mine")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"app/api/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestReportHandlers(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	otherId, err := s.store.Store().Create(ctx, &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"})
+	assert.NoError(t, err)
+	_, err = s.store.Stock().Create(ctx, &models.CreateStock{StoreId: otherId, ProductId: fixture.productId})
+	assert.NoError(t, err)
+	_, err = s.store.Order().Place(ctx, fixture.placeOrder(2))
+	assert.NoError(t, err)
+	_, err = s.store.Promocode().Create(ctx, &models.CreatePromocode{PromocodeName: "SPRING", Discount: 10, DiscountType: 2})
+	assert.NoError(t, err)
+
+	cashier := s.login(t, models.RoleCashier, fixture.storeId)
+	otherCashier := s.login(t, models.RoleCashier, otherId)
+	send := func(quantity int) *models.SendProduct {
+		return &models.SendProduct{SenderId: fixture.storeId, ReceiverId: otherId, ProductId: fixture.productId, Quantity: quantity}
+	}
+	totalSum := func(sum string) func(t *testing.T, data json.RawMessage) {
+		return func(t *testing.T, data json.RawMessage) {
+			var total string
+			decode(t, data, &total)
+			assert.Equal(t, sum, total)
+		}
+	}
+
+	s.run(t, []handlerCase{
+		{name: "send product", method: http.MethodPut, path: "/v1/report/send_product", body: send(1), status: http.StatusOK},
+		{name: "send more than stocked", method: http.MethodPut, path: "/v1/report/send_product", body: send(10), status: http.StatusUnprocessableEntity},
+		{name: "send nothing", method: http.MethodPut, path: "/v1/report/send_product", body: send(0), status: http.StatusUnprocessableEntity},
+		{name: "send unstocked product", method: http.MethodPut, path: "/v1/report/send_product", body: &models.SendProduct{SenderId: fixture.storeId, ReceiverId: otherId, ProductId: 99, Quantity: 1}, status: http.StatusNotFound},
+		{name: "send invalid body", method: http.MethodPut, path: "/v1/report/send_product", body: "one", status: http.StatusBadRequest},
+		{name: "send as cashier", method: http.MethodPut, path: "/v1/report/send_product", body: send(1), auth: cashier, status: http.StatusForbidden},
+		{name: "staff report", method: http.MethodGet, path: "/v1/report/staff_report?search=fabiola", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var report models.StaffListResponse
+				decode(t, data, &report)
+				assert.Equal(t, 1, report.Count)
+				assert.Equal(t, 2, report.StaffReport[0].Quantity)
+			}},
+		{name: "staff report invalid offset", method: http.MethodGet, path: "/v1/report/staff_report?offset=x", status: http.StatusBadRequest},
+		{name: "staff report as cashier", method: http.MethodGet, path: "/v1/report/staff_report", auth: cashier, status: http.StatusForbidden},
+		{name: "stock reconciliation", method: http.MethodGet, path: "/v1/report/stock_reconciliation", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var report models.StockReconciliationResponse
+				decode(t, data, &report)
+				assert.Equal(t, 0, report.Count)
+			}},
+		{name: "stock reconciliation invalid limit", method: http.MethodGet, path: "/v1/report/stock_reconciliation?limit=x", status: http.StatusBadRequest},
+		{name: "total sum", method: http.MethodGet, path: "/v1/report/total_sum?order_id=1", status: http.StatusOK, check: totalSum("759.98")},
+		{name: "total sum with promocode", method: http.MethodGet, path: "/v1/report/total_sum?order_id=1&promocode_name=spring", auth: cashier, status: http.StatusOK, check: totalSum("683.98")},
+		{name: "total sum invalid order", method: http.MethodGet, path: "/v1/report/total_sum?order_id=one", status: http.StatusBadRequest},
+		{name: "total sum not found", method: http.MethodGet, path: "/v1/report/total_sum?order_id=99", status: http.StatusNotFound},
+		{name: "total sum other store", method: http.MethodGet, path: "/v1/report/total_sum?order_id=1", auth: otherCashier, status: http.StatusForbidden},
+	})
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestStaffHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	create := &models.CreateStaff{FirstName: "Mireya", LastName: "Copeland", Email: "mireya.copeland@bikes.shop", Active: 1, StoreId: fixture.storeId, ManagerId: fixture.staffId}
+	update := &models.UpdateStaff{FirstName: "Mireya", LastName: "Copeland", Email: "mireya@bikes.shop", Active: 0, StoreId: fixture.storeId, ManagerId: fixture.staffId}
+	path := fmt.Sprintf("/v1/staff/%d", fixture.staffId+1)
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/staff", body: create, status: http.StatusCreated,
+			check: func(t *testing.T, data json.RawMessage) {
+				var staff models.Staff
+				decode(t, data, &staff)
+				assert.Equal(t, fixture.staffId+1, staff.StaffId)
+				assert.Equal(t, fixture.storeId, staff.StoreData.StoreId)
+			}},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/staff", body: "Mireya", status: http.StatusBadRequest},
+		{name: "create duplicate email", method: http.MethodPost, path: "/v1/staff", body: create, status: http.StatusConflict},
+		{name: "create unknown store", method: http.MethodPost, path: "/v1/staff", body: &models.CreateStaff{FirstName: "Genna", Email: "genna@bikes.shop", StoreId: 99}, status: http.StatusUnprocessableEntity},
+		{name: "get", method: http.MethodGet, path: path, status: http.StatusOK},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/staff/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/staff/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/staff?search=copeland", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListStaffResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid offset", method: http.MethodGet, path: "/v1/staff?offset=first", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: path, body: update, status: http.StatusOK},
+		{name: "update invalid body", method: http.MethodPut, path: path, body: "Mireya", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/staff/99", body: update, status: http.StatusNotFound},
+		{name: "delete manager", method: http.MethodDelete, path: fmt.Sprintf("/v1/staff/%d", fixture.staffId), status: http.StatusUnprocessableEntity},
+		{name: "delete", method: http.MethodDelete, path: path, status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: path, status: http.StatusNotFound},
+	})
+}
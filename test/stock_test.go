@@ -0,0 +1,59 @@
+package test
+
+import (
+	"app/api/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestStockHandlers(t *testing.T) {
+	s := newMemoryServer()
+	fixture := seedShop(t, s.store)
+
+	other := &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop"}
+	otherId, err := s.store.Store().Create(context.Background(), other)
+	assert.NoError(t, err)
+
+	manager := s.login(t, models.RoleStoreManager, fixture.storeId)
+	path := fmt.Sprintf("/v1/stock/%d", otherId)
+	updatePath := fmt.Sprintf("/v1/stock?storeId=%d&productId=%d", otherId, fixture.productId)
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/stock", body: &models.CreateStock{StoreId: otherId, ProductId: fixture.productId, Quantity: 2}, status: http.StatusCreated},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/stock", body: "two", status: http.StatusBadRequest},
+		{name: "create duplicate", method: http.MethodPost, path: "/v1/stock", body: &models.CreateStock{StoreId: otherId, ProductId: fixture.productId, Quantity: 2}, status: http.StatusConflict},
+		{name: "create unknown product", method: http.MethodPost, path: "/v1/stock", body: &models.CreateStock{StoreId: otherId, ProductId: 99, Quantity: 2}, status: http.StatusUnprocessableEntity},
+		{name: "create in other store", method: http.MethodPost, path: "/v1/stock", body: &models.CreateStock{StoreId: otherId, ProductId: fixture.productId, Quantity: 2}, auth: manager, status: http.StatusForbidden},
+		{name: "get", method: http.MethodGet, path: path, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var stock models.GetStock
+				decode(t, data, &stock)
+				assert.Equal(t, 2, stock.Products[0].Quantity)
+			}},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/stock/one", status: http.StatusBadRequest},
+		{name: "get other store", method: http.MethodGet, path: path, auth: manager, status: http.StatusForbidden},
+		{name: "list", method: http.MethodGet, path: "/v1/stock", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListStockResponse
+				decode(t, data, &list)
+				assert.Equal(t, 2, list.Count)
+			}},
+		{name: "list scoped", method: http.MethodGet, path: "/v1/stock", auth: manager, status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListStockResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid limit", method: http.MethodGet, path: "/v1/stock?limit=x", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: updatePath, body: &models.UpdateStock{Quantity: 7}, status: http.StatusOK},
+		{name: "update invalid store", method: http.MethodPut, path: "/v1/stock?storeId=x&productId=1", body: &models.UpdateStock{Quantity: 7}, status: http.StatusBadRequest},
+		{name: "update invalid body", method: http.MethodPut, path: updatePath, body: "seven", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: fmt.Sprintf("/v1/stock?storeId=%d&productId=99", otherId), body: &models.UpdateStock{Quantity: 7}, status: http.StatusNotFound},
+		{name: "update other store", method: http.MethodPut, path: updatePath, body: &models.UpdateStock{Quantity: 7}, auth: manager, status: http.StatusForbidden},
+	})
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"app/api/models"
+	"app/config"
+	"app/migrations"
+	"app/storage"
+	"app/storage/memory"
+	"app/storage/postgresql"
+	"app/storage/storagetest"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func TestMemoryStorageContract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.StorageI {
+		return memory.NewStorage()
+	})
+}
+
+// TestPostgresStorageContract runs the contract against the database from the config. Every
+// table is truncated before each test, so it only runs when TEST_POSTGRES=true.
+func TestPostgresStorageContract(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") != "true" {
+		t.Skip("set TEST_POSTGRES=true to run against postgres, it truncates every table")
+	}
+
+	cfg := config.Load()
+
+	conn, err := pgx.Connect(context.Background(), fmt.Sprintf(
+		"host=%s user=%s dbname=%s password=%s port=%s sslmode=disable",
+		cfg.PostgresHost,
+		cfg.PostgresUser,
+		cfg.PostgresDatabase,
+		cfg.PostgresPassword,
+		cfg.PostgresPort,
+	))
+	require.NoError(t, err)
+	defer conn.Close(context.Background())
+
+	storagetest.Run(t, func(t *testing.T) storage.StorageI {
+		_, err := conn.Exec(context.Background(), `
+			TRUNCATE
+				categories, brands, products, customers, stores, staffs, orders, order_items,
+				stocks, promocodes, users, refresh_tokens, order_status_history, stock_movements,
+				suppliers, purchase_orders, purchase_order_items, purchase_order_status_history
+			RESTART IDENTITY CASCADE
+		`)
+		require.NoError(t, err)
+
+		store, err := postgresql.NewConnectPostgresql(&cfg)
+		require.NoError(t, err)
+		t.Cleanup(store.CloseDB)
+
+		return store
+	})
+}
+
+// TestPostgresSeedLedger loads the BikeStores sample data into empty tables and checks that
+// every order it inserts has its stock movements, so the reconciliation report starts clean.
+func TestPostgresSeedLedger(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") != "true" {
+		t.Skip("set TEST_POSTGRES=true to run against postgres, it truncates every table")
+	}
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	db, err := postgresql.NewPool(&cfg)
+	require.NoError(t, err)
+	store := postgresql.NewStore(db)
+	defer store.CloseDB()
+
+	_, err = db.Exec(ctx, `
+		TRUNCATE
+			categories, brands, products, customers, stores, staffs, orders, order_items,
+			stocks, promocodes, users, refresh_tokens, order_status_history, stock_movements,
+			suppliers, purchase_orders, purchase_order_items, purchase_order_status_history
+		RESTART IDENTITY CASCADE
+	`)
+	require.NoError(t, err)
+
+	script, err := fs.ReadFile(migrations.Postgres(), "03_insert_tables.up.sql")
+	require.NoError(t, err)
+	require.NoError(t, postgresql.RunScript(ctx, db, string(script)))
+
+	var movements int
+	require.NoError(t, db.QueryRow(ctx, `SELECT COUNT(*) FROM stock_movements`).Scan(&movements))
+	assert.NotZero(t, movements)
+
+	reconciliation, err := store.Report().StockReconciliation(ctx, &models.StockReconciliationRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, reconciliation.Count)
+}
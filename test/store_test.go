@@ -0,0 +1,42 @@
+package test
+
+import (
+	"app/api/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestStoreHandlers(t *testing.T) {
+	s := newMemoryServer()
+
+	create := &models.CreateStore{StoreName: "Baldwin Bikes", Email: "baldwin@bikes.shop", City: "Baldwin", State: "NY"}
+	update := &models.UpdateStore{StoreName: "Rowlett Bikes", Email: "rowlett@bikes.shop", City: "Rowlett", State: "TX"}
+
+	s.run(t, []handlerCase{
+		{name: "create", method: http.MethodPost, path: "/v1/store", body: create, status: http.StatusCreated},
+		{name: "create invalid body", method: http.MethodPost, path: "/v1/store", body: "Baldwin Bikes", status: http.StatusBadRequest},
+		{name: "get", method: http.MethodGet, path: "/v1/store/1", status: http.StatusCreated},
+		{name: "get invalid id", method: http.MethodGet, path: "/v1/store/one", status: http.StatusBadRequest},
+		{name: "get not found", method: http.MethodGet, path: "/v1/store/99", status: http.StatusNotFound},
+		{name: "list", method: http.MethodGet, path: "/v1/store?search=baldwin", status: http.StatusOK,
+			check: func(t *testing.T, data json.RawMessage) {
+				var list models.GetListStoreResponse
+				decode(t, data, &list)
+				assert.Equal(t, 1, list.Count)
+			}},
+		{name: "list invalid limit", method: http.MethodGet, path: "/v1/store?limit=all", status: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/v1/store/1", body: update, status: http.StatusAccepted,
+			check: func(t *testing.T, data json.RawMessage) {
+				var store models.Store
+				decode(t, data, &store)
+				assert.Equal(t, "Rowlett Bikes", store.StoreName)
+			}},
+		{name: "update invalid body", method: http.MethodPut, path: "/v1/store/1", body: "Rowlett Bikes", status: http.StatusBadRequest},
+		{name: "update not found", method: http.MethodPut, path: "/v1/store/99", body: update, status: http.StatusNotFound},
+		{name: "delete", method: http.MethodDelete, path: "/v1/store/1", status: http.StatusNoContent},
+		{name: "delete not found", method: http.MethodDelete, path: "/v1/store/1", status: http.StatusNotFound},
+	})
+}